@@ -6,7 +6,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/kalo-build/morphe-go/pkg/yaml"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 )
 
 type CompileConfig struct {
@@ -80,6 +84,128 @@ func main() {
 		logInfo(compileConfig.Verbose, "Ordered migrations enabled - files will have numeric prefixes")
 	}
 
+	// Check for rollbacks config option, writing up/down migration pairs for every file
+	if rollbacks, ok := compileConfig.Config["rollbacks"].(bool); ok && rollbacks {
+		for _, tableWriter := range []write.PSQLTableWriter{morpheConfig.EnumWriter, morpheConfig.ModelWriter, morpheConfig.StructureWriter} {
+			if tableFileWriter, isFileWriter := tableWriter.(*compile.MorpheTableFileWriter); isFileWriter {
+				tableFileWriter.EnableRollbacks = true
+			}
+		}
+		if enumTypeFileWriter, isFileWriter := morpheConfig.EnumTypeWriter.(*compile.MorpheEnumTypeFileWriter); isFileWriter {
+			enumTypeFileWriter.EnableRollbacks = true
+		}
+		if viewFileWriter, isFileWriter := morpheConfig.EntityWriter.(*compile.MorpheViewFileWriter); isFileWriter {
+			viewFileWriter.EnableRollbacks = true
+		}
+		if migrationFileWriter, isFileWriter := morpheConfig.MigrationWriter.(*compile.MorpheMigrationFileWriter); isFileWriter {
+			migrationFileWriter.EnableRollbacks = true
+		}
+		logInfo(compileConfig.Verbose, "Rollbacks enabled - files are written as .up.sql/.down.sql pairs")
+	}
+
+	// Check for consolidated schema config option
+	if consolidatedSchema, ok := compileConfig.Config["consolidatedSchema"].(bool); ok && consolidatedSchema {
+		morpheConfig.SchemaWriter = &compile.MorpheSchemaFileWriter{
+			TargetFilePath: filepath.Join(compileConfig.OutputPath, compile.SchemaFileName),
+		}
+		logInfo(compileConfig.Verbose, "Consolidated schema enabled - all definitions are written to '%s'", compile.SchemaFileName)
+	}
+
+	// Check for native enums config option
+	if nativeEnums, ok := compileConfig.Config["nativeEnums"].(bool); ok && nativeEnums {
+		morpheConfig.MorpheEnumsConfig.UseNativeEnums = true
+		logInfo(compileConfig.Verbose, "Native enums enabled - enums compile to PostgreSQL ENUM types")
+	}
+
+	// Check for polymorphic triggers config option
+	if polymorphicTriggers, ok := compileConfig.Config["polymorphicTriggers"].(bool); ok && polymorphicTriggers {
+		morpheConfig.MorpheModelsConfig.EnablePolymorphicTriggers = true
+		logInfo(compileConfig.Verbose, "Polymorphic triggers enabled - polymorphic relations are checked by trigger functions")
+	}
+
+	// Check for junction composite keys config option
+	if junctionCompositeKeys, ok := compileConfig.Config["junctionCompositeKeys"].(bool); ok && junctionCompositeKeys {
+		morpheConfig.MorpheModelsConfig.JunctionCompositeKeys = true
+		logInfo(compileConfig.Verbose, "Junction composite keys enabled - junction tables are keyed by their foreign key columns")
+	}
+
+	// Check for audit columns config options
+	if auditColumns, ok := compileConfig.Config["auditColumns"].(bool); ok && auditColumns {
+		morpheConfig.MorpheModelsConfig.AuditColumns = true
+		logInfo(compileConfig.Verbose, "Audit columns enabled - model tables get created_at/updated_at columns")
+	}
+	if auditJunctionTables, ok := compileConfig.Config["auditJunctionTables"].(bool); ok && auditJunctionTables {
+		morpheConfig.MorpheModelsConfig.AuditJunctionTables = true
+		logInfo(compileConfig.Verbose, "Junction table audit columns enabled")
+	}
+
+	// Check for default referential action config options, applied to foreign keys without relation options
+	if defaultOnDelete, ok := compileConfig.Config["defaultOnDelete"].(string); ok && defaultOnDelete != "" {
+		morpheConfig.MorpheModelsConfig.DefaultOnDelete = defaultOnDelete
+		logInfo(compileConfig.Verbose, "Foreign keys default to ON DELETE %s", defaultOnDelete)
+	}
+	if defaultOnUpdate, ok := compileConfig.Config["defaultOnUpdate"].(string); ok && defaultOnUpdate != "" {
+		morpheConfig.MorpheModelsConfig.DefaultOnUpdate = defaultOnUpdate
+		logInfo(compileConfig.Verbose, "Foreign keys default to ON UPDATE %s", defaultOnUpdate)
+	}
+
+	// Check for tenancy config option, scoping model and junction tables to tenants
+	if tenancy, ok := compileConfig.Config["tenancy"].(map[string]any); ok {
+		columnName, _ := tenancy["columnName"].(string)
+		settingName, _ := tenancy["settingName"].(string)
+		fieldType, _ := tenancy["fieldType"].(string)
+		morpheConfig.MorpheModelsConfig.Tenancy = cfg.MorpheTenancyConfig{
+			ColumnName:  columnName,
+			FieldType:   yaml.ModelFieldType(fieldType),
+			SettingName: settingName,
+		}
+		logInfo(compileConfig.Verbose, "Tenancy enabled - tables are scoped to tenants by '%s'", columnName)
+	}
+
+	// Check for per-model options, e.g. relation referential actions, soft deletes and partitions
+	if rawModelOptions, ok := compileConfig.Config["models"]; ok {
+		modelOptions, modelOptionsErr := parseModelOptions(rawModelOptions)
+		if modelOptionsErr != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing models config:", modelOptionsErr)
+			os.Exit(ErrInvalidConfig)
+		}
+		morpheConfig.MorpheModelsConfig.ModelOptions = modelOptions
+		logInfo(compileConfig.Verbose, "Model options set for %d models", len(modelOptions))
+	}
+
+	// Check for per-entity options, e.g. materialized and writable views
+	if rawEntityOptions, ok := compileConfig.Config["entities"]; ok {
+		entityOptions, entityOptionsErr := parseEntityOptions(rawEntityOptions)
+		if entityOptionsErr != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing entities config:", entityOptionsErr)
+			os.Exit(ErrInvalidConfig)
+		}
+		morpheConfig.MorpheEntitiesConfig.EntityOptions = entityOptions
+		logInfo(compileConfig.Verbose, "Entity options set for %d entities", len(entityOptions))
+	}
+	if enumColumn, ok := compileConfig.Config["enumColumn"].(string); ok && enumColumn != "" {
+		morpheConfig.MorpheEntitiesConfig.EnumColumn = enumColumn
+		logInfo(compileConfig.Verbose, "Entity views project the enum %s of enum fields", enumColumn)
+	}
+
+	// Check for migrations config option, diffing against the schema snapshot of the previous run
+	if migrations, ok := compileConfig.Config["migrations"].(bool); ok && migrations {
+		snapshotPath := filepath.Join(compileConfig.OutputPath, compile.SchemaSnapshotFileName)
+		previousSnapshot, readSnapshotErr := compile.ReadSchemaSnapshotFile(snapshotPath)
+		if readSnapshotErr == nil {
+			morpheConfig.PreviousTables = previousSnapshot.GetTables()
+			morpheConfig.PreviousViews = previousSnapshot.GetViews()
+			logInfo(compileConfig.Verbose, "Migrations enabled - diffing against schema snapshot '%s'", snapshotPath)
+		} else if os.IsNotExist(readSnapshotErr) {
+			morpheConfig.PreviousTables = []*psqldef.Table{}
+			morpheConfig.PreviousViews = []*psqldef.View{}
+			logInfo(compileConfig.Verbose, "Migrations enabled - no previous schema snapshot found at '%s'", snapshotPath)
+		} else {
+			fmt.Fprintln(os.Stderr, "Error reading previous schema snapshot:", readSnapshotErr)
+			os.Exit(ErrInvalidConfig)
+		}
+	}
+
 	logInfo(compileConfig.Verbose, "Starting compilation process...")
 	compileErr := compile.MorpheToPSQL(morpheConfig)
 	if compileErr != nil {
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+)
+
+// parseModelOptions reads the "models" config option, holding the options of each model by model name, e.g.
+// {"Person": {"relations": {"Company": {"onDelete": "RESTRICT"}}, "softDelete": true}}
+func parseModelOptions(rawOptions any) (map[string]cfg.MorpheModelOptions, error) {
+	modelOptions := map[string]cfg.MorpheModelOptions{}
+	decodeErr := decodeOptions(rawOptions, &modelOptions)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return modelOptions, nil
+}
+
+// parseEntityOptions reads the "entities" config option, holding the options of each entity by entity name, e.g.
+// {"Person": {"materialized": true}}
+func parseEntityOptions(rawOptions any) (map[string]cfg.MorpheEntityOptions, error) {
+	entityOptions := map[string]cfg.MorpheEntityOptions{}
+	decodeErr := decodeOptions(rawOptions, &entityOptions)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return entityOptions, nil
+}
+
+// decodeOptions decodes a JSON config option into its cfg struct, matching keys case-insensitively like
+// encoding/json and rejecting unknown keys, so that misspelled options are not silently ignored
+func decodeOptions(rawOptions any, options any) error {
+	optionsJSON, marshalErr := json.Marshal(rawOptions)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	decoder := json.NewDecoder(bytes.NewReader(optionsJSON))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(options)
+}
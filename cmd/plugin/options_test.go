@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/stretchr/testify/suite"
+)
+
+type OptionsTestSuite struct {
+	suite.Suite
+}
+
+func TestOptionsTestSuite(t *testing.T) {
+	suite.Run(t, new(OptionsTestSuite))
+}
+
+// getRawConfig returns the plugin config as the plugin reads it from its JSON argument
+func (suite *OptionsTestSuite) getRawConfig(configJSON string) map[string]any {
+	var compileConfig CompileConfig
+	unmarshalErr := json.Unmarshal([]byte(`{"inputPath":"in","outputPath":"out","config":`+configJSON+`}`), &compileConfig)
+	suite.Require().Nil(unmarshalErr)
+	return compileConfig.Config
+}
+
+func (suite *OptionsTestSuite) TestParseModelOptions() {
+	rawConfig := suite.getRawConfig(`{
+		"models": {
+			"Person": {
+				"relations": {
+					"Company": {"onDelete": "RESTRICT", "onUpdate": "CASCADE"},
+					"Manager": {"onDelete": "SET NULL", "optional": true}
+				},
+				"softDelete": true
+			},
+			"Playlist": {
+				"relations": {
+					"Tracks": {
+						"fields": {
+							"Position": {"type": "Integer"},
+							"AddedAt": {"type": "Time", "attributes": ["optional"]}
+						},
+						"orderField": "Position"
+					}
+				}
+			},
+			"Event": {
+				"partition": {
+					"strategy": "RANGE",
+					"keyFields": ["OccurredAt"],
+					"partitions": [
+						{"name": "2024_01", "from": ["2024-01-01"], "to": ["2024-02-01"]},
+						{"name": "default", "default": true}
+					]
+				}
+			}
+		}
+	}`)
+
+	modelOptions, parseErr := parseModelOptions(rawConfig["models"])
+
+	suite.Nil(parseErr)
+	suite.Len(modelOptions, 3)
+
+	personOptions := modelOptions["Person"]
+	suite.True(personOptions.SoftDelete)
+	suite.Equal(cfg.MorpheRelationOptions{OnDelete: cfg.ReferentialActionRestrict, OnUpdate: cfg.ReferentialActionCascade}, personOptions.Relations["Company"])
+	suite.Equal(cfg.MorpheRelationOptions{OnDelete: cfg.ReferentialActionSetNull, Optional: true}, personOptions.Relations["Manager"])
+
+	tracksOptions := modelOptions["Playlist"].Relations["Tracks"]
+	suite.Equal("Position", tracksOptions.OrderField)
+	suite.Equal(yaml.ModelField{Type: yaml.ModelFieldTypeInteger}, tracksOptions.Fields["Position"])
+	suite.Equal(yaml.ModelField{Type: yaml.ModelFieldTypeTime, Attributes: []string{"optional"}}, tracksOptions.Fields["AddedAt"])
+
+	eventPartition := modelOptions["Event"].Partition
+	suite.Equal(cfg.PartitionStrategyRange, eventPartition.Strategy)
+	suite.Equal([]string{"OccurredAt"}, eventPartition.KeyFields)
+	suite.Equal([]cfg.MorphePartition{
+		{Name: "2024_01", From: []string{"2024-01-01"}, To: []string{"2024-02-01"}},
+		{Name: "default", Default: true},
+	}, eventPartition.Partitions)
+
+	for modelName, options := range modelOptions {
+		suite.Nil(options.Validate(), modelName)
+	}
+}
+
+func (suite *OptionsTestSuite) TestParseModelOptions_UnknownOption() {
+	rawConfig := suite.getRawConfig(`{"models": {"Person": {"relations": {"Company": {"onDelte": "RESTRICT"}}}}}`)
+
+	modelOptions, parseErr := parseModelOptions(rawConfig["models"])
+
+	suite.ErrorContains(parseErr, `unknown field "onDelte"`)
+	suite.Nil(modelOptions)
+}
+
+func (suite *OptionsTestSuite) TestParseModelOptions_InvalidType() {
+	rawConfig := suite.getRawConfig(`{"models": {"Person": {"softDelete": "yes"}}}`)
+
+	modelOptions, parseErr := parseModelOptions(rawConfig["models"])
+
+	suite.NotNil(parseErr)
+	suite.Nil(modelOptions)
+}
+
+func (suite *OptionsTestSuite) TestParseEntityOptions() {
+	rawConfig := suite.getRawConfig(`{
+		"entities": {
+			"Company": {"materialized": true},
+			"Person": {"writable": true}
+		}
+	}`)
+
+	entityOptions, parseErr := parseEntityOptions(rawConfig["entities"])
+
+	suite.Nil(parseErr)
+	suite.Equal(map[string]cfg.MorpheEntityOptions{
+		"Company": {Materialized: true},
+		"Person":  {Writable: true},
+	}, entityOptions)
+}
+
+func (suite *OptionsTestSuite) TestParseEntityOptions_UnknownOption() {
+	rawConfig := suite.getRawConfig(`{"entities": {"Person": {"materialised": true}}}`)
+
+	entityOptions, parseErr := parseEntityOptions(rawConfig["entities"])
+
+	suite.ErrorContains(parseErr, `unknown field "materialised"`)
+	suite.Nil(entityOptions)
+}
@@ -0,0 +1,65 @@
+package compile
+
+import (
+	"fmt"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// Names of the audit columns and of the shared trigger function keeping updated_at current
+const (
+	createdAtColumnName   = "created_at"
+	updatedAtColumnName   = "updated_at"
+	updatedAtFunctionName = "set_updated_at"
+)
+
+// addAuditColumns adds created_at and updated_at columns to a table, with a BEFORE UPDATE trigger setting updated_at
+func addAuditColumns(table *psqldef.Table) error {
+	for _, column := range table.Columns {
+		if column.Name == createdAtColumnName || column.Name == updatedAtColumnName {
+			return fmt.Errorf("%w: %s.%s", ErrAuditColumnConflict, table.Name, column.Name)
+		}
+	}
+
+	table.Columns = append(table.Columns,
+		psqldef.TableColumn{
+			Name:    createdAtColumnName,
+			Type:    psqldef.PSQLTypeTimestampTZ,
+			NotNull: true,
+			Default: "NOW()",
+		},
+		psqldef.TableColumn{
+			Name:    updatedAtColumnName,
+			Type:    psqldef.PSQLTypeTimestampTZ,
+			NotNull: true,
+			Default: "NOW()",
+		},
+	)
+
+	updatedAtFunction := psqldef.Function{
+		Schema:   table.Schema,
+		Name:     updatedAtFunctionName,
+		Returns:  "trigger",
+		Language: "plpgsql",
+		Body: []string{
+			"BEGIN",
+			fmt.Sprintf("\tNEW.%s = NOW();", updatedAtColumnName),
+			"\tRETURN NEW;",
+			"END;",
+		},
+		Shared: true,
+	}
+	table.Functions = append(table.Functions, updatedAtFunction)
+
+	table.Triggers = append(table.Triggers, psqldef.Trigger{
+		Schema:         table.Schema,
+		Name:           GetTriggerName(updatedAtFunction.Name),
+		TableName:      table.Name,
+		Timing:         "BEFORE",
+		Events:         []string{"UPDATE"},
+		FunctionSchema: updatedAtFunction.Schema,
+		FunctionName:   updatedAtFunction.Name,
+	})
+
+	return nil
+}
@@ -6,3 +6,13 @@ var ErrNoSchema = errors.New("schema cannot be empty")
 var ErrNoModelSchema = errors.New("model schema cannot be empty")
 var ErrNoEnumSchema = errors.New("enum schema cannot be empty")
 var ErrNoStructureSchema = errors.New("structure schema cannot be empty when persistence is enabled")
+var ErrInvalidReferentialAction = errors.New("referential action must be one of CASCADE, SET NULL, SET DEFAULT, RESTRICT or NO ACTION")
+var ErrInvalidEnumColumn = errors.New("enum column must be one of key or value")
+var ErrAuditJunctionTablesWithoutAuditColumns = errors.New("audit junction tables require audit columns")
+var ErrNoTenantSetting = errors.New("tenant setting name cannot be empty when tenancy is enabled")
+var ErrInvalidPartitionStrategy = errors.New("partition strategy must be one of RANGE, LIST or HASH")
+var ErrNoPartitionKey = errors.New("partition key fields cannot be empty")
+var ErrNoPartitionName = errors.New("partition name cannot be empty")
+var ErrInvalidPartitionBounds = errors.New("partition bounds do not match the partition strategy")
+var ErrUnknownOrderField = errors.New("order field must be one of the relation fields")
+var ErrWritableMaterializedView = errors.New("materialized views cannot be writable")
@@ -2,6 +2,13 @@ package cfg
 
 import "fmt"
 
+const (
+	// EnumColumnKey projects the key of an enum entry, e.g. "US"
+	EnumColumnKey = "key"
+	// EnumColumnValue projects the value of an enum entry, e.g. "American"
+	EnumColumnValue = "value"
+)
+
 // MorpheEntitiesConfig defines configuration options for compiling Morphe entities to PostgreSQL views
 type MorpheEntitiesConfig struct {
 	// Schema is the PostgreSQL schema name to use for generated views
@@ -9,6 +16,12 @@ type MorpheEntitiesConfig struct {
 
 	// ViewNameSuffix is appended to view names (default: "_entities")
 	ViewNameSuffix string
+
+	// EnumColumn is the enum lookup table column projected for enum fields, "key" or "value" (default: "key")
+	EnumColumn string
+
+	// EntityOptions holds per-entity options by entity name
+	EntityOptions map[string]MorpheEntityOptions
 }
 
 // Validate validates the MorpheEntitiesConfig
@@ -16,5 +29,22 @@ func (c MorpheEntitiesConfig) Validate() error {
 	if c.Schema == "" {
 		return fmt.Errorf("schema is required")
 	}
+	if c.EnumColumn != "" && c.EnumColumn != EnumColumnKey && c.EnumColumn != EnumColumnValue {
+		return ErrInvalidEnumColumn
+	}
+	for entityName, entityOptions := range c.EntityOptions {
+		entityOptionsErr := entityOptions.Validate()
+		if entityOptionsErr != nil {
+			return fmt.Errorf("entity '%s': %w", entityName, entityOptionsErr)
+		}
+	}
 	return nil
 }
+
+// GetEnumColumn returns the enum lookup table column projected for enum fields
+func (c MorpheEntitiesConfig) GetEnumColumn() string {
+	if c.EnumColumn == "" {
+		return EnumColumnKey
+	}
+	return c.EnumColumn
+}
@@ -0,0 +1,19 @@
+package cfg
+
+// MorpheEntityOptions holds per-entity options that cannot be expressed in Morphe entity definitions
+type MorpheEntityOptions struct {
+	// Materialized compiles the entity to a materialized view with unique indexes on its identifiers and a
+	// function refreshing it concurrently
+	Materialized bool
+
+	// Writable generates INSTEAD OF triggers routing inserts, updates and deletes on the view to its source tables
+	Writable bool
+}
+
+// Validate checks if the entity options are valid
+func (options MorpheEntityOptions) Validate() error {
+	if options.Materialized && options.Writable {
+		return ErrWritableMaterializedView
+	}
+	return nil
+}
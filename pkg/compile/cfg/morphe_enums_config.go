@@ -7,6 +7,10 @@ type MorpheEnumsConfig struct {
 
 	// Whether to use BIGSERIAL instead of SERIAL for auto-increment fields
 	UseBigSerial bool
+
+	// Whether to compile enums to native PostgreSQL ENUM types instead of lookup tables.
+	// Model fields referencing an enum then use the ENUM type instead of a foreign key to the lookup table.
+	UseNativeEnums bool
 }
 
 // Validate checks if the models configuration is valid
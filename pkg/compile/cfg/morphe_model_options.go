@@ -0,0 +1,96 @@
+package cfg
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+)
+
+// Referential actions supported for foreign keys
+const (
+	ReferentialActionCascade    = "CASCADE"
+	ReferentialActionSetNull    = "SET NULL"
+	ReferentialActionSetDefault = "SET DEFAULT"
+	ReferentialActionRestrict   = "RESTRICT"
+	ReferentialActionNoAction   = "NO ACTION"
+)
+
+var referentialActions = []string{
+	ReferentialActionCascade,
+	ReferentialActionSetNull,
+	ReferentialActionSetDefault,
+	ReferentialActionRestrict,
+	ReferentialActionNoAction,
+}
+
+// MorpheModelOptions holds per-model options that cannot be expressed in Morphe model definitions
+type MorpheModelOptions struct {
+	// Relations holds options for the model's relations by relation name
+	Relations map[string]MorpheRelationOptions
+
+	// SoftDelete adds a nullable deleted_at column, makes identifier unique indexes partial on live rows and hides
+	// deleted rows from entity views
+	SoftDelete bool
+
+	// Partition declares the model table as partitioned, e.g. by RANGE on a timestamp field
+	Partition MorphePartitionOptions
+}
+
+// MorpheRelationOptions holds options for the foreign keys compiled from a single relation
+type MorpheRelationOptions struct {
+	// OnDelete is the referential action when the referenced row is deleted (default: MorpheModelsConfig.DefaultOnDelete)
+	OnDelete string
+
+	// OnUpdate is the referential action when the referenced key is updated (default: MorpheModelsConfig.DefaultOnUpdate)
+	OnUpdate string
+
+	// Optional makes the relation's foreign key columns nullable, e.g. for "may have a manager" relations
+	Optional bool
+
+	// Fields adds attribute columns to the junction table of a ForMany or ForManyPoly relation, compiled like model
+	// fields, e.g. the role on a membership
+	Fields map[string]yaml.ModelField
+
+	// OrderField names the field of Fields ordering the related rows, indexed alongside the relation's source columns
+	OrderField string
+}
+
+// Validate checks if the model options are valid
+func (options MorpheModelOptions) Validate() error {
+	partitionErr := options.Partition.Validate()
+	if partitionErr != nil {
+		return partitionErr
+	}
+
+	for relationName, relationOptions := range options.Relations {
+		relationErr := relationOptions.Validate()
+		if relationErr != nil {
+			return fmt.Errorf("relation '%s': %w", relationName, relationErr)
+		}
+	}
+	return nil
+}
+
+// Validate checks if the relation options are valid
+func (options MorpheRelationOptions) Validate() error {
+	if options.OrderField != "" {
+		if _, orderFieldExists := options.Fields[options.OrderField]; !orderFieldExists {
+			return fmt.Errorf("%w: '%s'", ErrUnknownOrderField, options.OrderField)
+		}
+	}
+
+	onDeleteErr := ValidateReferentialAction(options.OnDelete)
+	if onDeleteErr != nil {
+		return onDeleteErr
+	}
+	return ValidateReferentialAction(options.OnUpdate)
+}
+
+// ValidateReferentialAction checks that an action is empty or one of the supported referential actions
+func ValidateReferentialAction(action string) error {
+	if action == "" || slices.Contains(referentialActions, action) {
+		return nil
+	}
+	return fmt.Errorf("%w: '%s'", ErrInvalidReferentialAction, action)
+}
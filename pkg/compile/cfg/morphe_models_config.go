@@ -1,5 +1,7 @@
 package cfg
 
+import "fmt"
+
 // MorpheModelsConfig holds configuration specific to PostgreSQL model tables
 type MorpheModelsConfig struct {
 	// Schema to use for model tables
@@ -7,6 +9,28 @@ type MorpheModelsConfig struct {
 
 	// Whether to use BIGSERIAL instead of SERIAL for auto-increment fields
 	UseBigSerial bool
+
+	// Whether junction tables use their foreign key columns as a composite primary key instead of a surrogate id
+	JunctionCompositeKeys bool
+
+	// Whether to generate trigger functions enforcing referential integrity for polymorphic relations
+	EnablePolymorphicTriggers bool
+
+	// Referential actions for relation and enum foreign keys without relation options (default: CASCADE on delete)
+	DefaultOnDelete string
+	DefaultOnUpdate string
+
+	// Whether to add created_at and updated_at columns, kept current by a BEFORE UPDATE trigger, to model tables
+	AuditColumns bool
+
+	// Whether to also add audit columns to junction tables (requires AuditColumns)
+	AuditJunctionTables bool
+
+	// Tenancy scopes model and junction tables to tenants through a tenant column and row-level security policies
+	Tenancy MorpheTenancyConfig
+
+	// ModelOptions holds per-model options by model name
+	ModelOptions map[string]MorpheModelOptions
 }
 
 // Validate checks if the models configuration is valid
@@ -15,5 +39,63 @@ func (config MorpheModelsConfig) Validate() error {
 		return ErrNoModelSchema
 	}
 
+	onDeleteErr := ValidateReferentialAction(config.DefaultOnDelete)
+	if onDeleteErr != nil {
+		return onDeleteErr
+	}
+	onUpdateErr := ValidateReferentialAction(config.DefaultOnUpdate)
+	if onUpdateErr != nil {
+		return onUpdateErr
+	}
+
+	tenancyErr := config.Tenancy.Validate()
+	if tenancyErr != nil {
+		return tenancyErr
+	}
+
+	if config.AuditJunctionTables && !config.AuditColumns {
+		return ErrAuditJunctionTablesWithoutAuditColumns
+	}
+
+	for modelName, modelOptions := range config.ModelOptions {
+		modelOptionsErr := modelOptions.Validate()
+		if modelOptionsErr != nil {
+			return fmt.Errorf("model '%s': %w", modelName, modelOptionsErr)
+		}
+	}
+
 	return nil
 }
+
+// GetDefaultReferentialActions returns the ON DELETE and ON UPDATE actions for foreign keys without relation options
+func (config MorpheModelsConfig) GetDefaultReferentialActions() (string, string) {
+	onDelete := config.DefaultOnDelete
+	if onDelete == "" {
+		onDelete = ReferentialActionCascade
+	}
+	return onDelete, config.DefaultOnUpdate
+}
+
+// GetRelationReferentialActions returns the ON DELETE and ON UPDATE actions for the foreign keys of a model's relation
+func (config MorpheModelsConfig) GetRelationReferentialActions(modelName string, relationName string) (string, string) {
+	onDelete, onUpdate := config.GetDefaultReferentialActions()
+
+	relationOptions := config.ModelOptions[modelName].Relations[relationName]
+	if relationOptions.OnDelete != "" {
+		onDelete = relationOptions.OnDelete
+	}
+	if relationOptions.OnUpdate != "" {
+		onUpdate = relationOptions.OnUpdate
+	}
+	return onDelete, onUpdate
+}
+
+// IsSoftDelete returns true if rows of the model are soft-deleted through a deleted_at column
+func (config MorpheModelsConfig) IsSoftDelete(modelName string) bool {
+	return config.ModelOptions[modelName].SoftDelete
+}
+
+// IsRelationOptional returns true if the foreign key columns of a model's relation are nullable
+func (config MorpheModelsConfig) IsRelationOptional(modelName string, relationName string) bool {
+	return config.ModelOptions[modelName].Relations[relationName].Optional
+}
@@ -0,0 +1,123 @@
+package cfg
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Partitioning strategies supported for model tables
+const (
+	PartitionStrategyRange = "RANGE"
+	PartitionStrategyList  = "LIST"
+	PartitionStrategyHash  = "HASH"
+)
+
+var partitionStrategies = []string{
+	PartitionStrategyRange,
+	PartitionStrategyList,
+	PartitionStrategyHash,
+}
+
+// MorphePartitionOptions declares a model table as partitioned
+type MorphePartitionOptions struct {
+	// Strategy is RANGE, LIST or HASH (empty: not partitioned)
+	Strategy string
+
+	// KeyFields are the model fields making up the partition key
+	KeyFields []string
+
+	// Partitions are the child partitions created with the table
+	Partitions []MorphePartition
+}
+
+// MorphePartition is a child partition of a partitioned model table
+type MorphePartition struct {
+	// Name is appended to the table name to name the partition table, e.g. "2024_01" for "events_2024_01"
+	Name string
+
+	// Default makes this the partition for rows matching no other partition (RANGE and LIST only)
+	Default bool
+
+	// From and To are the inclusive lower and exclusive upper RANGE bounds, one value per key field
+	From []string
+	To   []string
+
+	// Values are the LIST values of the partition
+	Values []string
+
+	// Modulus and Remainder select the HASH partition rows
+	Modulus   int
+	Remainder int
+}
+
+// IsPartitioned returns true if the options declare a partitioned table
+func (options MorphePartitionOptions) IsPartitioned() bool {
+	return options.Strategy != ""
+}
+
+// Validate checks if the partition options are valid
+func (options MorphePartitionOptions) Validate() error {
+	if !options.IsPartitioned() {
+		return nil
+	}
+	if !slices.Contains(partitionStrategies, options.Strategy) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidPartitionStrategy, options.Strategy)
+	}
+	if len(options.KeyFields) == 0 {
+		return ErrNoPartitionKey
+	}
+
+	for _, partition := range options.Partitions {
+		partitionErr := options.validatePartition(partition)
+		if partitionErr != nil {
+			return fmt.Errorf("partition '%s': %w", partition.Name, partitionErr)
+		}
+	}
+	return nil
+}
+
+func (options MorphePartitionOptions) validatePartition(partition MorphePartition) error {
+	if partition.Name == "" {
+		return ErrNoPartitionName
+	}
+	if partition.Default {
+		if options.Strategy == PartitionStrategyHash {
+			return ErrInvalidPartitionBounds
+		}
+		return nil
+	}
+
+	switch options.Strategy {
+	case PartitionStrategyRange:
+		if len(partition.From) != len(options.KeyFields) || len(partition.To) != len(options.KeyFields) {
+			return ErrInvalidPartitionBounds
+		}
+	case PartitionStrategyList:
+		if len(partition.Values) == 0 {
+			return ErrInvalidPartitionBounds
+		}
+	case PartitionStrategyHash:
+		if partition.Modulus <= 0 || partition.Remainder < 0 || partition.Remainder >= partition.Modulus {
+			return ErrInvalidPartitionBounds
+		}
+	}
+	return nil
+}
+
+// MonthlyRangePartitions returns RANGE partitions for the given number of months, starting with the month of start
+func MonthlyRangePartitions(start time.Time, months int) []MorphePartition {
+	monthStart := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	partitions := make([]MorphePartition, months)
+	for monthIdx := range partitions {
+		nextMonthStart := monthStart.AddDate(0, 1, 0)
+		partitions[monthIdx] = MorphePartition{
+			Name: monthStart.Format("2006_01"),
+			From: []string{monthStart.Format(time.DateOnly)},
+			To:   []string{nextMonthStart.Format(time.DateOnly)},
+		}
+		monthStart = nextMonthStart
+	}
+	return partitions
+}
@@ -0,0 +1,36 @@
+package cfg
+
+import "github.com/kalo-build/morphe-go/pkg/yaml"
+
+// MorpheTenancyConfig configures shared-schema multi-tenancy enforced by row-level security policies
+type MorpheTenancyConfig struct {
+	// ColumnName is the tenant column added to every model and junction table, e.g. "tenant_id" (empty disables tenancy)
+	ColumnName string
+
+	// FieldType is the Morphe field type of the tenant column (default: UUID)
+	FieldType yaml.ModelFieldType
+
+	// SettingName is the runtime setting holding the current tenant, e.g. "app.tenant_id"
+	SettingName string
+}
+
+// IsEnabled returns true if tables are scoped to tenants
+func (config MorpheTenancyConfig) IsEnabled() bool {
+	return config.ColumnName != ""
+}
+
+// Validate checks if the tenancy configuration is valid
+func (config MorpheTenancyConfig) Validate() error {
+	if config.IsEnabled() && config.SettingName == "" {
+		return ErrNoTenantSetting
+	}
+	return nil
+}
+
+// GetFieldType returns the Morphe field type of the tenant column
+func (config MorpheTenancyConfig) GetFieldType() yaml.ModelFieldType {
+	if config.FieldType == "" {
+		return yaml.ModelFieldTypeUUID
+	}
+	return config.FieldType
+}
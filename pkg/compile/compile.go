@@ -3,90 +3,441 @@ package compile
 import (
 	"fmt"
 
+	"github.com/kalo-build/go-util/core"
 	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 )
 
+// compiledMorpheDefinitions holds all definitions compiled from a Morphe registry before they are written
+type compiledMorpheDefinitions struct {
+	enumTypes      map[string]*psqldef.PSQLTypeEnum
+	enumTables     map[string]*psqldef.Table
+	modelTables    map[string][]*psqldef.Table
+	structureTable *psqldef.Table
+	entityViews    map[string]*psqldef.View
+}
+
 func MorpheToPSQL(config MorpheCompileConfig) error {
 	r, rErr := registry.LoadMorpheRegistry(config.RegistryHooks, config.MorpheLoadRegistryConfig)
 	if rErr != nil {
 		return rErr
 	}
 
-	// Track the current order number for ordered migrations
-	currentOrder := 0
+	compiledDefinitions, compileErr := compileAllMorpheDefinitions(config, r)
+	if compileErr != nil {
+		return compileErr
+	}
+
+	// Track all written tables and views for the schema snapshot and diffing against previous tables
+	var allWrittenTables []*psqldef.Table
+	var allWrittenViews []*psqldef.View
+	var writeErr error
+	if config.SchemaWriter != nil {
+		allWrittenTables, allWrittenViews, writeErr = writeConsolidatedSchema(config, compiledDefinitions)
+	} else {
+		allWrittenTables, allWrittenViews, writeErr = writeAllDefinitionFiles(config, r, compiledDefinitions)
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+
+	if config.PreviousTables != nil {
+		if config.MigrationWriter == nil {
+			return ErrNoMigrationWriter
+		}
+
+		allTableDiffs, diffErr := DiffTables(config.PreviousTables, allWrittenTables)
+		if diffErr != nil {
+			return diffErr
+		}
+		dropViewDiffs, createViewDiffs, diffViewsErr := DiffViews(config.PreviousViews, allWrittenViews, allTableDiffs)
+		if diffViewsErr != nil {
+			return diffViewsErr
+		}
+
+		startOrder, startOrderErr := getMigrationStartOrder(config.MigrationWriter)
+		if startOrderErr != nil {
+			return startOrderErr
+		}
+
+		// Views selecting from the migrated tables are dropped before and recreated after the table migrations
+		_, currentOrder, dropViewsErr := WriteAllViewMigrations(config, dropViewDiffs, startOrder)
+		if dropViewsErr != nil {
+			return dropViewsErr
+		}
+		_, _, currentOrder, writeMigrationsErr := WriteAllTableMigrations(config, allTableDiffs, currentOrder)
+		if writeMigrationsErr != nil {
+			return writeMigrationsErr
+		}
+		_, _, createViewsErr := WriteAllViewMigrations(config, createViewDiffs, currentOrder)
+		if createViewsErr != nil {
+			return createViewsErr
+		}
+	}
+
+	if config.SnapshotWriter != nil {
+		_, writeSnapshotErr := config.SnapshotWriter.WriteSnapshot(getSchemaSnapshot(allWrittenTables, allWrittenViews))
+		if writeSnapshotErr != nil {
+			return writeSnapshotErr
+		}
+	}
+
+	return nil
+}
+
+// getMigrationStartOrder returns the order after which new migrations are written, continuing the migrations of
+// previous compilations if the writer keeps track of them
+func getMigrationStartOrder(writer write.PSQLMigrationWriter) (int, error) {
+	orderReader, isOrderReader := writer.(write.PSQLMigrationOrderReader)
+	if !isOrderReader {
+		return 0, nil
+	}
+	return orderReader.GetLastMigrationOrder()
+}
+
+func compileAllMorpheDefinitions(config MorpheCompileConfig, r *registry.Registry) (*compiledMorpheDefinitions, error) {
+	compiledDefinitions := &compiledMorpheDefinitions{}
 
-	if r.HasEnums() {
+	if r.HasEnums() && config.MorpheEnumsConfig.UseNativeEnums {
+		allEnumTypes, compileAllEnumsErr := AllMorpheEnumsToPSQLEnumTypes(config, r)
+		if compileAllEnumsErr != nil {
+			return nil, compileAllEnumsErr
+		}
+		compiledDefinitions.enumTypes = allEnumTypes
+	} else if r.HasEnums() {
 		allEnumTables, compileAllEnumsErr := AllMorpheEnumsToPSQLTables(config, r)
 		if compileAllEnumsErr != nil {
-			return compileAllEnumsErr
+			return nil, compileAllEnumsErr
+		}
+		compiledDefinitions.enumTables = allEnumTables
+	}
+
+	if r.HasModels() {
+		allModelTables, compileAllModelsErr := AllMorpheModelsToPSQLTables(config, r)
+		if compileAllModelsErr != nil {
+			return nil, compileAllModelsErr
+		}
+		compiledDefinitions.modelTables = allModelTables
+	}
+
+	if r.HasStructures() {
+		structureTable, compileStructureErr := MorpheStructureToPSQLTable(config)
+		if compileStructureErr != nil {
+			return nil, compileStructureErr
+		}
+		compiledDefinitions.structureTable = structureTable
+	}
+
+	if r.HasEntities() {
+		if !r.HasModels() {
+			return nil, fmt.Errorf("entities compilation requires models to be compiled")
+		}
+
+		allEntityViews, compileAllEntityViewsErr := AllMorpheEntitiesToPSQLViews(config, r)
+		if compileAllEntityViewsErr != nil {
+			return nil, compileAllEntityViewsErr
+		}
+		compiledDefinitions.entityViews = allEntityViews
+	}
+
+	return compiledDefinitions, nil
+}
+
+// writeAllDefinitionFiles writes one file per definition with the enum, model, structure and entity writers
+func writeAllDefinitionFiles(config MorpheCompileConfig, r *registry.Registry, compiledDefinitions *compiledMorpheDefinitions) ([]*psqldef.Table, []*psqldef.View, error) {
+	allWrittenTables := []*psqldef.Table{}
+	allWrittenViews := []*psqldef.View{}
+
+	// Track the current order number for ordered migrations across enums, models, structures and entities,
+	// so that the whole output can be applied in lexicographic file order
+	currentOrder := 0
+
+	if compiledDefinitions.enumTypes != nil {
+		if config.EnumTypeWriter == nil {
+			return nil, nil, ErrNoEnumTypeWriter
 		}
 
+		if config.EnableOrderedMigrations {
+			var writeEnumTypesErr error
+			_, currentOrder, writeEnumTypesErr = WriteAllEnumTypeDefinitionsWithOrder(config, compiledDefinitions.enumTypes, currentOrder)
+			if writeEnumTypesErr != nil {
+				return nil, nil, writeEnumTypesErr
+			}
+		} else {
+			_, writeEnumTypesErr := WriteAllEnumTypeDefinitions(config, compiledDefinitions.enumTypes)
+			if writeEnumTypesErr != nil {
+				return nil, nil, writeEnumTypesErr
+			}
+		}
+	}
+
+	if compiledDefinitions.enumTables != nil {
+		var writtenEnumTables CompiledMorpheTables
 		if config.EnableOrderedMigrations {
 			var writeEnumTablesErr error
-			_, currentOrder, writeEnumTablesErr = WriteAllEnumTableDefinitionsWithOrder(config, allEnumTables, currentOrder)
+			writtenEnumTables, currentOrder, writeEnumTablesErr = WriteAllEnumTableDefinitionsWithOrder(config, compiledDefinitions.enumTables, currentOrder)
 			if writeEnumTablesErr != nil {
-				return writeEnumTablesErr
+				return nil, nil, writeEnumTablesErr
 			}
 		} else {
-			_, writeEnumTablesErr := WriteAllEnumTableDefinitions(config, allEnumTables)
+			var writeEnumTablesErr error
+			writtenEnumTables, writeEnumTablesErr = WriteAllEnumTableDefinitions(config, compiledDefinitions.enumTables)
 			if writeEnumTablesErr != nil {
-				return writeEnumTablesErr
+				return nil, nil, writeEnumTablesErr
 			}
 		}
+		allWrittenTables = append(allWrittenTables, writtenEnumTables.GetAllTables()...)
 	}
 
-	hasModels := r.HasModels()
-	if hasModels {
-		allModelTables, compileAllModelsErr := AllMorpheModelsToPSQLTables(config, r)
-		if compileAllModelsErr != nil {
-			return compileAllModelsErr
-		}
-
+	if compiledDefinitions.modelTables != nil {
+		var writtenModelTables CompiledMorpheTables
 		if config.EnableOrderedMigrations {
-			_, writeModelTablesErr := WriteAllModelTableDefinitionsWithOrder(config, allModelTables, currentOrder)
+			var writeModelTablesErr error
+			writtenModelTables, currentOrder, writeModelTablesErr = WriteAllModelTableDefinitionsWithOrder(config, compiledDefinitions.modelTables, currentOrder)
 			if writeModelTablesErr != nil {
-				return writeModelTablesErr
+				return nil, nil, writeModelTablesErr
 			}
-			// Note: We don't track currentOrder further since structures/entities are separate
 		} else {
-			_, writeModelTablesErr := WriteAllModelTableDefinitions(config, allModelTables)
+			var writeModelTablesErr error
+			writtenModelTables, writeModelTablesErr = WriteAllModelTableDefinitions(config, compiledDefinitions.modelTables)
 			if writeModelTablesErr != nil {
-				return writeModelTablesErr
+				return nil, nil, writeModelTablesErr
 			}
 		}
+		allWrittenTables = append(allWrittenTables, writtenModelTables.GetAllTables()...)
 	}
 
 	if r.HasStructures() {
 		if config.StructureWriter == nil {
-			return ErrNoStructureWriter
+			return nil, nil, ErrNoStructureWriter
 		}
 
-		structureTable, compileStructureErr := MorpheStructureToPSQLTable(config)
-		if compileStructureErr != nil {
-			return compileStructureErr
+		if compiledDefinitions.structureTable != nil {
+			structureOrder := 0
+			if config.EnableOrderedMigrations {
+				currentOrder++
+				structureOrder = currentOrder
+			}
+			writtenStructureTable, _, writeStructureErr := WriteStructureTableDefinitionWithOrder(
+				config.WriteTableHooks, config.StructureWriter, compiledDefinitions.structureTable, structureOrder)
+			if writeStructureErr != nil {
+				return nil, nil, writeStructureErr
+			}
+			allWrittenTables = append(allWrittenTables, writtenStructureTable)
 		}
+	}
 
-		_, _, writeStructureErr := WriteStructureTableDefinition(config.WriteTableHooks, config.StructureWriter, structureTable)
-		if writeStructureErr != nil {
-			return writeStructureErr
+	// Entity views select from the tables above, so they are always ordered last
+	if compiledDefinitions.entityViews != nil {
+		var writtenEntityViews CompiledMorpheViews
+		if config.EnableOrderedMigrations {
+			var writeEntityViewsErr error
+			writtenEntityViews, currentOrder, writeEntityViewsErr = WriteAllEntityViewDefinitionsWithOrder(config, compiledDefinitions.entityViews, currentOrder)
+			if writeEntityViewsErr != nil {
+				return nil, nil, writeEntityViewsErr
+			}
+		} else {
+			var writeEntityViewsErr error
+			writtenEntityViews, writeEntityViewsErr = WriteAllEntityViewDefinitions(config, compiledDefinitions.entityViews)
+			if writeEntityViewsErr != nil {
+				return nil, nil, writeEntityViewsErr
+			}
 		}
+		allWrittenViews = append(allWrittenViews, writtenEntityViews.GetAllViews()...)
 	}
 
-	if r.HasEntities() {
-		if !hasModels {
-			return fmt.Errorf("entities compilation requires models to be compiled")
+	return allWrittenTables, allWrittenViews, nil
+}
+
+// writeConsolidatedSchema writes all definitions in dependency order with the schema writer. The write hooks run for
+// each definition as with the per-definition writers: start hooks may update a definition before it is added to the
+// schema, and success hooks receive the consolidated schema contents. Writers returned by start hooks are unused.
+func writeConsolidatedSchema(config MorpheCompileConfig, compiledDefinitions *compiledMorpheDefinitions) ([]*psqldef.Table, []*psqldef.View, error) {
+	hookedDefinitions, startErr := triggerWriteSchemaDefinitionsStart(config, compiledDefinitions)
+	if startErr != nil {
+		return nil, nil, startErr
+	}
+	allTables, allViews := hookedDefinitions.getAllTablesAndViews()
+
+	sortedDefinitions, sortErr := SortSchemaDefinitionsByDependency(allTables, allViews)
+	if sortErr != nil {
+		return nil, nil, sortErr
+	}
+
+	schema := &psqldef.Schema{
+		EnumTypes:   []psqldef.PSQLTypeEnum{},
+		Definitions: sortedDefinitions,
+	}
+	for _, enumName := range core.MapKeysSorted(hookedDefinitions.enumTypes) {
+		schema.EnumTypes = append(schema.EnumTypes, *hookedDefinitions.enumTypes[enumName])
+	}
+
+	schemaContents, writeSchemaErr := config.SchemaWriter.WriteSchema(schema)
+	if writeSchemaErr != nil {
+		return nil, nil, writeSchemaErr
+	}
+
+	writtenDefinitions, successErr := triggerWriteSchemaDefinitionsSuccess(config, hookedDefinitions, schemaContents)
+	if successErr != nil {
+		return nil, nil, successErr
+	}
+	writtenTables, writtenViews := writtenDefinitions.getAllTablesAndViews()
+	return writtenTables, writtenViews, nil
+}
+
+// triggerWriteSchemaDefinitionsStart runs the write start hooks of all definitions written to a consolidated schema
+func triggerWriteSchemaDefinitionsStart(config MorpheCompileConfig, definitions *compiledMorpheDefinitions) (*compiledMorpheDefinitions, error) {
+	hookedDefinitions := &compiledMorpheDefinitions{
+		enumTypes:   map[string]*psqldef.PSQLTypeEnum{},
+		enumTables:  map[string]*psqldef.Table{},
+		modelTables: map[string][]*psqldef.Table{},
+		entityViews: map[string]*psqldef.View{},
+	}
+
+	for _, enumName := range core.MapKeysSorted(definitions.enumTypes) {
+		enumType := definitions.enumTypes[enumName]
+		_, hookedEnumType, startErr := triggerWriteEnumTypeStart(config.WriteEnumTypeHooks, config.EnumTypeWriter, enumType)
+		if startErr != nil {
+			return nil, triggerWriteEnumTypeFailure(config.WriteEnumTypeHooks, config.EnumTypeWriter, enumType, startErr)
 		}
+		hookedDefinitions.enumTypes[enumName] = hookedEnumType
+	}
 
-		allEntityViews, compileAllEntityViewsErr := AllMorpheEntitiesToPSQLViews(config, r)
-		if compileAllEntityViewsErr != nil {
-			return compileAllEntityViewsErr
+	for _, enumName := range core.MapKeysSorted(definitions.enumTables) {
+		enumTable := definitions.enumTables[enumName]
+		_, hookedEnumTable, startErr := triggerWriteEnumTableStart(config.WriteTableHooks, config.EnumWriter, enumTable)
+		if startErr != nil {
+			return nil, triggerWriteEnumTableFailure(config.WriteTableHooks, config.EnumWriter, enumTable, startErr)
 		}
+		hookedDefinitions.enumTables[enumName] = hookedEnumTable
+	}
 
-		_, writeEntityViewsErr := WriteAllEntityViewDefinitions(config, allEntityViews)
-		if writeEntityViewsErr != nil {
-			return writeEntityViewsErr
+	for _, modelName := range core.MapKeysSorted(definitions.modelTables) {
+		modelTables := definitions.modelTables[modelName]
+		hookedModelTables := []*psqldef.Table{}
+		for _, modelTable := range modelTables {
+			_, hookedModelTable, startErr := triggerWriteModelTableStart(config.WriteTableHooks, config.ModelWriter, modelTable)
+			if startErr != nil {
+				return nil, triggerWriteModelTableFailure(config.WriteTableHooks, config.ModelWriter, modelTable, startErr)
+			}
+			hookedModelTables = append(hookedModelTables, hookedModelTable)
 		}
+		hookedDefinitions.modelTables[modelName] = hookedModelTables
 	}
 
-	return nil
+	if definitions.structureTable != nil {
+		_, hookedStructureTable, startErr := triggerWriteModelTableStart(config.WriteTableHooks, config.StructureWriter, definitions.structureTable)
+		if startErr != nil {
+			return nil, triggerWriteModelTableFailure(config.WriteTableHooks, config.StructureWriter, definitions.structureTable, startErr)
+		}
+		hookedDefinitions.structureTable = hookedStructureTable
+	}
+
+	for _, entityName := range core.MapKeysSorted(definitions.entityViews) {
+		entityView := definitions.entityViews[entityName]
+		_, hookedEntityView, startErr := triggerWriteEntityViewStart(config.WriteViewHooks, config.EntityWriter, entityView)
+		if startErr != nil {
+			return nil, triggerWriteEntityViewFailure(config.WriteViewHooks, config.EntityWriter, entityView, startErr)
+		}
+		hookedDefinitions.entityViews[entityName] = hookedEntityView
+	}
+
+	return hookedDefinitions, nil
+}
+
+// triggerWriteSchemaDefinitionsSuccess runs the write success hooks of all definitions written to a consolidated
+// schema, passing each the contents of the whole schema
+func triggerWriteSchemaDefinitionsSuccess(config MorpheCompileConfig, definitions *compiledMorpheDefinitions, schemaContents []byte) (*compiledMorpheDefinitions, error) {
+	writtenDefinitions := &compiledMorpheDefinitions{
+		enumTypes:   map[string]*psqldef.PSQLTypeEnum{},
+		enumTables:  map[string]*psqldef.Table{},
+		modelTables: map[string][]*psqldef.Table{},
+		entityViews: map[string]*psqldef.View{},
+	}
+
+	for _, enumName := range core.MapKeysSorted(definitions.enumTypes) {
+		enumType := definitions.enumTypes[enumName]
+		writtenEnumType, _, successErr := triggerWriteEnumTypeSuccess(config.WriteEnumTypeHooks, enumType, schemaContents)
+		if successErr != nil {
+			return nil, triggerWriteEnumTypeFailure(config.WriteEnumTypeHooks, config.EnumTypeWriter, enumType, successErr)
+		}
+		writtenDefinitions.enumTypes[enumName] = writtenEnumType
+	}
+
+	for _, enumName := range core.MapKeysSorted(definitions.enumTables) {
+		enumTable := definitions.enumTables[enumName]
+		writtenEnumTable, _, successErr := triggerWriteEnumTableSuccess(config.WriteTableHooks, enumTable, schemaContents)
+		if successErr != nil {
+			return nil, triggerWriteEnumTableFailure(config.WriteTableHooks, config.EnumWriter, enumTable, successErr)
+		}
+		writtenDefinitions.enumTables[enumName] = writtenEnumTable
+	}
+
+	for _, modelName := range core.MapKeysSorted(definitions.modelTables) {
+		modelTables := definitions.modelTables[modelName]
+		writtenModelTables := []*psqldef.Table{}
+		for _, modelTable := range modelTables {
+			writtenModelTable, _, successErr := triggerWriteModelTableSuccess(config.WriteTableHooks, modelTable, schemaContents)
+			if successErr != nil {
+				return nil, triggerWriteModelTableFailure(config.WriteTableHooks, config.ModelWriter, modelTable, successErr)
+			}
+			writtenModelTables = append(writtenModelTables, writtenModelTable)
+		}
+		writtenDefinitions.modelTables[modelName] = writtenModelTables
+	}
+
+	if definitions.structureTable != nil {
+		writtenStructureTable, _, successErr := triggerWriteModelTableSuccess(config.WriteTableHooks, definitions.structureTable, schemaContents)
+		if successErr != nil {
+			return nil, triggerWriteModelTableFailure(config.WriteTableHooks, config.StructureWriter, definitions.structureTable, successErr)
+		}
+		writtenDefinitions.structureTable = writtenStructureTable
+	}
+
+	for _, entityName := range core.MapKeysSorted(definitions.entityViews) {
+		entityView := definitions.entityViews[entityName]
+		writtenEntityView, _, successErr := triggerWriteEntityViewSuccess(config.WriteViewHooks, entityView, schemaContents)
+		if successErr != nil {
+			return nil, triggerWriteEntityViewFailure(config.WriteViewHooks, config.EntityWriter, entityView, successErr)
+		}
+		writtenDefinitions.entityViews[entityName] = writtenEntityView
+	}
+
+	return writtenDefinitions, nil
+}
+
+// getAllTablesAndViews returns all compiled tables and views sorted by Morphe name
+func (definitions *compiledMorpheDefinitions) getAllTablesAndViews() ([]*psqldef.Table, []*psqldef.View) {
+	allTables := []*psqldef.Table{}
+	for _, enumName := range core.MapKeysSorted(definitions.enumTables) {
+		allTables = append(allTables, definitions.enumTables[enumName])
+	}
+	for _, modelName := range core.MapKeysSorted(definitions.modelTables) {
+		allTables = append(allTables, definitions.modelTables[modelName]...)
+	}
+	if definitions.structureTable != nil {
+		allTables = append(allTables, definitions.structureTable)
+	}
+
+	allViews := []*psqldef.View{}
+	for _, entityName := range core.MapKeysSorted(definitions.entityViews) {
+		allViews = append(allViews, definitions.entityViews[entityName])
+	}
+
+	return allTables, allViews
+}
+
+func getSchemaSnapshot(allTables []*psqldef.Table, allViews []*psqldef.View) *psqldef.SchemaSnapshot {
+	snapshot := &psqldef.SchemaSnapshot{
+		Tables: []psqldef.Table{},
+		Views:  []psqldef.View{},
+	}
+	for _, table := range allTables {
+		snapshot.Tables = append(snapshot.Tables, table.DeepClone())
+	}
+	for _, view := range allViews {
+		snapshot.Views = append(snapshot.Views, view.DeepClone())
+	}
+	return snapshot
 }
@@ -2,6 +2,7 @@ package compile
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/kalo-build/go-util/core"
@@ -103,13 +104,83 @@ func morpheEntityToPSQLView(config cfg.MorpheConfig, r *registry.Registry, entit
 		return nil, err
 	}
 
+	if config.MorpheModelsConfig.IsSoftDelete(context.rootModelName) {
+		view.WhereClause = fmt.Sprintf("%s.%s IS NULL", tableName, deletedAtColumnName)
+	}
+
+	entityOptions := config.MorpheEntitiesConfig.EntityOptions[entity.Name]
+	if entityOptions.Materialized {
+		if err := materializeView(context); err != nil {
+			return nil, err
+		}
+	}
+	if entityOptions.Writable {
+		if err := addWritableViewTriggers(context); err != nil {
+			return nil, err
+		}
+	}
+
 	return view, nil
 }
 
+// materializeView turns a view into a materialized view with unique indexes on the entity identifiers, which
+// REFRESH MATERIALIZED VIEW CONCURRENTLY requires, and a function refreshing it. To-many joins repeat the identifiers
+// across rows, so entities joining them cannot be materialized.
+func materializeView(ctx *entityCompileContext) error {
+	view, entity := ctx.view, ctx.entity
+	// REFRESH MATERIALIZED VIEW CONCURRENTLY requires a unique index on the view
+	if len(entity.Identifiers) == 0 {
+		return fmt.Errorf("%w: %s", ErrMaterializedViewWithoutIdentifiers, entity.Name)
+	}
+	for _, joinAlias := range core.MapKeysSorted(ctx.joins) {
+		info := ctx.joins[joinAlias]
+		if info.relationshipName == "" {
+			continue
+		}
+		sourceModel, modelErr := ctx.registry.GetModel(info.sourceModelName)
+		if modelErr != nil {
+			return modelErr
+		}
+		if yamlops.IsRelationMany(sourceModel.Related[info.relationshipName].Type) {
+			return fmt.Errorf("%w: %s joins %s.%s", ErrMaterializedViewToManyJoin, entity.Name, info.sourceModelName, info.relationshipName)
+		}
+	}
+
+	view.Materialized = true
+
+	for _, identifierName := range core.MapKeysSorted(entity.Identifiers) {
+		columnNames := getColumnNamesFromFields(entity.Identifiers[identifierName].Fields)
+		view.Indices = append(view.Indices, psqldef.Index{
+			Name:      GetIndexName(view.Name, strings.Join(columnNames, "_")),
+			TableName: view.Name,
+			Columns:   columnNames,
+			IsUnique:  true,
+		})
+	}
+
+	view.Functions = append(view.Functions, psqldef.Function{
+		Schema:   view.Schema,
+		Name:     GetRefreshFunctionName(view.Name),
+		Returns:  "void",
+		Language: "plpgsql",
+		Body: []string{
+			"BEGIN",
+			fmt.Sprintf("\tREFRESH MATERIALIZED VIEW CONCURRENTLY %s;", getQualifiedName(view.Schema, view.Name)),
+			"END;",
+		},
+	})
+	return nil
+}
+
 // joinInfo holds information about a join relationship
 type joinInfo struct {
-	relationshipName string // The name of the relationship (e.g., "WorkContact")
-	sourceModelName  string // The model that owns this relationship (e.g., "Person")
+	relationshipName string   // The name of the relationship (e.g., "WorkContact")
+	sourceModelName  string   // The model that owns this relationship (e.g., "Person")
+	sourceTableName  string   // The table alias the relationship is joined from (e.g., "people")
+	targetModelName  string   // The model joined by this relationship (e.g., "Contact")
+	relationPath     []string // The relationships traversed from the root table, ending with this one
+	enumName         string   // The enum whose lookup table is joined for an enum field, if any
+	enumFieldName    string   // The enum field referencing the lookup table (e.g., "Nationality")
 }
 
 // entityCompileContext holds all the context needed for entity compilation
@@ -119,7 +190,9 @@ type entityCompileContext struct {
 	entity    yaml.Entity
 	view      *psqldef.View
 	tableName string
-	joins     map[string]joinInfo // maps join table name to join information
+	joins     map[string]joinInfo // maps join table alias to join information
+
+	rootModelName string // The model the entity's field paths start from (e.g., "Person")
 }
 
 // processEntityFields processes all entity fields and adds appropriate columns to the view
@@ -160,15 +233,17 @@ func processFieldPath(ctx *entityCompileContext, fieldName string, relationshipC
 	}
 
 	rootModelName := relationshipChain[0]
+	ctx.rootModelName = rootModelName
 
 	// If there's only one element, this is a direct field reference (e.g., "User.UUID")
 	if len(relationshipChain) == 1 {
-		return addRegularColumn(ctx, columnName, ctx.tableName, targetFieldName)
+		return addFieldColumn(ctx, columnName, rootModelName, ctx.tableName, []string{}, targetFieldName)
 	}
 
 	// More than one element means we have relationships to traverse
 	currentModelName := rootModelName
 	currentTableName := ctx.tableName
+	relationPath := []string{}
 
 	// Start from index 1 since index 0 is the root model name
 	for i := 1; i < len(relationshipChain); i++ {
@@ -192,23 +267,88 @@ func processFieldPath(ctx *entityCompileContext, fieldName string, relationshipC
 		}
 
 		// Handle regular relationships - set up join and continue traversal
-		// Use relationName for table naming to maintain backward compatibility
-		relatedTableName := Pluralize(strcase.ToSnakeCaseLower(relationName))
+		// Each relation path gets its own alias, so the same model reached through different relations is joined separately
+		targetModelName, _ := getRelationTargetModelName(relationName, relation.Aliased)
+		relationPath = append(slices.Clone(relationPath), relationName)
+		relatedTableAlias := GetJoinAlias(relationPath...)
 
-		// Record join information for this table
-		ctx.joins[relatedTableName] = joinInfo{
+		// Record join information for this alias
+		joinErr := addJoinInfo(ctx, relatedTableAlias, joinInfo{
 			relationshipName: relationName,
 			sourceModelName:  currentModelName,
+			sourceTableName:  currentTableName,
+			targetModelName:  targetModelName,
+			relationPath:     relationPath,
+		})
+		if joinErr != nil {
+			return joinErr
 		}
 
 		// Update current context for next iteration - use the actual target model
-		targetModelName := yamlops.GetRelationTargetName(relationName, relation.Aliased)
 		currentModelName = targetModelName
-		currentTableName = relatedTableName
+		currentTableName = relatedTableAlias
 	}
 
 	// We've traversed all relationships, now add the final field column
-	return addRegularColumn(ctx, columnName, currentTableName, targetFieldName)
+	return addFieldColumn(ctx, columnName, currentModelName, currentTableName, relationPath, targetFieldName)
+}
+
+// addFieldColumn adds the column for a model field to the view, projecting enum fields stored as lookup table
+// foreign keys through a join on the lookup table
+func addFieldColumn(ctx *entityCompileContext, columnName string, modelName string, tableName string, relationPath []string, fieldName string) error {
+	if ctx.config.MorpheEnumsConfig.UseNativeEnums {
+		return addRegularColumn(ctx, columnName, tableName, fieldName)
+	}
+
+	model, modelErr := ctx.registry.GetModel(modelName)
+	if modelErr != nil {
+		return addRegularColumn(ctx, columnName, tableName, fieldName)
+	}
+	field, fieldExists := model.Fields[fieldName]
+	if !fieldExists {
+		return addRegularColumn(ctx, columnName, tableName, fieldName)
+	}
+	enum, enumErr := ctx.registry.GetEnum(string(field.Type))
+	if enumErr != nil {
+		return addRegularColumn(ctx, columnName, tableName, fieldName)
+	}
+
+	enumPath := append(slices.Clone(relationPath), fieldName)
+	enumTableAlias := GetJoinAlias(enumPath...)
+	joinErr := addJoinInfo(ctx, enumTableAlias, joinInfo{
+		sourceModelName: modelName,
+		sourceTableName: tableName,
+		relationPath:    enumPath,
+		enumName:        enum.Name,
+		enumFieldName:   fieldName,
+	})
+	if joinErr != nil {
+		return joinErr
+	}
+
+	column := psqldef.ViewColumn{
+		Name:      columnName,
+		SourceRef: enumTableAlias + "." + ctx.config.MorpheEntitiesConfig.GetEnumColumn(),
+		Alias:     "",
+	}
+	ctx.view.Columns = append(ctx.view.Columns, column)
+
+	return nil
+}
+
+// addJoinInfo records the join reached through a relation path under its alias. Aliases are derived from the
+// relation path, so distinct paths can produce the same alias, which must not shadow another join or the root table.
+func addJoinInfo(ctx *entityCompileContext, joinAlias string, info joinInfo) error {
+	if joinAlias == ctx.tableName {
+		return fmt.Errorf("%w: %s.%s is aliased %s like the root table", ErrJoinAliasConflict,
+			ctx.entity.Name, strings.Join(info.relationPath, "."), joinAlias)
+	}
+	if existingInfo, isJoined := ctx.joins[joinAlias]; isJoined && !slices.Equal(existingInfo.relationPath, info.relationPath) {
+		return fmt.Errorf("%w: %s.%s and %s.%s are both aliased %s", ErrJoinAliasConflict, ctx.entity.Name,
+			strings.Join(existingInfo.relationPath, "."), ctx.entity.Name, strings.Join(info.relationPath, "."), joinAlias)
+	}
+	ctx.joins[joinAlias] = info
+	return nil
 }
 
 // handlePolymorphicFieldPath handles field paths that encounter polymorphic relationships
@@ -308,20 +448,46 @@ func addRegularColumn(ctx *entityCompileContext, columnName, tableName, fieldNam
 
 // setupJoinsForRegularRelationships sets up joins for all recorded regular relationships
 func setupJoinsForRegularRelationships(ctx *entityCompileContext) error {
-	// Sort join tables for deterministic order
-	sortedJoinTables := core.MapKeysSorted(ctx.joins)
-
-	for _, joinTable := range sortedJoinTables {
-		joinInfo := ctx.joins[joinTable]
-		if err := addJoinClause(ctx, joinTable, joinInfo); err != nil {
+	// Sort join aliases for deterministic order, joining each table after the table it is joined from
+	sortedJoinAliases := core.MapKeysSorted(ctx.joins)
+	slices.SortStableFunc(sortedJoinAliases, func(a, b string) int {
+		return len(ctx.joins[a].relationPath) - len(ctx.joins[b].relationPath)
+	})
+
+	for _, joinAlias := range sortedJoinAliases {
+		joinInfo := ctx.joins[joinAlias]
+		if err := addJoinClause(ctx, joinAlias, joinInfo); err != nil {
 			return err
 		}
 	}
+	return validateJoinAliases(ctx)
+}
+
+// validateJoinAliases validates that the tables joined by the view, including junction tables, are referenced by
+// distinct aliases that do not shadow the root table
+func validateJoinAliases(ctx *entityCompileContext) error {
+	joinedAliases := []string{ctx.tableName}
+	for _, join := range ctx.view.Joins {
+		joinAlias := join.Alias
+		if joinAlias == "" {
+			joinAlias = join.Table
+		}
+		if slices.Contains(joinedAliases, joinAlias) {
+			return fmt.Errorf("%w: %s joins %s.%s as %s, which is already in use", ErrJoinAliasConflict, ctx.entity.Name, join.Schema, join.Table, joinAlias)
+		}
+		joinedAliases = append(joinedAliases, joinAlias)
+	}
 	return nil
 }
 
-// addJoinClause adds a single join clause to the view
-func addJoinClause(ctx *entityCompileContext, joinTable string, info joinInfo) error {
+// addJoinClause adds the join clauses for a single relationship to the view, following the foreign key direction
+// of the relationship type
+func addJoinClause(ctx *entityCompileContext, joinAlias string, info joinInfo) error {
+	if info.enumName != "" {
+		addEnumJoinClause(ctx, joinAlias, info)
+		return nil
+	}
+
 	model, err := ctx.registry.GetModel(info.sourceModelName)
 	if err != nil {
 		return fmt.Errorf("model %s not found in registry", info.sourceModelName)
@@ -332,41 +498,194 @@ func addJoinClause(ctx *entityCompileContext, joinTable string, info joinInfo) e
 		return fmt.Errorf("relationship %s not found in model %s", info.relationshipName, info.sourceModelName)
 	}
 
+	sourcePrimaryIdFields, sourcePrimaryErr := getModelPrimaryIdFields(model)
+	if sourcePrimaryErr != nil {
+		return sourcePrimaryErr
+	}
+
+	if yamlops.IsRelationHas(relation.Type) {
+		return addHasRelationJoinClauses(ctx, joinAlias, info, relation, sourcePrimaryIdFields)
+	}
+
 	// Get the target model from the relationship
-	targetModelName := yamlops.GetRelationTargetName(info.relationshipName, relation.Aliased)
-	targetModel, err := ctx.registry.GetModel(targetModelName)
+	targetModel, err := ctx.registry.GetModel(info.targetModelName)
 	if err != nil {
-		return fmt.Errorf("target model %s not found in registry (via relationship %s)", targetModelName, info.relationshipName)
+		return fmt.Errorf("target model %s not found in registry (via relationship %s)", info.targetModelName, info.relationshipName)
+	}
+	joinTable := GetTableNameFromModel(info.targetModelName)
+
+	targetPrimaryIdFields, targetPrimaryErr := getModelPrimaryIdFields(targetModel)
+	if targetPrimaryErr != nil {
+		return targetPrimaryErr
+	}
+	targetPrimaryIdNames := getColumnNamesFromFields(targetPrimaryIdFields)
+
+	if yamlops.IsRelationMany(relation.Type) {
+		// ForMany relationships are joined through their junction table, shared with a reciprocal relationship
+		junction, junctionErr := resolveForManyJunction(ctx.registry, model, info.relationshipName, relation)
+		if junctionErr != nil {
+			return junctionErr
+		}
+		junctionAlias := getJunctionJoinAlias(junction.TableName, info)
+		addViewJoinClause(ctx, junction.TableName, junctionAlias, getJoinConditions(
+			junctionAlias, junction.SourceColumnNames,
+			info.sourceTableName, getColumnNamesFromFields(sourcePrimaryIdFields),
+		))
+		addViewJoinClause(ctx, joinTable, joinAlias, getJoinConditions(
+			joinAlias, targetPrimaryIdNames,
+			junctionAlias, junction.TargetColumnNames,
+		))
+		return addJunctionFieldViewColumns(ctx, junction, junctionAlias, joinAlias)
+	}
+
+	// ForOne relationships hold the foreign key columns on the source table
+	addViewJoinClause(ctx, joinTable, joinAlias, getJoinConditions(
+		info.sourceTableName, getForeignKeyColumnNames(info.relationshipName, targetPrimaryIdFields),
+		joinAlias, targetPrimaryIdNames,
+	))
+	return nil
+}
+
+// addHasRelationJoinClauses joins a HasOne or HasMany relationship through the foreign key columns of its inverse
+// ForOne relationship, or through the junction table of its inverse ForMany relationship
+func addHasRelationJoinClauses(ctx *entityCompileContext, joinAlias string, info joinInfo, relation yaml.ModelRelation, sourcePrimaryIdFields []string) error {
+	targetModel, inverseName, inverse, inverseErr := getInverseRelation(ctx.registry, info.sourceModelName, info.relationshipName, relation)
+	if inverseErr != nil {
+		return inverseErr
+	}
+
+	joinTable := GetTableNameFromModel(targetModel.Name)
+	sourcePrimaryIdNames := getColumnNamesFromFields(sourcePrimaryIdFields)
+	inverseColumnNames := getForeignKeyColumnNames(inverseName, sourcePrimaryIdFields)
+
+	if yamlops.IsRelationOne(inverse.Type) {
+		addViewJoinClause(ctx, joinTable, joinAlias, getJoinConditions(
+			joinAlias, inverseColumnNames,
+			info.sourceTableName, sourcePrimaryIdNames,
+		))
+		return nil
+	}
+
+	targetPrimaryIdFields, targetPrimaryErr := getModelPrimaryIdFields(targetModel)
+	if targetPrimaryErr != nil {
+		return targetPrimaryErr
+	}
+
+	// The inverse junction table references the source model from its target side
+	junction, junctionErr := resolveForManyJunction(ctx.registry, targetModel, inverseName, inverse)
+	if junctionErr != nil {
+		return junctionErr
+	}
+	junctionAlias := getJunctionJoinAlias(junction.TableName, info)
+	addViewJoinClause(ctx, junction.TableName, junctionAlias, getJoinConditions(
+		junctionAlias, junction.TargetColumnNames,
+		info.sourceTableName, sourcePrimaryIdNames,
+	))
+	addViewJoinClause(ctx, joinTable, joinAlias, getJoinConditions(
+		joinAlias, getColumnNamesFromFields(targetPrimaryIdFields),
+		junctionAlias, junction.SourceColumnNames,
+	))
+	return addJunctionFieldViewColumns(ctx, junction, junctionAlias, joinAlias)
+}
+
+// getInverseRelation returns the target model of a Has relationship and the ForOne or ForMany relationship on it
+// that holds the foreign key, either named through a "Model.Relation" alias or found by its target model
+func getInverseRelation(r *registry.Registry, sourceModelName string, relationName string, relation yaml.ModelRelation) (yaml.Model, string, yaml.ModelRelation, error) {
+	targetModelName, inverseName := getRelationTargetModelName(relationName, relation.Aliased)
+
+	targetModel, modelErr := r.GetModel(targetModelName)
+	if modelErr != nil {
+		return yaml.Model{}, "", yaml.ModelRelation{}, fmt.Errorf("target model %s not found in registry (via relationship %s)", targetModelName, relationName)
+	}
+
+	if inverseName == "" {
+		for _, candidateName := range core.MapKeysSorted(targetModel.Related) {
+			candidate := targetModel.Related[candidateName]
+			if !yamlops.IsRelationFor(candidate.Type) || yamlops.IsRelationPoly(candidate.Type) {
+				continue
+			}
+			if yamlops.GetRelationTargetName(candidateName, candidate.Aliased) != sourceModelName {
+				continue
+			}
+			if inverseName != "" {
+				return yaml.Model{}, "", yaml.ModelRelation{}, fmt.Errorf("relationship %s of model %s has more than one inverse relationship in model %s", relationName, sourceModelName, targetModelName)
+			}
+			inverseName = candidateName
+		}
 	}
 
-	rootPrimaryId, rootExists := model.Identifiers["primary"]
-	if !rootExists {
-		return fmt.Errorf("primary identifier not found in model '%s'", info.sourceModelName)
+	inverse, inverseExists := targetModel.Related[inverseName]
+	if inverseName == "" || !inverseExists || !yamlops.IsRelationFor(inverse.Type) || yamlops.IsRelationPoly(inverse.Type) {
+		return yaml.Model{}, "", yaml.ModelRelation{}, fmt.Errorf("relationship %s of model %s has no inverse ForOne or ForMany relationship in model %s", relationName, sourceModelName, targetModelName)
 	}
+	return targetModel, inverseName, inverse, nil
+}
 
-	relatedPrimaryId, relatedExists := targetModel.Identifiers["primary"]
-	if !relatedExists {
-		return fmt.Errorf("primary identifier not found in model '%s'", targetModelName)
+// getRelationTargetModelName returns the target model of a relationship, and the inverse relationship named by a
+// "Model.Relation" alias if any
+func getRelationTargetModelName(relationName string, aliased string) (string, string) {
+	targetName := yamlops.GetRelationTargetName(relationName, aliased)
+	if aliasParts := strings.SplitN(targetName, ".", 2); len(aliasParts) == 2 {
+		return aliasParts[0], aliasParts[1]
 	}
+	return targetName, ""
+}
 
-	rootPrimaryIdName := strcase.ToSnakeCaseLower(rootPrimaryId.Fields[0])
-	relatedPrimaryIdName := strcase.ToSnakeCaseLower(relatedPrimaryId.Fields[0])
+// getModelPrimaryIdFields returns the fields of a model's primary identifier
+func getModelPrimaryIdFields(model yaml.Model) ([]string, error) {
+	primaryId, primaryExists := model.Identifiers["primary"]
+	if !primaryExists {
+		return nil, fmt.Errorf("primary identifier not found in model '%s'", model.Name)
+	}
+	if len(primaryId.Fields) == 0 {
+		return nil, fmt.Errorf("primary identifier of model '%s' must have at least one field", model.Name)
+	}
+	return primaryId.Fields, nil
+}
 
+// getJoinConditions pairs up the left and right columns of a join, one condition per column for composite keys
+func getJoinConditions(leftTable string, leftColumnNames []string, rightTable string, rightColumnNames []string) []psqldef.JoinCondition {
+	conditions := []psqldef.JoinCondition{}
+	for columnIdx, leftColumnName := range leftColumnNames {
+		conditions = append(conditions, psqldef.JoinCondition{
+			LeftRef:  leftTable + "." + leftColumnName,
+			RightRef: rightTable + "." + rightColumnNames[columnIdx],
+		})
+	}
+	return conditions
+}
+
+// addEnumJoinClause joins the lookup table of an enum field on the field's foreign key column
+func addEnumJoinClause(ctx *entityCompileContext, joinAlias string, info joinInfo) {
+	enumColumnName := GetColumnNameFromField(info.enumFieldName) + "_id"
 	joinClause := psqldef.JoinClause{
-		Type:   "LEFT",
-		Schema: ctx.config.MorpheModelsConfig.Schema,
-		Table:  joinTable,
-		Alias:  joinTable,
-		Conditions: []psqldef.JoinCondition{
-			{
-				LeftRef:  ctx.tableName + "." + rootPrimaryIdName,
-				RightRef: joinTable + "." + relatedPrimaryIdName,
-			},
-		},
+		Type:       "LEFT",
+		Schema:     ctx.config.MorpheEnumsConfig.Schema,
+		Table:      GetEnumTableName(info.enumName),
+		Alias:      joinAlias,
+		Conditions: getJoinConditions(joinAlias, []string{"id"}, info.sourceTableName, []string{enumColumnName}),
+	}
+
+	ctx.view.Joins = append(ctx.view.Joins, joinClause)
+}
+
+// getJunctionJoinAlias returns the alias of the junction table a relationship is joined through, unique to the
+// relation path leading to it
+func getJunctionJoinAlias(junctionTable string, info joinInfo) string {
+	return GetJunctionJoinAlias(junctionTable, info.relationPath[:len(info.relationPath)-1]...)
+}
+
+// addViewJoinClause adds a single left join clause to the view
+func addViewJoinClause(ctx *entityCompileContext, joinTable string, joinAlias string, conditions []psqldef.JoinCondition) {
+	joinClause := psqldef.JoinClause{
+		Type:       "LEFT",
+		Schema:     ctx.config.MorpheModelsConfig.Schema,
+		Table:      joinTable,
+		Alias:      joinAlias,
+		Conditions: conditions,
 	}
 
 	ctx.view.Joins = append(ctx.view.Joins, joinClause)
-	return nil
 }
 
 func triggerCompileMorpheEntityStart(hooks hook.CompileMorpheEntity, config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
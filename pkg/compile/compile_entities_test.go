@@ -2,6 +2,7 @@ package compile_test
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/kalo-build/morphe-go/pkg/registry"
@@ -230,8 +231,8 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView() {
 
 	suite.Len(join.Conditions, 1)
 	joinCondition0 := join.Conditions[0]
-	suite.Equal("users.uuid", joinCondition0.LeftRef)
-	suite.Equal("children.uuid", joinCondition0.RightRef)
+	suite.Equal("children.user_uuid", joinCondition0.LeftRef)
+	suite.Equal("users.uuid", joinCondition0.RightRef)
 }
 
 func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_AlternativeSuffix() {
@@ -530,553 +531,1365 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_EnumField() {
 	column0 := view.Columns[0]
 	suite.Equal(column0.Name, "nationality")
 	suite.Equal(column0.Alias, "")
-	suite.Equal(column0.SourceRef, "users.nationality")
+	suite.Equal(column0.SourceRef, "nationalities.key")
 
 	column1 := view.Columns[1]
 	suite.Equal(column1.Name, "uuid")
 	suite.Equal(column1.Alias, "")
 	suite.Equal(column1.SourceRef, "users.uuid")
 
-	suite.Equal(0, len(view.Joins))
+	suite.Equal(1, len(view.Joins))
+	enumJoin := view.Joins[0]
+	suite.Equal("LEFT", enumJoin.Type)
+	suite.Equal("public", enumJoin.Schema)
+	suite.Equal("nationalities", enumJoin.Table)
+	suite.Equal("nationalities", enumJoin.Alias)
+	suite.Len(enumJoin.Conditions, 1)
+	suite.Equal("nationalities.id", enumJoin.Conditions[0].LeftRef)
+	suite.Equal("users.nationality_id", enumJoin.Conditions[0].RightRef)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_NoSchema() {
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_EnumField_ValueColumn() {
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EnumColumn = cfg.EnumColumnValue
+
 	r := registry.NewRegistry()
 
 	entity0 := yaml.Entity{
 		Name: "User",
 		Fields: map[string]yaml.EntityField{
-			"UUID": {
-				Type: "User.UUID",
-			},
+			"UUID":        {Type: "User.UUID"},
+			"Nationality": {Type: "User.Company.Nationality"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"UUID"},
-			},
+			"primary": {Fields: []string{"UUID"}},
 		},
+		Related: map[string]yaml.EntityRelation{},
 	}
-	r.SetEntity("User", entity0)
-
-	config := suite.getCompileConfig()
-	config.MorpheEntitiesConfig.Schema = ""
+	model0 := yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"UUID": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"UUID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {Type: "ForOne"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"UUID":        {Type: yaml.ModelFieldTypeUUID},
+			"Nationality": {Type: "Nationality"},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"UUID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {Type: "HasMany"},
+		},
+	}
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+		},
+	}
+	r.SetModel("User", model0)
+	r.SetModel("Company", model1)
+	r.SetEnum("Nationality", enum0)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-	suite.ErrorContains(err, "schema is required")
-	suite.Nil(view)
-}
+	suite.Nil(err)
+	suite.Len(view.Columns, 2)
+	suite.Equal("nationality", view.Columns[0].Name)
+	suite.Equal("company_nationalities.value", view.Columns[0].SourceRef)
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_StartHook_Successful() {
-	var hookCalled = false
-	var hooksEntity yaml.Entity
+	// The lookup table is joined after the relationship it is reached through
+	suite.Len(view.Joins, 2)
+	suite.Equal("companies", view.Joins[0].Alias)
 
-	entityHooks := hook.CompileMorpheEntity{
-		OnCompileMorpheEntityStart: func(config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
-			hookCalled = true
-			hooksEntity = entity
+	enumJoin := view.Joins[1]
+	suite.Equal("nationalities", enumJoin.Table)
+	suite.Equal("company_nationalities", enumJoin.Alias)
+	suite.Equal("company_nationalities.id", enumJoin.Conditions[0].LeftRef)
+	suite.Equal("companies.nationality_id", enumJoin.Conditions[0].RightRef)
 
-			modifiedConfig := config
-			modifiedConfig.MorpheEntitiesConfig.ViewNameSuffix = "_hook_modified"
+	// Native enum columns are projected directly
+	config.MorpheEnumsConfig.UseNativeEnums = true
+	nativeView, nativeErr := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-			return modifiedConfig, entity, nil
-		},
-	}
+	suite.Nil(nativeErr)
+	suite.Equal("companies.nationality", nativeView.Columns[0].SourceRef)
+	suite.Len(nativeView.Joins, 1)
+}
 
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Materialized() {
 	config := suite.getCompileConfig()
-	config.EntityHooks = entityHooks
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"User": {Materialized: true},
+	}
+
+	r := registry.NewRegistry()
 
 	entity0 := yaml.Entity{
-		Name: "Basic",
+		Name: "User",
 		Fields: map[string]yaml.EntityField{
-			"ID": {
-				Type: "Basic.ID",
-			},
+			"UUID":  {Type: "User.UUID"},
+			"Email": {Type: "User.Email"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"UUID"}},
+			"email":   {Fields: []string{"Email"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
-
-	r := registry.NewRegistry()
-
 	model0 := yaml.Model{
-		Name: "Basic",
+		Name: "User",
 		Fields: map[string]yaml.ModelField{
-			"ID": {
-				Type: yaml.ModelFieldTypeAutoIncrement,
-			},
+			"UUID":  {Type: yaml.ModelFieldTypeUUID},
+			"Email": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"UUID"}},
 		},
 		Related: map[string]yaml.ModelRelation{},
 	}
-	r.SetModel("Basic", model0)
+	r.SetModel("User", model0)
+	r.SetEntity("User", entity0)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-	suite.True(hookCalled)
-	suite.Equal(entity0.Name, hooksEntity.Name)
-
 	suite.Nil(err)
-	suite.NotNil(view)
-
-	suite.Equal("public", view.Schema)
-	suite.Equal("basic_hook_modified", view.Name)
-
-	suite.Equal("public", view.FromSchema)
-	suite.Equal("basics", view.FromTable)
-	suite.Len(view.Columns, 1)
-
-	column0 := view.Columns[0]
-	suite.Equal("id", column0.Name)
-	suite.Equal("basics.id", column0.SourceRef)
+	suite.True(view.Materialized)
+
+	suite.Len(view.Indices, 2)
+	suite.Equal("idx_user_entities_email", view.Indices[0].Name)
+	suite.Equal([]string{"email"}, view.Indices[0].Columns)
+	suite.True(view.Indices[0].IsUnique)
+	suite.Equal("idx_user_entities_uuid", view.Indices[1].Name)
+	suite.Equal([]string{"uuid"}, view.Indices[1].Columns)
+	suite.True(view.Indices[1].IsUnique)
+
+	suite.Len(view.Functions, 1)
+	refreshFunction := view.Functions[0]
+	suite.Equal("public", refreshFunction.Schema)
+	suite.Equal("refresh_user_entities", refreshFunction.Name)
+	suite.Equal("void", refreshFunction.Returns)
+	suite.Equal([]string{
+		"BEGIN",
+		"\tREFRESH MATERIALIZED VIEW CONCURRENTLY public.user_entities;",
+		"END;",
+	}, refreshFunction.Body)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_StartHook_Failure() {
-	var featureFlag = "otherName"
-	entityHooks := hook.CompileMorpheEntity{
-		OnCompileMorpheEntityStart: func(config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
-			if featureFlag != "otherName" {
-				return config, entity, nil
-			}
-			return config, entity, fmt.Errorf("compile entity start hook error")
-		},
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Materialized_ToManyRelation() {
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"Company": {Materialized: true},
 	}
 
-	config := suite.getCompileConfig()
-	config.EntityHooks = entityHooks
+	r := registry.NewRegistry()
 
 	entity0 := yaml.Entity{
-		Name: "Basic",
+		Name: "Company",
 		Fields: map[string]yaml.EntityField{
-			"ID": {
-				Type: "Basic.ID",
-			},
+			"ID":       {Type: "Company.ID"},
+			"UserName": {Type: "Company.User.Name"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
-
-	r := registry.NewRegistry()
-
 	model0 := yaml.Model{
-		Name: "Basic",
+		Name: "User",
 		Fields: map[string]yaml.ModelField{
-			"ID": {
-				Type: yaml.ModelFieldTypeAutoIncrement,
-			},
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {Type: "ForOne"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
-	r.SetModel("Basic", model0)
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {Type: "HasMany"},
+		},
+	}
+	r.SetModel("User", model0)
+	r.SetModel("Company", model1)
+	r.SetEntity("Company", entity0)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-	suite.NotNil(err)
-	suite.ErrorContains(err, "compile entity start hook error")
+	// Each company repeats once per user, so its identifier cannot be uniquely indexed
+	suite.ErrorIs(err, compile.ErrMaterializedViewToManyJoin)
+	suite.ErrorContains(err, "Company joins Company.User")
 	suite.Nil(view)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_SuccessHook_Successful() {
-	var hookCalled = false
-
-	entityHooks := hook.CompileMorpheEntity{
-		OnCompileMorpheEntitySuccess: func(view *psqldef.View) (*psqldef.View, error) {
-			hookCalled = true
-
-			modifiedView := view.DeepClone()
-			modifiedView.Name = view.Name + "_modified"
-
-			return &modifiedView, nil
-		},
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Materialized_NoIdentifiers() {
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"Company": {Materialized: true},
 	}
 
-	config := suite.getCompileConfig()
-	config.EntityHooks = entityHooks
+	r := registry.NewRegistry()
 
 	entity0 := yaml.Entity{
-		Name: "Basic",
+		Name: "Company",
 		Fields: map[string]yaml.EntityField{
-			"ID": {
-				Type: "Basic.ID",
-			},
-		},
-		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"ID": {Type: "Company.ID"},
 		},
-		Related: map[string]yaml.EntityRelation{},
+		Identifiers: map[string]yaml.EntityIdentifier{},
+		Related:     map[string]yaml.EntityRelation{},
 	}
-
-	r := registry.NewRegistry()
-
 	model0 := yaml.Model{
-		Name: "Basic",
+		Name: "Company",
 		Fields: map[string]yaml.ModelField{
-			"ID": {
-				Type: yaml.ModelFieldTypeAutoIncrement,
-			},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{},
 	}
-	r.SetModel("Basic", model0)
+	r.SetModel("Company", model0)
+	r.SetEntity("Company", entity0)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-	suite.True(hookCalled)
-
-	suite.Nil(err)
-	suite.NotNil(view)
-
-	suite.Equal("public", view.Schema)
-	suite.Equal("basic_entities_modified", view.Name)
-
-	suite.Equal("public", view.FromSchema)
-	suite.Equal("basics", view.FromTable)
-	suite.Len(view.Columns, 1)
-
-	column0 := view.Columns[0]
-	suite.Equal("id", column0.Name)
-	suite.Equal("basics.id", column0.SourceRef)
+	// Without a unique index the view could not be refreshed concurrently
+	suite.ErrorContains(err, "entity 'Company' has no identifiers")
+	suite.Nil(view)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_SuccessHook_Failure() {
-	var featureFlag = "otherName"
-	entityHooks := hook.CompileMorpheEntity{
-		OnCompileMorpheEntitySuccess: func(view *psqldef.View) (*psqldef.View, error) {
-			if featureFlag != "otherName" {
-				return view, nil
-			}
-			return nil, fmt.Errorf("compile entity success hook error")
-		},
-	}
-
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_LongEnumName() {
 	config := suite.getCompileConfig()
-	config.EntityHooks = entityHooks
+
+	r := registry.NewRegistry()
 
 	entity0 := yaml.Entity{
-		Name: "Basic",
+		Name: "User",
 		Fields: map[string]yaml.EntityField{
-			"ID": {
-				Type: "Basic.ID",
-			},
+			"ID":     {Type: "User.ID"},
+			"Status": {Type: "User.Status"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
-
-	r := registry.NewRegistry()
-
 	model0 := yaml.Model{
-		Name: "Basic",
+		Name: "User",
 		Fields: map[string]yaml.ModelField{
-			"ID": {
-				Type: yaml.ModelFieldTypeAutoIncrement,
-			},
+			"ID":     {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Status": {Type: "ExtremelyLongUserAccountVerificationStatusForComplianceReview"},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{},
 	}
-	r.SetModel("Basic", model0)
+	enum0 := yaml.Enum{
+		Name: "ExtremelyLongUserAccountVerificationStatusForComplianceReview",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"Verified": "verified",
+		},
+	}
+	r.SetModel("User", model0)
+	r.SetEnum(enum0.Name, enum0)
+	r.SetEntity("User", entity0)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-	suite.NotNil(err)
-	suite.ErrorContains(err, "compile entity success hook error")
-	suite.Nil(view)
+	suite.Nil(err)
+	suite.Len(view.Joins, 1)
+	// The view joins the lookup table under the name it is created with
+	suite.Equal(compile.GetEnumTableName(enum0.Name), view.Joins[0].Table)
+	suite.Equal("extremely_long_user_account_verification_status_for_compliance_reviews", view.Joins[0].Table)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FailureHook() {
-	var hookCalled = false
-	var originalError error
-
-	entityHooks := hook.CompileMorpheEntity{
-		OnCompileMorpheEntityStart: func(config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
-			return config, entity, fmt.Errorf("original error")
-		},
-		OnCompileMorpheEntityFailure: func(config cfg.MorpheConfig, entity yaml.Entity, err error) error {
-			hookCalled = true
-			originalError = err
-			return fmt.Errorf("enhanced error: %w", err)
-		},
-	}
-
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_JoinAliasConflict() {
 	config := suite.getCompileConfig()
-	config.EntityHooks = entityHooks
+
+	r := registry.NewRegistry()
 
 	entity0 := yaml.Entity{
-		Name: "Basic",
+		Name: "Person",
 		Fields: map[string]yaml.EntityField{
-			"ID": {
-				Type: "Basic.ID",
-			},
+			"ID":                       {Type: "Person.ID"},
+			"WorkContactAddressStreet": {Type: "Person.WorkContact.Address.Street"},
+			"WorkStreet":               {Type: "Person.WorkContactAddress.Street"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
-
-	r := registry.NewRegistry()
-
 	model0 := yaml.Model{
-		Name: "Basic",
+		Name: "Person",
 		Fields: map[string]yaml.ModelField{
-			"ID": {
-				Type: yaml.ModelFieldTypeAutoIncrement,
-			},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {
-				Fields: []string{"ID"},
-			},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"WorkContact":        {Type: "ForOne", Aliased: "Contact"},
+			"WorkContactAddress": {Type: "ForOne", Aliased: "Address"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Contact",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Address": {Type: "ForOne"},
+		},
+	}
+	model2 := yaml.Model{
+		Name: "Address",
+		Fields: map[string]yaml.ModelField{
+			"ID":     {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Street": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{},
 	}
-	r.SetModel("Basic", model0)
+	r.SetModel("Person", model0)
+	r.SetModel("Contact", model1)
+	r.SetModel("Address", model2)
+	r.SetEntity("Person", entity0)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
-	suite.True(hookCalled)
-	suite.NotNil(originalError)
-	suite.Equal("original error", originalError.Error())
-
-	suite.NotNil(err)
-	suite.ErrorContains(err, "enhanced error")
-	suite.ErrorContains(err, "original error")
-
+	suite.ErrorIs(err, compile.ErrJoinAliasConflict)
+	suite.ErrorContains(err, "Person.WorkContact.Address and Person.WorkContactAddress are both aliased work_contact_addresses")
 	suite.Nil(view)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_ForOnePoly() {
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_JoinAliasConflict_RootTable() {
 	config := suite.getCompileConfig()
+
 	r := registry.NewRegistry()
 
-	// Comment entity for a model that has ForOnePoly relationships
-	// Test that entity compilation works correctly for models with polymorphic relationships
-	commentEntity := yaml.Entity{
-		Name: "Comment",
+	entity0 := yaml.Entity{
+		Name: "Person",
 		Fields: map[string]yaml.EntityField{
-			"id": {
-				Type:       "Comment.id",
-				Attributes: []string{"immutable"},
-			},
-			"content": {
-				Type: "Comment.content",
-			},
-			// Only reference regular fields, not polymorphic columns
-			// This tests that entity compilation works for models with polymorphic relationships
+			"ID":       {Type: "Person.ID"},
+			"ParentID": {Type: "Person.Person.ID"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"id"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
-
-	// Comment model with ForOnePoly relationship
-	commentModel := yaml.Model{
-		Name: "Comment",
+	model0 := yaml.Model{
+		Name: "Person",
 		Fields: map[string]yaml.ModelField{
-			"id": {
-				Type: yaml.ModelFieldTypeUUID,
-			},
-			"content": {
-				Type: yaml.ModelFieldTypeString,
-			},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Commentable": {
-				Type: "ForOnePoly",
-				For:  []string{"Post", "Article"},
-			},
+			"Person": {Type: "ForOne"},
 		},
 	}
+	r.SetModel("Person", model0)
+	r.SetEntity("Person", entity0)
 
-	// Target models
-	postModel := yaml.Model{
-		Name: "Post",
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.ErrorIs(err, compile.ErrJoinAliasConflict)
+	suite.ErrorContains(err, "Person.Person is aliased people like the root table")
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Writable() {
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"User": {Writable: true},
+	}
+
+	r := registry.NewRegistry()
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"ID":          {Type: "User.ID"},
+			"Name":        {Type: "User.Name"},
+			"Nationality": {Type: "User.Nationality"},
+			"CompanyName": {Type: "User.Company.Name"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	model0 := yaml.Model{
+		Name: "User",
 		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+			"ID":          {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name":        {Type: yaml.ModelFieldTypeString},
+			"Nationality": {Type: "Nationality"},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {Type: "ForOne"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
-
-	articleModel := yaml.Model{
-		Name: "Article",
+	model1 := yaml.Model{
+		Name: "Company",
 		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {Type: "HasMany"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+		},
+	}
+	r.SetModel("User", model0)
+	r.SetModel("Company", model1)
+	r.SetEnum("Nationality", enum0)
+	r.SetEntity("User", entity0)
 
-	r.SetModel("Comment", commentModel)
-	r.SetModel("Post", postModel)
-	r.SetModel("Article", articleModel)
-
-	view, err := compile.MorpheEntityToPSQLView(config, r, commentEntity)
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
 	suite.Nil(err)
-	suite.NotNil(view)
-	suite.Equal("comment_entities", view.Name)
-	suite.Equal("comments", view.FromTable)
-
-	// Should have only regular fields (polymorphic relationship doesn't affect entity views)
-	suite.Len(view.Columns, 2)
-
-	suite.Equal("content", view.Columns[0].Name)
-	suite.Equal("comments.content", view.Columns[0].SourceRef)
-
-	suite.Equal("id", view.Columns[1].Name)
-	suite.Equal("comments.id", view.Columns[1].SourceRef)
-
-	// Should have no joins
-	suite.Len(view.Joins, 0)
+	suite.Len(view.Functions, 1)
+	writeFunction := view.Functions[0]
+	suite.Equal("public", writeFunction.Schema)
+	suite.Equal("write_user_entities", writeFunction.Name)
+	suite.Equal("trigger", writeFunction.Returns)
+	suite.Equal("plpgsql", writeFunction.Language)
+	suite.Equal([]string{
+		"BEGIN",
+		"\tIF TG_OP = 'DELETE' THEN",
+		"\t\tDELETE FROM public.users",
+		"\t\tWHERE id = OLD.id;",
+		"\t\tRETURN OLD;",
+		"\tEND IF;",
+		"",
+		"\tIF TG_OP = 'INSERT' THEN",
+		"\t\tIF NEW.company_name IS NOT NULL THEN",
+		"\t\t\tRAISE EXCEPTION 'inserts into user_entities cannot set columns of related rows (company_name)';",
+		"\t\tEND IF;",
+		"\t\tINSERT INTO public.users (name, nationality_id)",
+		"\t\tVALUES (NEW.name, (SELECT id FROM public.nationalities WHERE key = NEW.nationality))",
+		"\t\tRETURNING id INTO NEW.id;",
+		"\tELSE",
+		"\t\tUPDATE public.users",
+		"\t\tSET id = NEW.id, name = NEW.name, nationality_id = (SELECT id FROM public.nationalities WHERE key = NEW.nationality)",
+		"\t\tWHERE id = OLD.id;",
+		"",
+		"\t\tUPDATE public.companies",
+		"\t\tSET name = NEW.company_name",
+		"\t\tWHERE (companies.id) IN (",
+		"\t\t\tSELECT companies.id",
+		"\t\t\tFROM public.users",
+		"\t\t\tLEFT JOIN public.companies",
+		"\t\t\t\tON users.company_id = companies.id",
+		"\t\t\tLEFT JOIN public.nationalities",
+		"\t\t\t\tON nationalities.id = users.nationality_id",
+		"\t\t\tWHERE users.id = NEW.id",
+		"\t\t);",
+		"\tEND IF;",
+		"",
+		"\tRETURN NEW;",
+		"END;",
+	}, writeFunction.Body)
+
+	suite.Len(view.Triggers, 1)
+	writeTrigger := view.Triggers[0]
+	suite.Equal("public", writeTrigger.Schema)
+	suite.Equal("trg_write_user_entities", writeTrigger.Name)
+	suite.Equal("user_entities", writeTrigger.TableName)
+	suite.Equal("INSTEAD OF", writeTrigger.Timing)
+	suite.Equal([]string{"INSERT", "UPDATE", "DELETE"}, writeTrigger.Events)
+	suite.Equal("public", writeTrigger.FunctionSchema)
+	suite.Equal("write_user_entities", writeTrigger.FunctionName)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_ForManyPoly() {
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Writable_InsertRelatedColumns() {
 	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"User": {Writable: true},
+	}
+
 	r := registry.NewRegistry()
 
-	// Tag entity with ForManyPoly relationship
-	tagEntity := yaml.Entity{
-		Name: "Tag",
+	entity0 := yaml.Entity{
+		Name: "User",
 		Fields: map[string]yaml.EntityField{
-			"id": {
-				Type:       "Tag.id",
-				Attributes: []string{"immutable"},
-			},
-			"name": {
-				Type: "Tag.name",
-			},
-			// Don't reference the polymorphic relationship directly to avoid validation errors
-			// The test will verify that only regular fields are included
+			"ID":          {Type: "User.ID"},
+			"Name":        {Type: "User.Name"},
+			"CompanyName": {Type: "User.Company.Name"},
+			"CompanyCity": {Type: "User.Company.City"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"id"},
-			},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
-
-	// Tag model with ForManyPoly relationship
-	tagModel := yaml.Model{
-		Name: "Tag",
+	model0 := yaml.Model{
+		Name: "User",
 		Fields: map[string]yaml.ModelField{
-			"id": {
-				Type: yaml.ModelFieldTypeUUID,
-			},
-			"name": {
-				Type: yaml.ModelFieldTypeString,
-			},
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Taggable": {
-				Type: "ForManyPoly",
-				For:  []string{"Post", "Product"},
-			},
+			"Company": {Type: "ForOne"},
 		},
 	}
-
-	// Target models
-	postModel := yaml.Model{
-		Name: "Post",
+	model1 := yaml.Model{
+		Name: "Company",
 		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name": {Type: yaml.ModelFieldTypeString},
+			"City": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
-		},
-		Related: map[string]yaml.ModelRelation{},
-	}
-
-	productModel := yaml.Model{
-		Name: "Product",
-		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+			"primary": {Fields: []string{"ID"}},
 		},
-		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+		Related: map[string]yaml.ModelRelation{
+			"User": {Type: "HasMany"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
+	r.SetModel("User", model0)
+	r.SetModel("Company", model1)
+	r.SetEntity("User", entity0)
 
-	r.SetModel("Tag", tagModel)
-	r.SetModel("Post", postModel)
-	r.SetModel("Product", productModel)
-
-	view, err := compile.MorpheEntityToPSQLView(config, r, tagEntity)
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
 
 	suite.Nil(err)
-	suite.NotNil(view)
+	suite.Len(view.Functions, 1)
+	body := view.Functions[0].Body
+	insertIdx := slices.Index(body, "\tIF TG_OP = 'INSERT' THEN")
+	suite.GreaterOrEqual(insertIdx, 0)
+	suite.Equal([]string{
+		"\tIF TG_OP = 'INSERT' THEN",
+		"\t\tIF NEW.company_city IS NOT NULL OR NEW.company_name IS NOT NULL THEN",
+		"\t\t\tRAISE EXCEPTION 'inserts into user_entities cannot set columns of related rows (company_city, company_name)';",
+		"\t\tEND IF;",
+		"\t\tINSERT INTO public.users (name)",
+	}, body[insertIdx:insertIdx+5])
+
+	elseIdx := slices.Index(body, "\tELSE")
+	companiesUpdateIdx := slices.Index(body, "\t\tUPDATE public.companies")
+	suite.Greater(companiesUpdateIdx, elseIdx)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Writable_ToManyRelation() {
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"Company": {Writable: true},
+	}
+
+	r := registry.NewRegistry()
+
+	entity0 := yaml.Entity{
+		Name: "Company",
+		Fields: map[string]yaml.EntityField{
+			"ID":       {Type: "Company.ID"},
+			"UserName": {Type: "Company.User.Name"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	model0 := yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {Type: "ForOne"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Company",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"User": {Type: "HasMany"},
+		},
+	}
+	r.SetModel("User", model0)
+	r.SetModel("Company", model1)
+	r.SetEntity("Company", entity0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.ErrorContains(err, "column user_name of writable entity Company is reached through to-many relationship User and cannot be written")
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Writable_Materialized() {
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"User": {Materialized: true, Writable: true},
+	}
+
+	r := registry.NewRegistry()
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"UUID": {Type: "User.UUID"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"UUID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	r.SetEntity("User", entity0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.ErrorIs(err, cfg.ErrWritableMaterializedView)
+	suite.ErrorContains(err, "entity 'User'")
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_SoftDelete() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"User": {SoftDelete: true},
+	}
+	config.MorpheEntitiesConfig.EntityOptions = map[string]cfg.MorpheEntityOptions{
+		"User": {Writable: true},
+	}
+
+	r := registry.NewRegistry()
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"ID": {Type: "User.ID"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	model0 := yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r.SetModel("User", model0)
+	r.SetEntity("User", entity0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.Nil(err)
+	suite.Equal("users.deleted_at IS NULL", view.WhereClause)
+
+	// Deleting through a writable view marks the row deleted
+	suite.Len(view.Functions, 1)
+	suite.Equal([]string{
+		"BEGIN",
+		"\tIF TG_OP = 'DELETE' THEN",
+		"\t\tUPDATE public.users",
+		"\t\tSET deleted_at = NOW()",
+		"\t\tWHERE id = OLD.id;",
+		"\t\tRETURN OLD;",
+		"\tEND IF;",
+	}, view.Functions[0].Body[:7])
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_NoSchema() {
+	r := registry.NewRegistry()
+
+	entity0 := yaml.Entity{
+		Name: "User",
+		Fields: map[string]yaml.EntityField{
+			"UUID": {
+				Type: "User.UUID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"UUID"},
+			},
+		},
+	}
+	r.SetEntity("User", entity0)
+
+	config := suite.getCompileConfig()
+	config.MorpheEntitiesConfig.Schema = ""
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.ErrorContains(err, "schema is required")
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_StartHook_Successful() {
+	var hookCalled = false
+	var hooksEntity yaml.Entity
+
+	entityHooks := hook.CompileMorpheEntity{
+		OnCompileMorpheEntityStart: func(config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
+			hookCalled = true
+			hooksEntity = entity
+
+			modifiedConfig := config
+			modifiedConfig.MorpheEntitiesConfig.ViewNameSuffix = "_hook_modified"
+
+			return modifiedConfig, entity, nil
+		},
+	}
+
+	config := suite.getCompileConfig()
+	config.EntityHooks = entityHooks
+
+	entity0 := yaml.Entity{
+		Name: "Basic",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Basic.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r.SetModel("Basic", model0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.True(hookCalled)
+	suite.Equal(entity0.Name, hooksEntity.Name)
+
+	suite.Nil(err)
+	suite.NotNil(view)
+
+	suite.Equal("public", view.Schema)
+	suite.Equal("basic_hook_modified", view.Name)
+
+	suite.Equal("public", view.FromSchema)
+	suite.Equal("basics", view.FromTable)
+	suite.Len(view.Columns, 1)
+
+	column0 := view.Columns[0]
+	suite.Equal("id", column0.Name)
+	suite.Equal("basics.id", column0.SourceRef)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_StartHook_Failure() {
+	var featureFlag = "otherName"
+	entityHooks := hook.CompileMorpheEntity{
+		OnCompileMorpheEntityStart: func(config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
+			if featureFlag != "otherName" {
+				return config, entity, nil
+			}
+			return config, entity, fmt.Errorf("compile entity start hook error")
+		},
+	}
+
+	config := suite.getCompileConfig()
+	config.EntityHooks = entityHooks
+
+	entity0 := yaml.Entity{
+		Name: "Basic",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Basic.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r.SetModel("Basic", model0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.NotNil(err)
+	suite.ErrorContains(err, "compile entity start hook error")
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_SuccessHook_Successful() {
+	var hookCalled = false
+
+	entityHooks := hook.CompileMorpheEntity{
+		OnCompileMorpheEntitySuccess: func(view *psqldef.View) (*psqldef.View, error) {
+			hookCalled = true
+
+			modifiedView := view.DeepClone()
+			modifiedView.Name = view.Name + "_modified"
+
+			return &modifiedView, nil
+		},
+	}
+
+	config := suite.getCompileConfig()
+	config.EntityHooks = entityHooks
+
+	entity0 := yaml.Entity{
+		Name: "Basic",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Basic.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r.SetModel("Basic", model0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.True(hookCalled)
+
+	suite.Nil(err)
+	suite.NotNil(view)
+
+	suite.Equal("public", view.Schema)
+	suite.Equal("basic_entities_modified", view.Name)
+
+	suite.Equal("public", view.FromSchema)
+	suite.Equal("basics", view.FromTable)
+	suite.Len(view.Columns, 1)
+
+	column0 := view.Columns[0]
+	suite.Equal("id", column0.Name)
+	suite.Equal("basics.id", column0.SourceRef)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_SuccessHook_Failure() {
+	var featureFlag = "otherName"
+	entityHooks := hook.CompileMorpheEntity{
+		OnCompileMorpheEntitySuccess: func(view *psqldef.View) (*psqldef.View, error) {
+			if featureFlag != "otherName" {
+				return view, nil
+			}
+			return nil, fmt.Errorf("compile entity success hook error")
+		},
+	}
+
+	config := suite.getCompileConfig()
+	config.EntityHooks = entityHooks
+
+	entity0 := yaml.Entity{
+		Name: "Basic",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Basic.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r.SetModel("Basic", model0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.NotNil(err)
+	suite.ErrorContains(err, "compile entity success hook error")
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FailureHook() {
+	var hookCalled = false
+	var originalError error
+
+	entityHooks := hook.CompileMorpheEntity{
+		OnCompileMorpheEntityStart: func(config cfg.MorpheConfig, entity yaml.Entity) (cfg.MorpheConfig, yaml.Entity, error) {
+			return config, entity, fmt.Errorf("original error")
+		},
+		OnCompileMorpheEntityFailure: func(config cfg.MorpheConfig, entity yaml.Entity, err error) error {
+			hookCalled = true
+			originalError = err
+			return fmt.Errorf("enhanced error: %w", err)
+		},
+	}
+
+	config := suite.getCompileConfig()
+	config.EntityHooks = entityHooks
+
+	entity0 := yaml.Entity{
+		Name: "Basic",
+		Fields: map[string]yaml.EntityField{
+			"ID": {
+				Type: "Basic.ID",
+			},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	r := registry.NewRegistry()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r.SetModel("Basic", model0)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, entity0)
+
+	suite.True(hookCalled)
+	suite.NotNil(originalError)
+	suite.Equal("original error", originalError.Error())
+
+	suite.NotNil(err)
+	suite.ErrorContains(err, "enhanced error")
+	suite.ErrorContains(err, "original error")
+
+	suite.Nil(view)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_ForOnePoly() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	// Comment entity for a model that has ForOnePoly relationships
+	// Test that entity compilation works correctly for models with polymorphic relationships
+	commentEntity := yaml.Entity{
+		Name: "Comment",
+		Fields: map[string]yaml.EntityField{
+			"id": {
+				Type:       "Comment.id",
+				Attributes: []string{"immutable"},
+			},
+			"content": {
+				Type: "Comment.content",
+			},
+			// Only reference regular fields, not polymorphic columns
+			// This tests that entity compilation works for models with polymorphic relationships
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"id"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	// Comment model with ForOnePoly relationship
+	commentModel := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"id": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"content": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For:  []string{"Post", "Article"},
+			},
+		},
+	}
+
+	// Target models
+	postModel := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	articleModel := yaml.Model{
+		Name: "Article",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r.SetModel("Comment", commentModel)
+	r.SetModel("Post", postModel)
+	r.SetModel("Article", articleModel)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, commentEntity)
+
+	suite.Nil(err)
+	suite.NotNil(view)
+	suite.Equal("comment_entities", view.Name)
+	suite.Equal("comments", view.FromTable)
+
+	// Should have only regular fields (polymorphic relationship doesn't affect entity views)
+	suite.Len(view.Columns, 2)
+
+	suite.Equal("content", view.Columns[0].Name)
+	suite.Equal("comments.content", view.Columns[0].SourceRef)
+
+	suite.Equal("id", view.Columns[1].Name)
+	suite.Equal("comments.id", view.Columns[1].SourceRef)
+
+	// Should have no joins
+	suite.Len(view.Joins, 0)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_ForManyPoly() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	// Tag entity with ForManyPoly relationship
+	tagEntity := yaml.Entity{
+		Name: "Tag",
+		Fields: map[string]yaml.EntityField{
+			"id": {
+				Type:       "Tag.id",
+				Attributes: []string{"immutable"},
+			},
+			"name": {
+				Type: "Tag.name",
+			},
+			// Don't reference the polymorphic relationship directly to avoid validation errors
+			// The test will verify that only regular fields are included
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"id"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	// Tag model with ForManyPoly relationship
+	tagModel := yaml.Model{
+		Name: "Tag",
+		Fields: map[string]yaml.ModelField{
+			"id": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"name": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Taggable": {
+				Type: "ForManyPoly",
+				For:  []string{"Post", "Product"},
+			},
+		},
+	}
+
+	// Target models
+	postModel := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	productModel := yaml.Model{
+		Name: "Product",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r.SetModel("Tag", tagModel)
+	r.SetModel("Post", postModel)
+	r.SetModel("Product", productModel)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, tagEntity)
+
+	suite.Nil(err)
+	suite.NotNil(view)
 	suite.Equal("tag_entities", view.Name)
 	suite.Equal("tags", view.FromTable)
 
-	// ForManyPoly should be simple - only regular fields, no junction table materialization
-	// Should have columns: id, name
+	// ForManyPoly should be simple - only regular fields, no junction table materialization
+	// Should have columns: id, name
+	suite.Len(view.Columns, 2)
+
+	suite.Equal("id", view.Columns[0].Name)
+	suite.Equal("tags.id", view.Columns[0].SourceRef)
+
+	suite.Equal("name", view.Columns[1].Name)
+	suite.Equal("tags.name", view.Columns[1].SourceRef)
+
+	// Should have no joins for ForManyPoly relationships
+	suite.Len(view.Joins, 0)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	// Post entity with HasOnePoly relationship
+	postEntity := yaml.Entity{
+		Name: "Post",
+		Fields: map[string]yaml.EntityField{
+			"id": {
+				Type:       "Post.id",
+				Attributes: []string{"immutable"},
+			},
+			"title": {
+				Type: "Post.title",
+			},
+			// Don't reference the polymorphic relationship directly to avoid validation errors
+			// The test will verify that only regular fields are included
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {
+				Fields: []string{"id"},
+			},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+
+	// Post model with HasOnePoly relationship
+	postModel := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"id": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+			"title": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Comment": {
+				Type:    "HasOnePoly",
+				Through: "Commentable",
+			},
+		},
+	}
+
+	// Comment model with the forward ForOnePoly relationship
+	commentModel := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"id":      {Type: yaml.ModelFieldTypeUUID},
+			"content": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For:  []string{"Post", "Article"},
+			},
+		},
+	}
+
+	articleModel := yaml.Model{
+		Name: "Article",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	r.SetModel("Post", postModel)
+	r.SetModel("Comment", commentModel)
+	r.SetModel("Article", articleModel)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, postEntity)
+
+	suite.Nil(err)
+	suite.NotNil(view)
+	suite.Equal("post_entities", view.Name)
+	suite.Equal("posts", view.FromTable)
+
+	// HasOnePoly should not be materialized - only regular fields
+	// Should have columns: id, title
 	suite.Len(view.Columns, 2)
 
 	suite.Equal("id", view.Columns[0].Name)
-	suite.Equal("tags.id", view.Columns[0].SourceRef)
+	suite.Equal("posts.id", view.Columns[0].SourceRef)
 
-	suite.Equal("name", view.Columns[1].Name)
-	suite.Equal("tags.name", view.Columns[1].SourceRef)
+	suite.Equal("title", view.Columns[1].Name)
+	suite.Equal("posts.title", view.Columns[1].SourceRef)
 
-	// Should have no joins for ForManyPoly relationships
+	// Should have no joins for HasOnePoly relationships
 	suite.Len(view.Joins, 0)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasManyPoly() {
 	config := suite.getCompileConfig()
 	r := registry.NewRegistry()
 
-	// Post entity with HasOnePoly relationship
+	// Post entity with HasManyPoly relationship
 	postEntity := yaml.Entity{
 		Name: "Post",
 		Fields: map[string]yaml.EntityField{
@@ -1098,7 +1911,7 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
 		Related: map[string]yaml.EntityRelation{},
 	}
 
-	// Post model with HasOnePoly relationship
+	// Post model with HasManyPoly relationship
 	postModel := yaml.Model{
 		Name: "Post",
 		Fields: map[string]yaml.ModelField{
@@ -1113,33 +1926,33 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
 			"primary": {Fields: []string{"id"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Comment": {
-				Type:    "HasOnePoly",
-				Through: "Commentable",
+			"Tag": {
+				Type:    "HasManyPoly",
+				Through: "Taggable",
 			},
 		},
 	}
 
-	// Comment model with the forward ForOnePoly relationship
-	commentModel := yaml.Model{
-		Name: "Comment",
+	// Tag model with the forward ForManyPoly relationship
+	tagModel := yaml.Model{
+		Name: "Tag",
 		Fields: map[string]yaml.ModelField{
-			"id":      {Type: yaml.ModelFieldTypeUUID},
-			"content": {Type: yaml.ModelFieldTypeString},
+			"id":   {Type: yaml.ModelFieldTypeUUID},
+			"name": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
 			"primary": {Fields: []string{"id"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Commentable": {
-				Type: "ForOnePoly",
-				For:  []string{"Post", "Article"},
+			"Taggable": {
+				Type: "ForManyPoly",
+				For:  []string{"Post", "Product"},
 			},
 		},
 	}
 
-	articleModel := yaml.Model{
-		Name: "Article",
+	productModel := yaml.Model{
+		Name: "Product",
 		Fields: map[string]yaml.ModelField{
 			"id": {Type: yaml.ModelFieldTypeUUID},
 		},
@@ -1150,8 +1963,8 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
 	}
 
 	r.SetModel("Post", postModel)
-	r.SetModel("Comment", commentModel)
-	r.SetModel("Article", articleModel)
+	r.SetModel("Tag", tagModel)
+	r.SetModel("Product", productModel)
 
 	view, err := compile.MorpheEntityToPSQLView(config, r, postEntity)
 
@@ -1160,7 +1973,7 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
 	suite.Equal("post_entities", view.Name)
 	suite.Equal("posts", view.FromTable)
 
-	// HasOnePoly should not be materialized - only regular fields
+	// HasManyPoly should not be materialized - only regular fields
 	// Should have columns: id, title
 	suite.Len(view.Columns, 2)
 
@@ -1170,27 +1983,30 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasOnePoly() {
 	suite.Equal("title", view.Columns[1].Name)
 	suite.Equal("posts.title", view.Columns[1].SourceRef)
 
-	// Should have no joins for HasOnePoly relationships
+	// Should have no joins for HasManyPoly relationships
 	suite.Len(view.Joins, 0)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasManyPoly() {
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Mixed_Polymorphic_And_Regular() {
 	config := suite.getCompileConfig()
 	r := registry.NewRegistry()
 
-	// Post entity with HasManyPoly relationship
-	postEntity := yaml.Entity{
-		Name: "Post",
+	// Entity with mixed polymorphic and regular relationships
+	mixedEntity := yaml.Entity{
+		Name: "Mixed",
 		Fields: map[string]yaml.EntityField{
 			"id": {
-				Type:       "Post.id",
+				Type:       "Mixed.id",
 				Attributes: []string{"immutable"},
 			},
-			"title": {
-				Type: "Post.title",
+			"name": {
+				Type: "Mixed.name",
 			},
-			// Don't reference the polymorphic relationship directly to avoid validation errors
-			// The test will verify that only regular fields are included
+			"user": {
+				Type: "Mixed.User.email", // Regular relationship - should create join
+			},
+			// Note: Cannot reference polymorphic relationships directly due to entity validation
+			// This test verifies that regular relationships work correctly alongside polymorphic ones
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
 			"primary": {
@@ -1200,14 +2016,14 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasManyPoly()
 		Related: map[string]yaml.EntityRelation{},
 	}
 
-	// Post model with HasManyPoly relationship
-	postModel := yaml.Model{
-		Name: "Post",
+	// Mixed model with various relationship types
+	mixedModel := yaml.Model{
+		Name: "Mixed",
 		Fields: map[string]yaml.ModelField{
 			"id": {
 				Type: yaml.ModelFieldTypeUUID,
 			},
-			"title": {
+			"name": {
 				Type: yaml.ModelFieldTypeString,
 			},
 		},
@@ -1215,214 +2031,544 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_HasManyPoly()
 			"primary": {Fields: []string{"id"}},
 		},
 		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For:  []string{"Post", "Article"},
+			},
+			"User": {
+				Type: "ForOne", // Regular relationship
+			},
 			"Tag": {
 				Type:    "HasManyPoly",
 				Through: "Taggable",
 			},
 		},
 	}
-
-	// Tag model with the forward ForManyPoly relationship
-	tagModel := yaml.Model{
-		Name: "Tag",
+
+	// Supporting models
+	userModel := yaml.Model{
+		Name: "User",
+		Fields: map[string]yaml.ModelField{
+			"id":    {Type: yaml.ModelFieldTypeUUID},
+			"email": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	postModel := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	articleModel := yaml.Model{
+		Name: "Article",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	tagModel := yaml.Model{
+		Name: "Tag",
+		Fields: map[string]yaml.ModelField{
+			"id":   {Type: yaml.ModelFieldTypeUUID},
+			"name": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Taggable": {
+				Type: "ForManyPoly",
+				For:  []string{"Mixed", "Product"},
+			},
+		},
+	}
+
+	r.SetModel("Mixed", mixedModel)
+	r.SetModel("User", userModel)
+	r.SetModel("Post", postModel)
+	r.SetModel("Article", articleModel)
+	r.SetModel("Tag", tagModel)
+
+	view, err := compile.MorpheEntityToPSQLView(config, r, mixedEntity)
+
+	suite.Nil(err)
+	suite.NotNil(view)
+	suite.Equal("mixed_entities", view.Name)
+	suite.Equal("mixeds", view.FromTable)
+
+	// Should have columns: id, name, user
+	// (polymorphic relationships don't interfere with regular relationships)
+	suite.Len(view.Columns, 3)
+
+	// Check regular columns
+	suite.Equal("id", view.Columns[0].Name)
+	suite.Equal("mixeds.id", view.Columns[0].SourceRef)
+
+	suite.Equal("name", view.Columns[1].Name)
+	suite.Equal("mixeds.name", view.Columns[1].SourceRef)
+
+	suite.Equal("user", view.Columns[2].Name)
+	suite.Equal("users.email", view.Columns[2].SourceRef)
+
+	// Should have one join for the regular User relationship
+	suite.Len(view.Joins, 1)
+
+	join := view.Joins[0]
+	suite.Equal("LEFT", join.Type)
+	suite.Equal("users", join.Table)
+	suite.Equal("users", join.Alias)
+	suite.Len(join.Conditions, 1)
+	suite.Equal("mixeds.user_id", join.Conditions[0].LeftRef)
+	suite.Equal("users.id", join.Conditions[0].RightRef)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_ForMany() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	personModel := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Project": {Type: "ForMany"},
+		},
+	}
+	projectModel := yaml.Model{
+		Name: "Project",
+		Fields: map[string]yaml.ModelField{
+			"ID":    {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Title": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person": {Type: "HasMany"},
+		},
+	}
+	personEntity := yaml.Entity{
+		Name: "Person",
+		Fields: map[string]yaml.EntityField{
+			"ID":           {Type: "Person.ID"},
+			"ProjectTitle": {Type: "Person.Project.Title"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	projectEntity := yaml.Entity{
+		Name: "Project",
+		Fields: map[string]yaml.EntityField{
+			"ID":       {Type: "Project.ID"},
+			"PersonID": {Type: "Project.Person.ID"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	r.SetModel("Person", personModel)
+	r.SetModel("Project", projectModel)
+	r.SetEntity("Person", personEntity)
+	r.SetEntity("Project", projectEntity)
+
+	personView, personErr := compile.MorpheEntityToPSQLView(config, r, personEntity)
+
+	suite.Nil(personErr)
+	suite.Len(personView.Joins, 2)
+
+	junctionJoin := personView.Joins[0]
+	suite.Equal("person_projects", junctionJoin.Table)
+	suite.Len(junctionJoin.Conditions, 1)
+	suite.Equal("person_projects.person_id", junctionJoin.Conditions[0].LeftRef)
+	suite.Equal("people.id", junctionJoin.Conditions[0].RightRef)
+
+	projectJoin := personView.Joins[1]
+	suite.Equal("projects", projectJoin.Table)
+	suite.Len(projectJoin.Conditions, 1)
+	suite.Equal("projects.id", projectJoin.Conditions[0].LeftRef)
+	suite.Equal("person_projects.project_id", projectJoin.Conditions[0].RightRef)
+
+	// The inverse HasMany relationship goes through the same junction table
+	projectView, projectErr := compile.MorpheEntityToPSQLView(config, r, projectEntity)
+
+	suite.Nil(projectErr)
+	suite.Len(projectView.Joins, 2)
+
+	inverseJunctionJoin := projectView.Joins[0]
+	suite.Equal("person_projects", inverseJunctionJoin.Table)
+	suite.Equal("person_projects.project_id", inverseJunctionJoin.Conditions[0].LeftRef)
+	suite.Equal("projects.id", inverseJunctionJoin.Conditions[0].RightRef)
+
+	peopleJoin := projectView.Joins[1]
+	suite.Equal("people", peopleJoin.Table)
+	suite.Equal("people.id", peopleJoin.Conditions[0].LeftRef)
+	suite.Equal("person_projects.person_id", peopleJoin.Conditions[0].RightRef)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_ForMany_Reciprocal() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	personModel := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Name": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Projects": {Type: "ForMany", Aliased: "Project"},
+		},
+	}
+	projectModel := yaml.Model{
+		Name: "Project",
 		Fields: map[string]yaml.ModelField{
-			"id":   {Type: yaml.ModelFieldTypeUUID},
-			"name": {Type: yaml.ModelFieldTypeString},
+			"ID":    {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Title": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Taggable": {
-				Type: "ForManyPoly",
-				For:  []string{"Post", "Product"},
-			},
+			"Members": {Type: "ForMany", Aliased: "Person"},
 		},
 	}
-
-	productModel := yaml.Model{
-		Name: "Product",
-		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+	personEntity := yaml.Entity{
+		Name: "Person",
+		Fields: map[string]yaml.EntityField{
+			"ID":           {Type: "Person.ID"},
+			"ProjectTitle": {Type: "Person.Projects.Title"},
 		},
-		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
 		},
-		Related: map[string]yaml.ModelRelation{},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	projectEntity := yaml.Entity{
+		Name: "Project",
+		Fields: map[string]yaml.EntityField{
+			"ID":         {Type: "Project.ID"},
+			"MemberName": {Type: "Project.Members.Name"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
 	}
+	r.SetModel("Person", personModel)
+	r.SetModel("Project", projectModel)
+	r.SetEntity("Person", personEntity)
+	r.SetEntity("Project", projectEntity)
 
-	r.SetModel("Post", postModel)
-	r.SetModel("Tag", tagModel)
-	r.SetModel("Product", productModel)
+	personView, personErr := compile.MorpheEntityToPSQLView(config, r, personEntity)
 
-	view, err := compile.MorpheEntityToPSQLView(config, r, postEntity)
+	suite.Nil(personErr)
+	suite.Len(personView.Joins, 2)
 
-	suite.Nil(err)
-	suite.NotNil(view)
-	suite.Equal("post_entities", view.Name)
-	suite.Equal("posts", view.FromTable)
+	junctionJoin := personView.Joins[0]
+	suite.Equal("person_projects", junctionJoin.Table)
+	suite.Equal("person_projects.person_id", junctionJoin.Conditions[0].LeftRef)
+	suite.Equal("people.id", junctionJoin.Conditions[0].RightRef)
 
-	// HasManyPoly should not be materialized - only regular fields
-	// Should have columns: id, title
-	suite.Len(view.Columns, 2)
+	projectJoin := personView.Joins[1]
+	suite.Equal("projects", projectJoin.Table)
+	suite.Equal("projects.id", projectJoin.Conditions[0].LeftRef)
+	suite.Equal("person_projects.projects_id", projectJoin.Conditions[0].RightRef)
 
-	suite.Equal("id", view.Columns[0].Name)
-	suite.Equal("posts.id", view.Columns[0].SourceRef)
+	// The reciprocal ForMany relationship joins through the junction table owned by Person
+	projectView, projectErr := compile.MorpheEntityToPSQLView(config, r, projectEntity)
 
-	suite.Equal("title", view.Columns[1].Name)
-	suite.Equal("posts.title", view.Columns[1].SourceRef)
+	suite.Nil(projectErr)
+	suite.Len(projectView.Joins, 2)
 
-	// Should have no joins for HasManyPoly relationships
-	suite.Len(view.Joins, 0)
+	reciprocalJunctionJoin := projectView.Joins[0]
+	suite.Equal("person_projects", reciprocalJunctionJoin.Table)
+	suite.Equal("person_projects.projects_id", reciprocalJunctionJoin.Conditions[0].LeftRef)
+	suite.Equal("projects.id", reciprocalJunctionJoin.Conditions[0].RightRef)
+
+	membersJoin := projectView.Joins[1]
+	suite.Equal("people", membersJoin.Table)
+	suite.Equal("members", membersJoin.Alias)
+	suite.Equal("members.id", membersJoin.Conditions[0].LeftRef)
+	suite.Equal("person_projects.person_id", membersJoin.Conditions[0].RightRef)
 }
 
-func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_Mixed_Polymorphic_And_Regular() {
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_ForMany_JunctionFields() {
 	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Playlist": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Tracks": {
+					Fields: map[string]yaml.ModelField{
+						"Position": {Type: yaml.ModelFieldTypeInteger},
+					},
+					OrderField: "Position",
+				},
+			},
+		},
+	}
 	r := registry.NewRegistry()
 
-	// Entity with mixed polymorphic and regular relationships
-	mixedEntity := yaml.Entity{
-		Name: "Mixed",
+	playlistModel := yaml.Model{
+		Name: "Playlist",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Tracks": {Type: "ForMany", Aliased: "Track"},
+		},
+	}
+	trackModel := yaml.Model{
+		Name: "Track",
+		Fields: map[string]yaml.ModelField{
+			"ID":    {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Title": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Playlists": {Type: "HasMany", Aliased: "Playlist"},
+		},
+	}
+	playlistEntity := yaml.Entity{
+		Name: "Playlist",
 		Fields: map[string]yaml.EntityField{
-			"id": {
-				Type:       "Mixed.id",
-				Attributes: []string{"immutable"},
-			},
-			"name": {
-				Type: "Mixed.name",
-			},
-			"user": {
-				Type: "Mixed.User.email", // Regular relationship - should create join
-			},
-			// Note: Cannot reference polymorphic relationships directly due to entity validation
-			// This test verifies that regular relationships work correctly alongside polymorphic ones
+			"ID":         {Type: "Playlist.ID"},
+			"TrackTitle": {Type: "Playlist.Tracks.Title"},
 		},
 		Identifiers: map[string]yaml.EntityIdentifier{
-			"primary": {
-				Fields: []string{"id"},
-			},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	trackEntity := yaml.Entity{
+		Name: "Track",
+		Fields: map[string]yaml.EntityField{
+			"ID":         {Type: "Track.ID"},
+			"PlaylistID": {Type: "Track.Playlists.ID"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.EntityRelation{},
 	}
+	r.SetModel("Playlist", playlistModel)
+	r.SetModel("Track", trackModel)
+	r.SetEntity("Playlist", playlistEntity)
+	r.SetEntity("Track", trackEntity)
+
+	playlistView, playlistErr := compile.MorpheEntityToPSQLView(config, r, playlistEntity)
+
+	suite.Nil(playlistErr)
+	suite.Len(playlistView.Joins, 2)
+	suite.Equal("playlist_tracks", playlistView.Joins[0].Table)
+	suite.Equal([]psqldef.ViewColumn{
+		{Name: "id", SourceRef: "playlists.id"},
+		{Name: "track_title", SourceRef: "tracks.title"},
+		{Name: "tracks_position", SourceRef: "playlist_tracks.position"},
+	}, playlistView.Columns)
+
+	// The inverse HasMany relationship exposes the same junction fields
+	trackView, trackErr := compile.MorpheEntityToPSQLView(config, r, trackEntity)
+
+	suite.Nil(trackErr)
+	suite.Len(trackView.Joins, 2)
+	suite.Equal("playlist_tracks", trackView.Joins[0].Table)
+	suite.Equal([]psqldef.ViewColumn{
+		{Name: "id", SourceRef: "tracks.id"},
+		{Name: "playlist_id", SourceRef: "playlists.id"},
+		{Name: "playlists_position", SourceRef: "playlist_tracks.position"},
+	}, trackView.Columns)
+}
 
-	// Mixed model with various relationship types
-	mixedModel := yaml.Model{
-		Name: "Mixed",
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_MultiHop() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	personModel := yaml.Model{
+		Name: "Person",
 		Fields: map[string]yaml.ModelField{
-			"id": {
-				Type: yaml.ModelFieldTypeUUID,
-			},
-			"name": {
-				Type: yaml.ModelFieldTypeString,
-			},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Commentable": {
-				Type: "ForOnePoly",
-				For:  []string{"Post", "Article"},
-			},
-			"User": {
-				Type: "ForOne", // Regular relationship
-			},
-			"Tag": {
-				Type:    "HasManyPoly",
-				Through: "Taggable",
-			},
+			"Company": {Type: "ForOne"},
 		},
 	}
-
-	// Supporting models
-	userModel := yaml.Model{
-		Name: "User",
+	companyModel := yaml.Model{
+		Name: "Company",
 		Fields: map[string]yaml.ModelField{
-			"id":    {Type: yaml.ModelFieldTypeUUID},
-			"email": {Type: yaml.ModelFieldTypeString},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person":  {Type: "HasMany"},
+			"Address": {Type: "HasOne"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
-
-	postModel := yaml.Model{
-		Name: "Post",
+	addressModel := yaml.Model{
+		Name: "Address",
 		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"City": {Type: yaml.ModelFieldTypeString},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Company": {Type: "ForOne"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
+	personEntity := yaml.Entity{
+		Name: "Person",
+		Fields: map[string]yaml.EntityField{
+			"ID":          {Type: "Person.ID"},
+			"CompanyCity": {Type: "Person.Company.Address.City"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	r.SetModel("Person", personModel)
+	r.SetModel("Company", companyModel)
+	r.SetModel("Address", addressModel)
+	r.SetEntity("Person", personEntity)
 
-	articleModel := yaml.Model{
-		Name: "Article",
+	view, err := compile.MorpheEntityToPSQLView(config, r, personEntity)
+
+	suite.Nil(err)
+	suite.Len(view.Joins, 2)
+
+	// Each hop is joined after the table it is joined from
+	companyJoin := view.Joins[0]
+	suite.Equal("companies", companyJoin.Table)
+	suite.Equal("people.company_id", companyJoin.Conditions[0].LeftRef)
+	suite.Equal("companies.id", companyJoin.Conditions[0].RightRef)
+
+	addressJoin := view.Joins[1]
+	suite.Equal("addresses", addressJoin.Table)
+	suite.Equal("company_addresses", addressJoin.Alias)
+	suite.Equal("company_addresses.company_id", addressJoin.Conditions[0].LeftRef)
+	suite.Equal("companies.id", addressJoin.Conditions[0].RightRef)
+}
+
+func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_AliasedRelationships_MultiHop() {
+	config := suite.getCompileConfig()
+	r := registry.NewRegistry()
+
+	personModel := yaml.Model{
+		Name: "Person",
 		Fields: map[string]yaml.ModelField{
-			"id": {Type: yaml.ModelFieldTypeUUID},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"HomeContact": {Type: "ForOne", Aliased: "Contact"},
+			"WorkContact": {Type: "ForOne", Aliased: "Contact"},
 		},
-		Related: map[string]yaml.ModelRelation{},
 	}
-
-	tagModel := yaml.Model{
-		Name: "Tag",
+	contactModel := yaml.Model{
+		Name: "Contact",
 		Fields: map[string]yaml.ModelField{
-			"id":   {Type: yaml.ModelFieldTypeUUID},
-			"name": {Type: yaml.ModelFieldTypeString},
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
-			"primary": {Fields: []string{"id"}},
+			"primary": {Fields: []string{"ID"}},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"Taggable": {
-				Type: "ForManyPoly",
-				For:  []string{"Mixed", "Product"},
-			},
+			"Address": {Type: "ForOne"},
 		},
 	}
+	addressModel := yaml.Model{
+		Name: "Address",
+		Fields: map[string]yaml.ModelField{
+			"ID":   {Type: yaml.ModelFieldTypeAutoIncrement},
+			"City": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Contact": {Type: "HasMany"},
+		},
+	}
+	personEntity := yaml.Entity{
+		Name: "Person",
+		Fields: map[string]yaml.EntityField{
+			"ID":       {Type: "Person.ID"},
+			"HomeCity": {Type: "Person.HomeContact.Address.City"},
+			"WorkCity": {Type: "Person.WorkContact.Address.City"},
+		},
+		Identifiers: map[string]yaml.EntityIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.EntityRelation{},
+	}
+	r.SetModel("Person", personModel)
+	r.SetModel("Contact", contactModel)
+	r.SetModel("Address", addressModel)
+	r.SetEntity("Person", personEntity)
 
-	r.SetModel("Mixed", mixedModel)
-	r.SetModel("User", userModel)
-	r.SetModel("Post", postModel)
-	r.SetModel("Article", articleModel)
-	r.SetModel("Tag", tagModel)
-
-	view, err := compile.MorpheEntityToPSQLView(config, r, mixedEntity)
+	view, err := compile.MorpheEntityToPSQLView(config, r, personEntity)
 
 	suite.Nil(err)
-	suite.NotNil(view)
-	suite.Equal("mixed_entities", view.Name)
-	suite.Equal("mixeds", view.FromTable)
-
-	// Should have columns: id, name, user
-	// (polymorphic relationships don't interfere with regular relationships)
 	suite.Len(view.Columns, 3)
+	suite.Equal("home_contact_addresses.city", view.Columns[0].SourceRef)
+	suite.Equal("people.id", view.Columns[1].SourceRef)
+	suite.Equal("work_contact_addresses.city", view.Columns[2].SourceRef)
 
-	// Check regular columns
-	suite.Equal("id", view.Columns[0].Name)
-	suite.Equal("mixeds.id", view.Columns[0].SourceRef)
-
-	suite.Equal("name", view.Columns[1].Name)
-	suite.Equal("mixeds.name", view.Columns[1].SourceRef)
+	// Both paths reach the contacts and addresses tables, each through its own aliases
+	suite.Len(view.Joins, 4)
 
-	suite.Equal("user", view.Columns[2].Name)
-	suite.Equal("users.email", view.Columns[2].SourceRef)
+	joinAliases := []string{}
+	for _, join := range view.Joins {
+		joinAliases = append(joinAliases, join.Alias)
+	}
+	suite.Equal([]string{"home_contacts", "work_contacts", "home_contact_addresses", "work_contact_addresses"}, joinAliases)
 
-	// Should have one join for the regular User relationship
-	suite.Len(view.Joins, 1)
+	homeAddressJoin := view.Joins[2]
+	suite.Equal("addresses", homeAddressJoin.Table)
+	suite.Equal("home_contacts.address_id", homeAddressJoin.Conditions[0].LeftRef)
+	suite.Equal("home_contact_addresses.id", homeAddressJoin.Conditions[0].RightRef)
 
-	join := view.Joins[0]
-	suite.Equal("LEFT", join.Type)
-	suite.Equal("users", join.Table)
-	suite.Equal("users", join.Alias)
-	suite.Len(join.Conditions, 1)
-	suite.Equal("mixeds.id", join.Conditions[0].LeftRef)
-	suite.Equal("users.id", join.Conditions[0].RightRef)
+	workAddressJoin := view.Joins[3]
+	suite.Equal("addresses", workAddressJoin.Table)
+	suite.Equal("work_contacts.address_id", workAddressJoin.Conditions[0].LeftRef)
+	suite.Equal("work_contact_addresses.id", workAddressJoin.Conditions[0].RightRef)
 }
 
 func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_AliasedRelationships_DifferentPrimaryKeys() {
@@ -1542,13 +2688,13 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_Alia
 	workJoin := view.Joins[0]
 	suite.Equal("LEFT", workJoin.Type)
 	suite.Equal("public", workJoin.Schema)
-	suite.Equal("work_contacts", workJoin.Table)
+	suite.Equal("contacts", workJoin.Table)
 	suite.Equal("work_contacts", workJoin.Alias)
 	suite.Len(workJoin.Conditions, 1)
 
-	// This is the key assertion - the join should use person_id from person_profiles
+	// This is the key assertion - the join should use the work_contact_contact_id foreign key from person_profiles
 	// and contact_id from work_contacts, NOT person_id from both sides
-	suite.Equal("person_profiles.person_id", workJoin.Conditions[0].LeftRef)
+	suite.Equal("person_profiles.work_contact_contact_id", workJoin.Conditions[0].LeftRef)
 	suite.Equal("work_contacts.contact_id", workJoin.Conditions[0].RightRef)
 }
 
@@ -1693,20 +2839,20 @@ func (suite *CompileEntitiesTestSuite) TestMorpheEntityToPSQLView_FieldPath_Alia
 	personalJoin := view.Joins[0]
 	suite.Equal("LEFT", personalJoin.Type)
 	suite.Equal("public", personalJoin.Schema)
-	suite.Equal("personal_contacts", personalJoin.Table)
+	suite.Equal("contacts", personalJoin.Table)
 	suite.Equal("personal_contacts", personalJoin.Alias)
 	suite.Len(personalJoin.Conditions, 1)
-	suite.Equal("person_profiles.id", personalJoin.Conditions[0].LeftRef)
+	suite.Equal("person_profiles.personal_contact_id", personalJoin.Conditions[0].LeftRef)
 	suite.Equal("personal_contacts.id", personalJoin.Conditions[0].RightRef)
 
 	// Join for WorkContact (processed second alphabetically)
 	workJoin := view.Joins[1]
 	suite.Equal("LEFT", workJoin.Type)
 	suite.Equal("public", workJoin.Schema)
-	suite.Equal("work_contacts", workJoin.Table)
+	suite.Equal("contacts", workJoin.Table)
 	suite.Equal("work_contacts", workJoin.Alias)
 	suite.Len(workJoin.Conditions, 1)
-	suite.Equal("person_profiles.id", workJoin.Conditions[0].LeftRef)
+	suite.Equal("person_profiles.work_contact_id", workJoin.Conditions[0].LeftRef)
 	suite.Equal("work_contacts.id", workJoin.Conditions[0].RightRef)
 }
 
@@ -4,3 +4,5 @@ import "errors"
 
 var ErrNoEnumTables = errors.New("no enum tables provided")
 var ErrNoEnumTable = errors.New("no enum table provided")
+var ErrNoEnumType = errors.New("no enum type provided")
+var ErrNoEnumTypeWriter = errors.New("no enum type writer provided")
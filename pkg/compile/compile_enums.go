@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/kalo-build/go-util/core"
-	"github.com/kalo-build/go-util/strcase"
 	"github.com/kalo-build/morphe-go/pkg/registry"
 	"github.com/kalo-build/morphe-go/pkg/yaml"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
@@ -55,8 +54,7 @@ func createPSQLTableForEnum(config cfg.MorpheEnumsConfig, enum yaml.Enum) (*psql
 		return nil, validateMorpheErr
 	}
 
-	tableName := strcase.ToSnakeCaseLower(enum.Name)
-	tableName = Pluralize(tableName)
+	tableName := GetEnumTableName(enum.Name)
 
 	serialType := psqldef.PSQLTypeSerial
 	if config.UseBigSerial {
@@ -121,6 +119,62 @@ func createPSQLTableForEnum(config cfg.MorpheEnumsConfig, enum yaml.Enum) (*psql
 	return table, nil
 }
 
+func AllMorpheEnumsToPSQLEnumTypes(config MorpheCompileConfig, r *registry.Registry) (map[string]*psqldef.PSQLTypeEnum, error) {
+	allEnumTypeDefs := map[string]*psqldef.PSQLTypeEnum{}
+	for enumName, enum := range r.GetAllEnums() {
+		enumType, enumErr := MorpheEnumToPSQLEnumType(config, enum)
+		if enumErr != nil {
+			return nil, enumErr
+		}
+		allEnumTypeDefs[enumName] = enumType
+	}
+	return allEnumTypeDefs, nil
+}
+
+// MorpheEnumToPSQLEnumType converts a Morphe enum to a native PostgreSQL ENUM type
+func MorpheEnumToPSQLEnumType(config MorpheCompileConfig, enum yaml.Enum) (*psqldef.PSQLTypeEnum, error) {
+	enumsConfig, enum, enumStartErr := triggerCompileMorpheEnumStart(config.EnumHooks, config.MorpheEnumsConfig, enum)
+	if enumStartErr != nil {
+		return nil, triggerCompileMorpheEnumFailure(config.EnumHooks, config.MorpheEnumsConfig, enum, enumStartErr)
+	}
+
+	enumType, createPSQLEnumTypeErr := createPSQLEnumTypeForEnum(enumsConfig, enum)
+	if createPSQLEnumTypeErr != nil {
+		return nil, triggerCompileMorpheEnumFailure(config.EnumHooks, enumsConfig, enum, createPSQLEnumTypeErr)
+	}
+
+	enumType, enumSuccessErr := triggerCompileMorpheEnumTypeSuccess(config.EnumHooks, enumType)
+	if enumSuccessErr != nil {
+		return nil, triggerCompileMorpheEnumFailure(config.EnumHooks, enumsConfig, enum, enumSuccessErr)
+	}
+
+	return enumType, nil
+}
+
+// createPSQLEnumTypeForEnum creates a native PostgreSQL ENUM type for a Morphe enum
+func createPSQLEnumTypeForEnum(config cfg.MorpheEnumsConfig, enum yaml.Enum) (*psqldef.PSQLTypeEnum, error) {
+	validateConfigErr := config.Validate()
+	if validateConfigErr != nil {
+		return nil, validateConfigErr
+	}
+	validateMorpheErr := enum.Validate()
+	if validateMorpheErr != nil {
+		return nil, validateMorpheErr
+	}
+
+	enumType := getPSQLEnumType(config, enum)
+	return &enumType, nil
+}
+
+// getPSQLEnumType returns the native PostgreSQL ENUM type for a Morphe enum, using the sorted entry keys as values
+func getPSQLEnumType(config cfg.MorpheEnumsConfig, enum yaml.Enum) psqldef.PSQLTypeEnum {
+	return psqldef.PSQLTypeEnum{
+		Values: core.MapKeysSorted(enum.Entries),
+		Schema: config.Schema,
+		Name:   GetEnumTypeName(enum.Name),
+	}
+}
+
 // triggerCompileMorpheEnumStart triggers the start hook for enum compilation
 func triggerCompileMorpheEnumStart(hooks hook.CompileMorpheEnum, config cfg.MorpheEnumsConfig, enum yaml.Enum) (cfg.MorpheEnumsConfig, yaml.Enum, error) {
 	if hooks.OnCompileMorpheEnumStart == nil {
@@ -146,6 +200,22 @@ func triggerCompileMorpheEnumSuccess(hooks hook.CompileMorpheEnum, table *psqlde
 	return updatedTable, nil
 }
 
+// triggerCompileMorpheEnumTypeSuccess triggers the success hook for enum type compilation
+func triggerCompileMorpheEnumTypeSuccess(hooks hook.CompileMorpheEnum, enumType *psqldef.PSQLTypeEnum) (*psqldef.PSQLTypeEnum, error) {
+	if hooks.OnCompileMorpheEnumTypeSuccess == nil {
+		return enumType, nil
+	}
+
+	enumTypeClone := enumType.DeepClone()
+
+	updatedEnumType, err := hooks.OnCompileMorpheEnumTypeSuccess(&enumTypeClone)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedEnumType, nil
+}
+
 // triggerCompileMorpheEnumFailure triggers the failure hook for enum compilation
 func triggerCompileMorpheEnumFailure(hooks hook.CompileMorpheEnum, config cfg.MorpheEnumsConfig, enum yaml.Enum, failureErr error) error {
 	if hooks.OnCompileMorpheEnumFailure == nil {
@@ -2,6 +2,7 @@ package compile_test
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/kalo-build/morphe-go/pkg/yaml"
@@ -648,3 +649,101 @@ func (suite *CompileEnumsTestSuite) TestMorpheEnumToPSQLTable_FailureHook() {
 	suite.ErrorContains(enumErr, "compile enum failure hook error")
 	suite.Nil(lookupTable)
 }
+
+func (suite *CompileEnumsTestSuite) TestMorpheEnumToPSQLEnumType() {
+	config := suite.getMorpheConfig()
+	config.MorpheEnumsConfig.UseNativeEnums = true
+
+	enum0 := yaml.Enum{
+		Name: "UserRole",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"Viewer": "VIEWER",
+			"Admin":  "ADMIN",
+			"Editor": "EDITOR",
+		},
+	}
+
+	enumType, enumErr := compile.MorpheEnumToPSQLEnumType(config, enum0)
+
+	suite.Nil(enumErr)
+	suite.NotNil(enumType)
+	suite.Equal("public", enumType.Schema)
+	suite.Equal("user_role", enumType.Name)
+	suite.Equal([]string{"Admin", "Editor", "Viewer"}, enumType.Values)
+	suite.Equal("public.user_role", enumType.GetSyntax())
+}
+
+func (suite *CompileEnumsTestSuite) TestMorpheEnumToPSQLEnumType_NoEntries() {
+	config := suite.getMorpheConfig()
+	config.MorpheEnumsConfig.UseNativeEnums = true
+
+	enum0 := yaml.Enum{
+		Name:    "UserRole",
+		Type:    yaml.EnumTypeString,
+		Entries: map[string]any{},
+	}
+
+	enumType, enumErr := compile.MorpheEnumToPSQLEnumType(config, enum0)
+
+	suite.NotNil(enumErr)
+	suite.Nil(enumType)
+}
+
+func (suite *CompileEnumsTestSuite) TestMorpheEnumToPSQLEnumType_SuccessHook_Successful() {
+	enumHooks := hook.CompileMorpheEnum{
+		OnCompileMorpheEnumTypeSuccess: func(enumType *psqldef.PSQLTypeEnum) (*psqldef.PSQLTypeEnum, error) {
+			enumType.Name = enumType.Name + "_changed"
+			return enumType, nil
+		},
+	}
+
+	config := suite.getMorpheConfig()
+	config.MorpheEnumsConfig.UseNativeEnums = true
+	config.EnumHooks = enumHooks
+
+	enum0 := yaml.Enum{
+		Name: "Color",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"Red": "rgb(255,0,0)",
+		},
+	}
+
+	enumType, enumErr := compile.MorpheEnumToPSQLEnumType(config, enum0)
+
+	suite.Nil(enumErr)
+	suite.Equal("color_changed", enumType.Name)
+}
+
+func (suite *CompileEnumsTestSuite) TestMorpheEnumTypeFileWriter_WriteEnumTypeWithOrder() {
+	workingDirPath := suite.T().TempDir()
+
+	writer := &compile.MorpheEnumTypeFileWriter{
+		TargetDirPath: workingDirPath,
+	}
+
+	enumType := &psqldef.PSQLTypeEnum{
+		Values: []string{"DE", "FR", "US"},
+		Schema: "public",
+		Name:   "nationality",
+	}
+
+	enumTypeContents, writeErr := writer.WriteEnumTypeWithOrder(enumType, 1)
+
+	suite.Nil(writeErr)
+
+	expectedContents := `-- Enum type definition for nationality
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+DO $$ BEGIN
+	CREATE TYPE public.nationality AS ENUM ('DE', 'FR', 'US');
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END $$;
+
+`
+	suite.Equal(expectedContents, string(enumTypeContents))
+	suite.FileExists(filepath.Join(workingDirPath, "001_nationality.sql"))
+}
@@ -16,3 +16,5 @@ func ErrUnsupportedMorpheFieldType[TType yaml.ModelFieldType | yaml.StructureFie
 func ErrMissingMorpheIdentifierField(modelName string, identifierName string, fieldName string) error {
 	return fmt.Errorf("morphe model '%s' has no field '%s' referenced in identifiers ('%s')", modelName, identifierName, fieldName)
 }
+
+var ErrNoSchema = errors.New("no schema provided")
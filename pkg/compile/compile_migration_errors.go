@@ -0,0 +1,14 @@
+package compile
+
+import "errors"
+
+var ErrNoTableDiff = errors.New("no table diff provided")
+var ErrNoMigrationWriter = errors.New("migration writer must be provided when previous tables are set")
+var ErrNoViewDiff = errors.New("no view diff provided")
+var ErrNoViewMigrationWriter = errors.New("migration writer must write view migrations when views change")
+var ErrNoSchemaSnapshot = errors.New("no schema snapshot provided")
+var ErrNotNullColumnWithoutDefault = errors.New("added NOT NULL column has no default")
+var ErrEnumValueRemoved = errors.New("removing an enum value is not supported")
+var ErrPartitionKeyChanged = errors.New("changing the partition key of a table is not supported")
+var ErrPartitionBoundChanged = errors.New("changing the bound of a partition is not supported")
+var ErrIrreversibleMigration = errors.New("migration cannot be rolled back")
@@ -6,5 +6,20 @@ var ErrNoModelTables = errors.New("no model tables provided")
 var ErrNoModelTable = errors.New("no model table provided")
 var ErrNoStructureTable = errors.New("no structure table provided")
 var ErrNoStructureWriter = errors.New("structure writer must be provided when structure persistence is enabled")
+var ErrAuditColumnConflict = errors.New("audit column conflicts with an existing column")
+var ErrSoftDeleteColumnConflict = errors.New("soft delete column conflicts with an existing column")
+var ErrTenantColumnConflict = errors.New("tenant column conflicts with an existing column")
+var ErrTenantSetNullOnUpdate = errors.New("ON UPDATE SET NULL cannot be used with tenancy as it would clear the tenant column")
+var ErrPartitionedForeignKeyTarget = errors.New("foreign keys to partitioned tables must reference the partition key")
+var ErrPartitionedOneToOneRelation = errors.New("partitioned models cannot enforce one-to-one relationships without the partition key")
+var ErrAmbiguousReciprocalRelation = errors.New("reciprocal ForMany relationships are ambiguous")
+var ErrRelationCardinalityMismatch = errors.New("relationship cardinality disagrees with its inverse")
+var ErrJunctionFieldsWithoutJunctionTable = errors.New("junction fields can only be declared for ForMany and ForManyPoly relations")
+var ErrJunctionFieldsOnReciprocalRelation = errors.New("junction fields must be declared on the relation owning the shared junction table")
+var ErrReciprocalRelationOptionConflict = errors.New("reciprocal ForMany relations sharing a junction table set conflicting options")
+var ErrJunctionFieldConflict = errors.New("junction field conflicts with an existing column")
+var ErrMaterializedViewToManyJoin = errors.New("materialized entities cannot join to-many relationships")
+var ErrMaterializedViewWithoutIdentifiers = errors.New("materialized entities need an identifier to be refreshed concurrently")
+var ErrJoinAliasConflict = errors.New("entity view joins share a table alias")
 var ErrNoEntityViews = errors.New("no entity views provided")
 var ErrNoEntityView = errors.New("no entity view provided")
@@ -2,7 +2,9 @@ package compile
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/kalo-build/clone"
@@ -27,6 +29,115 @@ func hasAttribute(attributes []string, target string) bool {
 	return false
 }
 
+// getAttributeValue returns the value of a "name:value" field attribute
+func getAttributeValue(attributes []string, name string) (string, bool) {
+	for _, attr := range attributes {
+		attrName, attrValue, hasValue := strings.Cut(attr, ":")
+		if hasValue && attrName == name {
+			return attrValue, true
+		}
+	}
+	return "", false
+}
+
+// numericColumnTypeSyntaxes are the column types whose default and min/max values are written as plain numbers
+var numericColumnTypeSyntaxes = []string{
+	psqldef.PSQLTypeSmallInt.GetSyntax(),
+	psqldef.PSQLTypeInteger.GetSyntax(),
+	psqldef.PSQLTypeBigInt.GetSyntax(),
+	psqldef.PSQLTypeSerial.GetSyntax(),
+	psqldef.PSQLTypeBigSerial.GetSyntax(),
+	psqldef.PSQLTypeReal.GetSyntax(),
+	psqldef.PSQLTypeDoublePrecision.GetSyntax(),
+	psqldef.PSQLTypeNumeric.GetSyntax(),
+}
+
+// columnAttributeNames are the field attributes applied to the column by applyFieldAttributes
+var columnAttributeNames = []string{"default", "defaultExpr", "min", "max", "maxLength", "pattern"}
+
+// applyFieldAttributes sets the column default and returns the check constraints for the
+// "default:", "defaultExpr:", "min:", "max:", "maxLength:" and "pattern:" attributes of a field
+func applyFieldAttributes(schema string, tableName string, fieldName string, column *psqldef.TableColumn, attributes []string) ([]psqldef.CheckConstraint, error) {
+	typeSyntax := column.Type.GetSyntax()
+	isNumeric := slices.Contains(numericColumnTypeSyntaxes, typeSyntax)
+
+	defaultValue, hasDefault := getAttributeValue(attributes, "default")
+	defaultExpression, hasDefaultExpression := getAttributeValue(attributes, "defaultExpr")
+	if hasDefault && hasDefaultExpression {
+		return nil, fmt.Errorf("morphe model field '%s' has both a default and a default expression", fieldName)
+	}
+	if hasDefaultExpression {
+		if defaultExpression == "" {
+			return nil, fmt.Errorf("morphe model field '%s' has an empty default expression", fieldName)
+		}
+		column.Default = defaultExpression
+	}
+	if hasDefault {
+		switch {
+		case isNumeric:
+			if _, parseErr := strconv.ParseFloat(defaultValue, 64); parseErr != nil {
+				return nil, fmt.Errorf("morphe model field '%s' has non-numeric default '%s'", fieldName, defaultValue)
+			}
+			column.Default = defaultValue
+		case typeSyntax == psqldef.PSQLTypeBoolean.GetSyntax():
+			if _, parseErr := strconv.ParseBool(defaultValue); parseErr != nil {
+				return nil, fmt.Errorf("morphe model field '%s' has non-boolean default '%s'", fieldName, defaultValue)
+			}
+			column.Default = strings.ToUpper(defaultValue)
+		default:
+			column.Default = quoteSQLString(defaultValue)
+		}
+	}
+
+	checkConstraints := []psqldef.CheckConstraint{}
+	addCheck := func(checkName string, expression string) {
+		checkConstraints = append(checkConstraints, psqldef.CheckConstraint{
+			Schema:     schema,
+			Name:       GetCheckConstraintName(tableName, column.Name, checkName),
+			TableName:  tableName,
+			Expression: expression,
+		})
+	}
+
+	for _, bound := range []struct {
+		name     string
+		operator string
+	}{{"min", ">="}, {"max", "<="}} {
+		boundValue, hasBound := getAttributeValue(attributes, bound.name)
+		if !hasBound {
+			continue
+		}
+		if !isNumeric {
+			return nil, fmt.Errorf("morphe model field '%s' of type '%s' does not support the '%s' attribute", fieldName, typeSyntax, bound.name)
+		}
+		if _, parseErr := strconv.ParseFloat(boundValue, 64); parseErr != nil {
+			return nil, fmt.Errorf("morphe model field '%s' has non-numeric %s '%s'", fieldName, bound.name, boundValue)
+		}
+		addCheck(bound.name, fmt.Sprintf("%s %s %s", column.Name, bound.operator, boundValue))
+	}
+
+	if maxLength, hasMaxLength := getAttributeValue(attributes, "maxLength"); hasMaxLength {
+		maxLengthValue, parseErr := strconv.Atoi(maxLength)
+		if parseErr != nil || maxLengthValue <= 0 {
+			return nil, fmt.Errorf("morphe model field '%s' has invalid maxLength '%s'", fieldName, maxLength)
+		}
+		addCheck("maxLength", fmt.Sprintf("char_length(%s) <= %d", column.Name, maxLengthValue))
+	}
+
+	if pattern, hasPattern := getAttributeValue(attributes, "pattern"); hasPattern {
+		if _, compileErr := regexp.Compile(pattern); compileErr != nil {
+			return nil, fmt.Errorf("morphe model field '%s' has invalid pattern '%s': %w", fieldName, pattern, compileErr)
+		}
+		addCheck("pattern", fmt.Sprintf("%s ~ %s", column.Name, quoteSQLString(pattern)))
+	}
+
+	return checkConstraints, nil
+}
+
+func quoteSQLString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
 func AllMorpheModelsToPSQLTables(config MorpheCompileConfig, r *registry.Registry) (map[string][]*psqldef.Table, error) {
 	allModelTableDefs := map[string][]*psqldef.Table{}
 	for modelName, model := range r.GetAllModels() {
@@ -80,32 +191,33 @@ func morpheModelToPSQLTables(config cfg.MorpheConfig, r *registry.Registry, mode
 		return nil, validateAliasErr
 	}
 
+	validatePairingErr := validateRelationPairing(r, model)
+	if validatePairingErr != nil {
+		return nil, validatePairingErr
+	}
+
+	validateJunctionFieldsErr := validateJunctionFieldRelations(config.MorpheModelsConfig, model)
+	if validateJunctionFieldsErr != nil {
+		return nil, validateJunctionFieldsErr
+	}
+
 	schema := config.MorpheModelsConfig.Schema
 	modelName := model.Name
 	tableName := GetTableNameFromModel(modelName)
 
-	var typeMap map[yaml.ModelFieldType]psqldef.PSQLType
-	var relatedTypeMap map[yaml.ModelFieldType]psqldef.PSQLType
-
-	if config.MorpheModelsConfig.UseBigSerial {
-		typeMap = typemap.MorpheModelFieldToPSQLFieldBigSerial
-		relatedTypeMap = typemap.MorpheModelFieldToPSQLFieldBigSerialForeign
-	} else {
-		typeMap = typemap.MorpheModelFieldToPSQLField
-		relatedTypeMap = typemap.MorpheModelFieldToPSQLFieldForeign
-	}
+	typeMap, relatedTypeMap := getModelFieldTypeMaps(config.MorpheModelsConfig)
 
 	primaryID, primaryIDExists := model.Identifiers["primary"]
 	if !primaryIDExists {
 		return nil, fmt.Errorf("no primary identifier set for model '%s'", model.Name)
 	}
 
-	fieldColumns, enumForeignKeys, fieldColumnsErr := getColumnsForModelFields(config, r, typeMap, tableName, primaryID, model.Fields)
+	fieldColumns, enumForeignKeys, checkConstraints, fieldColumnsErr := getColumnsForModelFields(config, r, typeMap, tableName, primaryID, model.Fields)
 	if fieldColumnsErr != nil {
 		return nil, fieldColumnsErr
 	}
 
-	relatedColumns, relatedColumnsErr := getColumnsForModelRelations(r, relatedTypeMap, model.Related)
+	relatedColumns, relatedColumnsErr := getColumnsForModelRelations(config.MorpheModelsConfig, r, relatedTypeMap, model)
 	if relatedColumnsErr != nil {
 		return nil, relatedColumnsErr
 	}
@@ -117,9 +229,10 @@ func morpheModelToPSQLTables(config cfg.MorpheConfig, r *registry.Registry, mode
 		ForeignKeys:       enumForeignKeys,
 		Indices:           []psqldef.Index{},
 		UniqueConstraints: []psqldef.UniqueConstraint{},
+		CheckConstraints:  checkConstraints,
 	}
 
-	relationForeignKeys, foreignKeysErr := getForeignKeysForModelRelations(schema, tableName, r, model.Related)
+	relationForeignKeys, foreignKeysErr := getForeignKeysForModelRelations(config.MorpheModelsConfig, tableName, r, model)
 	if foreignKeysErr != nil {
 		return nil, foreignKeysErr
 	}
@@ -127,19 +240,64 @@ func morpheModelToPSQLTables(config cfg.MorpheConfig, r *registry.Registry, mode
 
 	indices := getIndicesForForeignKeys(schema, tableName, modelTable.ForeignKeys)
 	modelTable.Indices = indices
+	oneToOneErr := makeOneToOneForeignKeyIndicesUnique(config, r, &modelTable, model)
+	if oneToOneErr != nil {
+		return nil, oneToOneErr
+	}
+
+	if config.MorpheModelsConfig.EnablePolymorphicTriggers {
+		polyTriggersErr := addPolymorphicTriggersForForOnePolyRelations(&modelTable, r, model.Related)
+		if polyTriggersErr != nil {
+			return nil, polyTriggersErr
+		}
+	}
+
+	if config.MorpheModelsConfig.AuditColumns {
+		auditErr := addAuditColumns(&modelTable)
+		if auditErr != nil {
+			return nil, auditErr
+		}
+	}
 
 	// Apply spec-compliant processing to the model table
 	addUniqueIndicesFromIdentifiers(&modelTable, model.Identifiers)
+	if config.MorpheModelsConfig.IsSoftDelete(model.Name) {
+		softDeleteErr := addSoftDeleteColumn(&modelTable)
+		if softDeleteErr != nil {
+			return nil, softDeleteErr
+		}
+	}
+	if config.MorpheModelsConfig.Tenancy.IsEnabled() {
+		tenancyErr := addTenancy(config.MorpheModelsConfig, r, &modelTable, true)
+		if tenancyErr != nil {
+			return nil, tenancyErr
+		}
+	}
+	partitionOptions := config.MorpheModelsConfig.ModelOptions[model.Name].Partition
+	if partitionOptions.IsPartitioned() {
+		partitionErr := partitionTable(config, r, &modelTable, model, partitionOptions)
+		if partitionErr != nil {
+			return nil, partitionErr
+		}
+	}
 	quoteReservedColumnNames(&modelTable)
 	ensureNamedForeignKeyConstraints(&modelTable)
+	foreignKeyActionsErr := validateForeignKeyActions(&modelTable)
+	if foreignKeyActionsErr != nil {
+		return nil, foreignKeyActionsErr
+	}
+	partitionedTargetErr := validatePartitionedForeignKeyTargets(config, r, &modelTable)
+	if partitionedTargetErr != nil {
+		return nil, partitionedTargetErr
+	}
 
-	junctionTables, junctionTablesErr := getJunctionTablesForForManyRelations(schema, r, model)
+	junctionTables, junctionTablesErr := getJunctionTablesForForManyRelations(config, r, model)
 	if junctionTablesErr != nil {
 		return nil, junctionTablesErr
 	}
 
 	// Get polymorphic junction tables for ForManyPoly relationships
-	polymorphicJunctionTables, polymorphicJunctionTablesErr := getJunctionTablesForForManyPolyRelations(schema, r, model)
+	polymorphicJunctionTables, polymorphicJunctionTablesErr := getJunctionTablesForForManyPolyRelations(config, r, model)
 	if polymorphicJunctionTablesErr != nil {
 		return nil, polymorphicJunctionTablesErr
 	}
@@ -149,8 +307,28 @@ func morpheModelToPSQLTables(config cfg.MorpheConfig, r *registry.Registry, mode
 
 	// Process junction tables as well
 	for tableIdx := range allJunctionTables {
+		if config.MorpheModelsConfig.AuditJunctionTables {
+			auditErr := addAuditColumns(allJunctionTables[tableIdx])
+			if auditErr != nil {
+				return nil, auditErr
+			}
+		}
+		if config.MorpheModelsConfig.Tenancy.IsEnabled() {
+			tenancyErr := addTenancy(config.MorpheModelsConfig, r, allJunctionTables[tableIdx], false)
+			if tenancyErr != nil {
+				return nil, tenancyErr
+			}
+		}
 		quoteReservedColumnNames(allJunctionTables[tableIdx])
 		ensureNamedForeignKeyConstraints(allJunctionTables[tableIdx])
+		foreignKeyActionsErr := validateForeignKeyActions(allJunctionTables[tableIdx])
+		if foreignKeyActionsErr != nil {
+			return nil, foreignKeyActionsErr
+		}
+		partitionedTargetErr := validatePartitionedForeignKeyTargets(config, r, allJunctionTables[tableIdx])
+		if partitionedTargetErr != nil {
+			return nil, partitionedTargetErr
+		}
 	}
 
 	tables := []*psqldef.Table{&modelTable}
@@ -161,9 +339,34 @@ func morpheModelToPSQLTables(config cfg.MorpheConfig, r *registry.Registry, mode
 	return tables, nil
 }
 
-func getColumnsForModelFields(config cfg.MorpheConfig, r *registry.Registry, typeMap map[yaml.ModelFieldType]psqldef.PSQLType, tableName string, primaryID yaml.ModelIdentifier, modelFields map[string]yaml.ModelField) ([]psqldef.TableColumn, []psqldef.ForeignKey, error) {
+// getModelFieldColumnName returns the column name of a model field, which references the enum lookup table for enum
+// fields unless enums are native types
+func getModelFieldColumnName(config cfg.MorpheConfig, r *registry.Registry, fieldName string, field yaml.ModelField) string {
+	columnName := GetColumnNameFromField(fieldName)
+	typeMap, _ := getModelFieldTypeMaps(config.MorpheModelsConfig)
+	if _, supported := typeMap[field.Type]; supported || config.MorpheEnumsConfig.UseNativeEnums {
+		return columnName
+	}
+	if _, enumErr := r.GetEnum(string(field.Type)); enumErr != nil {
+		return columnName
+	}
+	return columnName + "_id"
+}
+
+// getModelFieldTypeMaps returns the type maps for model field columns and for columns referencing model fields
+func getModelFieldTypeMaps(modelsConfig cfg.MorpheModelsConfig) (map[yaml.ModelFieldType]psqldef.PSQLType, map[yaml.ModelFieldType]psqldef.PSQLType) {
+	if modelsConfig.UseBigSerial {
+		return typemap.MorpheModelFieldToPSQLFieldBigSerial, typemap.MorpheModelFieldToPSQLFieldBigSerialForeign
+	}
+	return typemap.MorpheModelFieldToPSQLField, typemap.MorpheModelFieldToPSQLFieldForeign
+}
+
+func getColumnsForModelFields(config cfg.MorpheConfig, r *registry.Registry, typeMap map[yaml.ModelFieldType]psqldef.PSQLType, tableName string, primaryID yaml.ModelIdentifier, modelFields map[string]yaml.ModelField) ([]psqldef.TableColumn, []psqldef.ForeignKey, []psqldef.CheckConstraint, error) {
 	columns := []psqldef.TableColumn{}
 	enumForeignKeys := []psqldef.ForeignKey{}
+	checkConstraints := []psqldef.CheckConstraint{}
+	schema := config.MorpheModelsConfig.Schema
+	enumOnDelete, enumOnUpdate := config.MorpheModelsConfig.GetDefaultReferentialActions()
 
 	modelFieldNames := core.MapKeysSorted(modelFields)
 	for _, fieldName := range modelFieldNames {
@@ -180,17 +383,47 @@ func getColumnsForModelFields(config cfg.MorpheConfig, r *registry.Registry, typ
 				PrimaryKey: slices.Index(primaryID.Fields, fieldName) != -1,
 				Default:    "",
 			}
+			fieldChecks, fieldAttributesErr := applyFieldAttributes(schema, tableName, fieldName, &column, field.Attributes)
+			if fieldAttributesErr != nil {
+				return nil, nil, nil, fieldAttributesErr
+			}
+			checkConstraints = append(checkConstraints, fieldChecks...)
 			columns = append(columns, column)
 			continue
 		}
 
 		enumType, enumErr := r.GetEnum(string(field.Type))
 		if enumErr != nil {
-			return nil, nil, fmt.Errorf("morphe model field '%s' has unsupported type '%s'", fieldName, field.Type)
+			return nil, nil, nil, fmt.Errorf("morphe model field '%s' has unsupported type '%s'", fieldName, field.Type)
 		}
 
-		columnName = columnName + "_id"
-		enumTableName := Pluralize(strcase.ToSnakeCaseLower(enumType.Name))
+		if config.MorpheEnumsConfig.UseNativeEnums {
+			column := psqldef.TableColumn{
+				Name:       columnName,
+				Type:       getPSQLEnumType(config.MorpheEnumsConfig, enumType),
+				NotNull:    !hasAttribute(field.Attributes, "optional"),
+				PrimaryKey: slices.Index(primaryID.Fields, fieldName) != -1,
+				Default:    "",
+			}
+			fieldChecks, fieldAttributesErr := applyFieldAttributes(schema, tableName, fieldName, &column, field.Attributes)
+			if fieldAttributesErr != nil {
+				return nil, nil, nil, fieldAttributesErr
+			}
+			checkConstraints = append(checkConstraints, fieldChecks...)
+			columns = append(columns, column)
+			continue
+		}
+
+		// Lookup table enum columns hold the id of an entry, which their attributes cannot describe
+		for _, attributeName := range columnAttributeNames {
+			if _, hasAttribute := getAttributeValue(field.Attributes, attributeName); hasAttribute {
+				return nil, nil, nil, fmt.Errorf("morphe model field '%s' of lookup table enum type '%s' does not support the '%s' attribute",
+					fieldName, enumType.Name, attributeName)
+			}
+		}
+
+		columnName = getModelFieldColumnName(config, r, fieldName, field)
+		enumTableName := GetEnumTableName(enumType.Name)
 
 		foreignKey := psqldef.ForeignKey{
 			Schema:         config.MorpheModelsConfig.Schema,
@@ -200,31 +433,33 @@ func getColumnsForModelFields(config cfg.MorpheConfig, r *registry.Registry, typ
 			RefSchema:      config.MorpheEnumsConfig.Schema,
 			RefTableName:   enumTableName,
 			RefColumnNames: []string{"id"},
-			OnDelete:       "CASCADE",
-			OnUpdate:       "",
+			OnDelete:       enumOnDelete,
+			OnUpdate:       enumOnUpdate,
 		}
 		enumForeignKeys = append(enumForeignKeys, foreignKey)
 
 		column := psqldef.TableColumn{
 			Name:       columnName,
 			Type:       psqldef.PSQLTypeInteger,
-			NotNull:    true,
+			NotNull:    !hasAttribute(field.Attributes, "optional"),
 			PrimaryKey: slices.Index(primaryID.Fields, fieldName) != -1,
 			Default:    "",
 		}
 		columns = append(columns, column)
 	}
 
-	return columns, enumForeignKeys, nil
+	return columns, enumForeignKeys, checkConstraints, nil
 }
 
-func getColumnsForModelRelations(r *registry.Registry, typeMap map[yaml.ModelFieldType]psqldef.PSQLType, relatedModels map[string]yaml.ModelRelation) ([]psqldef.TableColumn, error) {
+func getColumnsForModelRelations(modelsConfig cfg.MorpheModelsConfig, r *registry.Registry, typeMap map[yaml.ModelFieldType]psqldef.PSQLType, model yaml.Model) ([]psqldef.TableColumn, error) {
 	columns := []psqldef.TableColumn{}
 
+	relatedModels := model.Related
 	relatedModelNames := core.MapKeysSorted(relatedModels)
 	for _, relatedModelName := range relatedModelNames {
 		modelRelation := relatedModels[relatedModelName]
 		relationType := modelRelation.Type
+		isOptional := modelsConfig.IsRelationOptional(model.Name, relatedModelName)
 		// Resolve the actual target model name using aliasing
 		targetModelName := yamlops.GetRelationTargetName(relatedModelName, modelRelation.Aliased)
 
@@ -233,17 +468,22 @@ func getColumnsForModelRelations(r *registry.Registry, typeMap map[yaml.ModelFie
 			typeColumn := psqldef.TableColumn{
 				Name:       typeColumnName,
 				Type:       psqldef.PSQLTypeText,
-				NotNull:    true,
+				NotNull:    !isOptional,
 				PrimaryKey: false,
 				Default:    "",
 			}
 			columns = append(columns, typeColumn)
 
+			idColumnType, idColumnTypeErr := getPolymorphicIdColumnType(r, typeMap, modelRelation)
+			if idColumnTypeErr != nil {
+				return nil, idColumnTypeErr
+			}
+
 			idColumnName := strcase.ToSnakeCaseLower(relatedModelName) + "_id"
 			idColumn := psqldef.TableColumn{
 				Name:       idColumnName,
-				Type:       psqldef.PSQLTypeText,
-				NotNull:    true,
+				Type:       idColumnType,
+				NotNull:    !isOptional,
 				PrimaryKey: false,
 				Default:    "",
 			}
@@ -265,17 +505,20 @@ func getColumnsForModelRelations(r *registry.Registry, typeMap map[yaml.ModelFie
 			return nil, fmt.Errorf("related model %s has no primary identifier", targetModelName)
 		}
 
-		if len(primaryID.Fields) != 1 {
-			return nil, fmt.Errorf("related entity %s primary identifier must have exactly one field", targetModelName)
+		if len(primaryID.Fields) == 0 {
+			return nil, fmt.Errorf("related entity %s primary identifier must have at least one field", targetModelName)
 		}
 
-		targetPrimaryIdName := primaryID.Fields[0]
-		targetPrimaryIdField, primaryFieldExists := relatedModel.Fields[targetPrimaryIdName]
-		if !primaryFieldExists {
-			return nil, fmt.Errorf("related entity %s primary identifier field %s not found", targetModelName, targetPrimaryIdName)
-		}
+		for _, targetPrimaryIdName := range primaryID.Fields {
+			targetPrimaryIdField, primaryFieldExists := relatedModel.Fields[targetPrimaryIdName]
+			if !primaryFieldExists {
+				return nil, fmt.Errorf("related entity %s primary identifier field %s not found", targetModelName, targetPrimaryIdName)
+			}
+
+			if !yamlops.IsRelationFor(relationType) || !yamlops.IsRelationOne(relationType) {
+				continue
+			}
 
-		if yamlops.IsRelationFor(relationType) && yamlops.IsRelationOne(relationType) {
 			// Use relatedModelName for column naming to maintain backward compatibility
 			columnName := GetForeignKeyColumnName(relatedModelName, targetPrimaryIdName)
 
@@ -287,7 +530,7 @@ func getColumnsForModelRelations(r *registry.Registry, typeMap map[yaml.ModelFie
 			column := psqldef.TableColumn{
 				Name:       columnName,
 				Type:       columnType,
-				NotNull:    true,
+				NotNull:    !isOptional,
 				PrimaryKey: false,
 				Default:    "",
 			}
@@ -299,8 +542,10 @@ func getColumnsForModelRelations(r *registry.Registry, typeMap map[yaml.ModelFie
 	return columns, nil
 }
 
-func getForeignKeysForModelRelations(schema string, tableName string, r *registry.Registry, relatedModels map[string]yaml.ModelRelation) ([]psqldef.ForeignKey, error) {
+func getForeignKeysForModelRelations(modelsConfig cfg.MorpheModelsConfig, tableName string, r *registry.Registry, model yaml.Model) ([]psqldef.ForeignKey, error) {
 	foreignKeys := []psqldef.ForeignKey{}
+	schema := modelsConfig.Schema
+	relatedModels := model.Related
 
 	relatedModelNames := core.MapKeysSorted(relatedModels)
 	for _, relatedModelName := range relatedModelNames {
@@ -323,33 +568,35 @@ func getForeignKeysForModelRelations(schema string, tableName string, r *registr
 			return nil, fmt.Errorf("related model %s has no primary identifier", targetModelName)
 		}
 
-		if len(primaryID.Fields) != 1 {
-			return nil, fmt.Errorf("related entity %s primary identifier must have exactly one field", targetModelName)
+		if len(primaryID.Fields) == 0 {
+			return nil, fmt.Errorf("related entity %s primary identifier must have at least one field", targetModelName)
 		}
 
-		targetPrimaryIdName := primaryID.Fields[0]
-		_, primaryFieldExists := relatedModel.Fields[targetPrimaryIdName]
-		if !primaryFieldExists {
-			return nil, fmt.Errorf("related entity %s primary identifier field %s not found", targetModelName, targetPrimaryIdName)
+		for _, targetPrimaryIdName := range primaryID.Fields {
+			_, primaryFieldExists := relatedModel.Fields[targetPrimaryIdName]
+			if !primaryFieldExists {
+				return nil, fmt.Errorf("related entity %s primary identifier field %s not found", targetModelName, targetPrimaryIdName)
+			}
 		}
 
 		if yamlops.IsRelationFor(relationType) && yamlops.IsRelationOne(relationType) {
 			// Use relatedModelName for column naming to maintain backward compatibility
-			columnName := GetForeignKeyColumnName(relatedModelName, targetPrimaryIdName)
+			columnNames := getForeignKeyColumnNames(relatedModelName, primaryID.Fields)
 			// Use targetModelName for the reference table
 			refTableName := GetTableNameFromModel(targetModelName)
-			refColumnName := GetColumnNameFromField(targetPrimaryIdName)
+			refColumnNames := getColumnNamesFromFields(primaryID.Fields)
+			onDelete, onUpdate := modelsConfig.GetRelationReferentialActions(model.Name, relatedModelName)
 
 			foreignKey := psqldef.ForeignKey{
 				Schema:         schema,
-				Name:           GetForeignKeyConstraintName(tableName, columnName),
+				Name:           GetForeignKeyConstraintName(tableName, strings.Join(columnNames, "_")),
 				TableName:      tableName,
-				ColumnNames:    []string{columnName},
+				ColumnNames:    columnNames,
 				RefSchema:      schema,
 				RefTableName:   refTableName,
-				RefColumnNames: []string{refColumnName},
-				OnDelete:       "CASCADE",
-				OnUpdate:       "",
+				RefColumnNames: refColumnNames,
+				OnDelete:       onDelete,
+				OnUpdate:       onUpdate,
 			}
 
 			foreignKeys = append(foreignKeys, foreignKey)
@@ -363,16 +610,15 @@ func getIndicesForForeignKeys(schema string, tableName string, foreignKeys []psq
 	indices := []psqldef.Index{}
 
 	for _, fk := range foreignKeys {
-		for _, columnName := range fk.ColumnNames {
-			index := psqldef.Index{
-				Name:      GetIndexName(tableName, columnName),
-				TableName: tableName,
-				Columns:   []string{columnName},
-				IsUnique:  false,
-			}
-
-			indices = append(indices, index)
+		// A composite foreign key is covered by a single multi-column index
+		index := psqldef.Index{
+			Name:      GetIndexName(tableName, strings.Join(fk.ColumnNames, "_")),
+			TableName: tableName,
+			Columns:   slices.Clone(fk.ColumnNames),
+			IsUnique:  false,
 		}
+
+		indices = append(indices, index)
 	}
 
 	return indices
@@ -416,8 +662,11 @@ func triggerCompileMorpheModelFailure(hooks hook.CompileMorpheModel, morpheConfi
 }
 
 // getJunctionTablesForForManyRelations creates junction tables for ForMany relationships
-func getJunctionTablesForForManyRelations(schema string, r *registry.Registry, model yaml.Model) ([]*psqldef.Table, error) {
+func getJunctionTablesForForManyRelations(config cfg.MorpheConfig, r *registry.Registry, model yaml.Model) ([]*psqldef.Table, error) {
 	junctionTables := []*psqldef.Table{}
+	modelsConfig := config.MorpheModelsConfig
+	schema := modelsConfig.Schema
+	_, relatedTypeMap := getModelFieldTypeMaps(modelsConfig)
 	modelName := model.Name
 	tableName := GetTableNameFromModel(modelName)
 
@@ -426,10 +675,11 @@ func getJunctionTablesForForManyRelations(schema string, r *registry.Registry, m
 	if !hasPrimary {
 		return nil, fmt.Errorf("model %s has no primary identifier", modelName)
 	}
-	if len(primaryID.Fields) != 1 {
-		return nil, fmt.Errorf("model %s primary identifier must have exactly one field", modelName)
+	if len(primaryID.Fields) == 0 {
+		return nil, fmt.Errorf("model %s primary identifier must have at least one field", modelName)
 	}
-	primaryIdName := primaryID.Fields[0]
+	primaryIdName := strings.Join(getColumnNamesFromFields(primaryID.Fields), "_")
+	sourceColumnNames := getForeignKeyColumnNames(modelName, primaryID.Fields)
 
 	relatedModelNames := core.MapKeysSorted(model.Related)
 	for _, relatedModelName := range relatedModelNames {
@@ -439,6 +689,24 @@ func getJunctionTablesForForManyRelations(schema string, r *registry.Registry, m
 		targetModelName := yamlops.GetRelationTargetName(relatedModelName, modelRelation.Aliased)
 
 		if yamlops.IsRelationFor(relationType) && yamlops.IsRelationMany(relationType) && !yamlops.IsRelationPoly(relationType) {
+			// Reciprocal ForMany relationships share the junction table created alongside the owning model
+			junction, junctionErr := resolveForManyJunction(r, model, relatedModelName, modelRelation)
+			if junctionErr != nil {
+				return nil, junctionErr
+			}
+			relationOptions := modelsConfig.ModelOptions[modelName].Relations[relatedModelName]
+			if !junction.IsOwner {
+				if len(relationOptions.Fields) > 0 {
+					return nil, fmt.Errorf("%w: %s.%s shares the junction table of %s.%s", ErrJunctionFieldsOnReciprocalRelation,
+						modelName, relatedModelName, junction.OwnerModelName, junction.OwnerRelationName)
+				}
+				optionsErr := validateSharedJunctionOptions(modelsConfig, modelName, relatedModelName, junction)
+				if optionsErr != nil {
+					return nil, optionsErr
+				}
+				continue
+			}
+
 			// Use targetModelName for model lookup
 			relatedModel, modelErr := r.GetModel(targetModelName)
 			if modelErr != nil {
@@ -450,79 +718,73 @@ func getJunctionTablesForForManyRelations(schema string, r *registry.Registry, m
 			if !hasRelatedPrimary {
 				return nil, fmt.Errorf("related model %s has no primary identifier", targetModelName)
 			}
-			if len(relatedPrimaryID.Fields) != 1 {
-				return nil, fmt.Errorf("related model %s primary identifier must have exactly one field", targetModelName)
+			if len(relatedPrimaryID.Fields) == 0 {
+				return nil, fmt.Errorf("related model %s primary identifier must have at least one field", targetModelName)
 			}
-			relatedPrimaryIdName := relatedPrimaryID.Fields[0]
+			relatedPrimaryIdName := strings.Join(getColumnNamesFromFields(relatedPrimaryID.Fields), "_")
 
 			// Create junction table - use relatedModelName for naming to maintain backward compatibility
 			junctionTableName := GetJunctionTableName(modelName, relatedModelName)
 
 			// Create column names - use relationship names for columns
-			sourceColumnName := GetForeignKeyColumnName(modelName, primaryIdName)
-			targetColumnName := GetForeignKeyColumnName(relatedModelName, relatedPrimaryIdName)
+			targetColumnNames := getForeignKeyColumnNames(relatedModelName, relatedPrimaryID.Fields)
 
-			// Create columns
-			columns := []psqldef.TableColumn{
-				{
-					Name:       "id",
-					Type:       psqldef.PSQLTypeSerial,
-					PrimaryKey: true,
-				},
-				{
-					Name: sourceColumnName,
-					Type: psqldef.PSQLTypeInteger,
-				},
-				{
-					Name: targetColumnName,
-					Type: psqldef.PSQLTypeInteger,
-				},
+			// The relation's referential actions apply to both sides of the junction table
+			onDelete, onUpdate := modelsConfig.GetRelationReferentialActions(modelName, relatedModelName)
+
+			// Create columns, one per primary identifier field on either side, typed like the referenced fields
+			columns := getJunctionIdColumns(modelsConfig)
+			sourceColumns, sourceColumnsErr := getJunctionForeignKeyColumns(modelsConfig, relatedTypeMap, model, sourceColumnNames)
+			if sourceColumnsErr != nil {
+				return nil, sourceColumnsErr
+			}
+			targetColumns, targetColumnsErr := getJunctionForeignKeyColumns(modelsConfig, relatedTypeMap, relatedModel, targetColumnNames)
+			if targetColumnsErr != nil {
+				return nil, targetColumnsErr
 			}
+			columns = append(columns, sourceColumns...)
+			columns = append(columns, targetColumns...)
 
 			// Create foreign keys
 			foreignKeys := []psqldef.ForeignKey{
 				{
-					Schema:       schema,
-					Name:         GetJunctionTableForeignKeyConstraintName(junctionTableName, modelName, primaryIdName),
-					TableName:    junctionTableName,
-					ColumnNames:  []string{sourceColumnName},
-					RefSchema:    schema,
-					RefTableName: tableName,
-					RefColumnNames: []string{
-						GetColumnNameFromField(primaryIdName),
-					},
-					OnDelete: "CASCADE",
+					Schema:         schema,
+					Name:           GetJunctionTableForeignKeyConstraintName(junctionTableName, modelName, primaryIdName),
+					TableName:      junctionTableName,
+					ColumnNames:    slices.Clone(sourceColumnNames),
+					RefSchema:      schema,
+					RefTableName:   tableName,
+					RefColumnNames: getColumnNamesFromFields(primaryID.Fields),
+					OnDelete:       onDelete,
+					OnUpdate:       onUpdate,
 				},
 				{
-					Schema:       schema,
+					Schema: schema,
 					// Use relatedModelName for constraint naming
-					Name:         GetJunctionTableForeignKeyConstraintName(junctionTableName, relatedModelName, relatedPrimaryIdName),
-					TableName:    junctionTableName,
-					ColumnNames:  []string{targetColumnName},
-					RefSchema:    schema,
+					Name:        GetJunctionTableForeignKeyConstraintName(junctionTableName, relatedModelName, relatedPrimaryIdName),
+					TableName:   junctionTableName,
+					ColumnNames: targetColumnNames,
+					RefSchema:   schema,
 					// Use targetModelName for the reference table
-					RefTableName: GetTableNameFromModel(targetModelName),
-					RefColumnNames: []string{
-						GetColumnNameFromField(relatedPrimaryIdName),
-					},
-					OnDelete: "CASCADE",
+					RefTableName:   GetTableNameFromModel(targetModelName),
+					RefColumnNames: getColumnNamesFromFields(relatedPrimaryID.Fields),
+					OnDelete:       onDelete,
+					OnUpdate:       onUpdate,
 				},
 			}
 
-			// Create unique constraint - use relationship names
-			uniqueConstraints := []psqldef.UniqueConstraint{
-				{
+			// Create unique constraint - use relationship names, unless the columns already make up the primary key
+			uniqueConstraints := []psqldef.UniqueConstraint{}
+			if !modelsConfig.JunctionCompositeKeys {
+				uniqueConstraints = append(uniqueConstraints, psqldef.UniqueConstraint{
 					Name: GetJunctionTableUniqueConstraintName(
 						junctionTableName,
 						modelName, primaryIdName,
 						relatedModelName, relatedPrimaryIdName,
 					),
-					TableName: junctionTableName,
-					ColumnNames: []string{
-						sourceColumnName,
-						targetColumnName,
-					},
-				},
+					TableName:   junctionTableName,
+					ColumnNames: append(slices.Clone(sourceColumnNames), targetColumnNames...),
+				})
 			}
 
 			// Create indices for foreign keys
@@ -538,6 +800,11 @@ func getJunctionTablesForForManyRelations(schema string, r *registry.Registry, m
 				UniqueConstraints: uniqueConstraints,
 			}
 
+			fieldsErr := addJunctionFields(config, r, junctionTable, sourceColumnNames, relationOptions)
+			if fieldsErr != nil {
+				return nil, fieldsErr
+			}
+
 			junctionTables = append(junctionTables, junctionTable)
 		}
 	}
@@ -546,8 +813,11 @@ func getJunctionTablesForForManyRelations(schema string, r *registry.Registry, m
 }
 
 // getJunctionTablesForForManyPolyRelations creates polymorphic junction tables for ForManyPoly relationships
-func getJunctionTablesForForManyPolyRelations(schema string, r *registry.Registry, model yaml.Model) ([]*psqldef.Table, error) {
+func getJunctionTablesForForManyPolyRelations(config cfg.MorpheConfig, r *registry.Registry, model yaml.Model) ([]*psqldef.Table, error) {
 	junctionTables := []*psqldef.Table{}
+	modelsConfig := config.MorpheModelsConfig
+	schema := modelsConfig.Schema
+	_, relatedTypeMap := getModelFieldTypeMaps(modelsConfig)
 	modelName := model.Name
 	tableName := GetTableNameFromModel(modelName)
 
@@ -556,10 +826,11 @@ func getJunctionTablesForForManyPolyRelations(schema string, r *registry.Registr
 	if !hasPrimary {
 		return nil, fmt.Errorf("model %s has no primary identifier", modelName)
 	}
-	if len(primaryID.Fields) != 1 {
-		return nil, fmt.Errorf("model %s primary identifier must have exactly one field", modelName)
+	if len(primaryID.Fields) == 0 {
+		return nil, fmt.Errorf("model %s primary identifier must have at least one field", modelName)
 	}
-	primaryIdName := primaryID.Fields[0]
+	primaryIdName := strings.Join(getColumnNamesFromFields(primaryID.Fields), "_")
+	sourceColumnNames := getForeignKeyColumnNames(modelName, primaryID.Fields)
 
 	relatedModelNames := core.MapKeysSorted(model.Related)
 	for _, relationName := range relatedModelNames {
@@ -570,63 +841,66 @@ func getJunctionTablesForForManyPolyRelations(schema string, r *registry.Registr
 			// Create junction table name - use relation name instead of target model name
 			junctionTableName := GetJunctionTableName(modelName, relationName)
 
+			onDelete, onUpdate := modelsConfig.GetRelationReferentialActions(modelName, relationName)
+
+			idColumnType, idColumnTypeErr := getPolymorphicIdColumnType(r, relatedTypeMap, modelRelation)
+			if idColumnTypeErr != nil {
+				return nil, idColumnTypeErr
+			}
+
 			// Create column names
-			sourceColumnName := GetForeignKeyColumnName(modelName, primaryIdName)
 			typeColumnName := strcase.ToSnakeCaseLower(relationName) + "_type"
 			idColumnName := strcase.ToSnakeCaseLower(relationName) + "_id"
 
 			// Create columns
-			columns := []psqldef.TableColumn{
-				{
-					Name:       "id",
-					Type:       psqldef.PSQLTypeSerial,
-					PrimaryKey: true,
-				},
-				{
-					Name: sourceColumnName,
-					Type: psqldef.PSQLTypeInteger,
-				},
+			columns := getJunctionIdColumns(modelsConfig)
+			sourceColumns, sourceColumnsErr := getJunctionForeignKeyColumns(modelsConfig, relatedTypeMap, model, sourceColumnNames)
+			if sourceColumnsErr != nil {
+				return nil, sourceColumnsErr
+			}
+			columns = append(columns, sourceColumns...)
+			columns = append(columns, []psqldef.TableColumn{
 				{
-					Name: typeColumnName,
-					Type: psqldef.PSQLTypeText,
+					Name:       typeColumnName,
+					Type:       psqldef.PSQLTypeText,
+					NotNull:    true,
+					PrimaryKey: modelsConfig.JunctionCompositeKeys,
 				},
 				{
-					Name: idColumnName,
-					Type: psqldef.PSQLTypeText,
+					Name:       idColumnName,
+					Type:       idColumnType,
+					NotNull:    true,
+					PrimaryKey: modelsConfig.JunctionCompositeKeys,
 				},
-			}
+			}...)
 
 			// Create foreign key only for the source model (no FK for polymorphic columns)
 			foreignKeys := []psqldef.ForeignKey{
 				{
-					Schema:       schema,
-					Name:         GetJunctionTableForeignKeyConstraintName(junctionTableName, modelName, primaryIdName),
-					TableName:    junctionTableName,
-					ColumnNames:  []string{sourceColumnName},
-					RefSchema:    schema,
-					RefTableName: tableName,
-					RefColumnNames: []string{
-						GetColumnNameFromField(primaryIdName),
-					},
-					OnDelete: "CASCADE",
+					Schema:         schema,
+					Name:           GetJunctionTableForeignKeyConstraintName(junctionTableName, modelName, primaryIdName),
+					TableName:      junctionTableName,
+					ColumnNames:    slices.Clone(sourceColumnNames),
+					RefSchema:      schema,
+					RefTableName:   tableName,
+					RefColumnNames: getColumnNamesFromFields(primaryID.Fields),
+					OnDelete:       onDelete,
+					OnUpdate:       onUpdate,
 				},
 			}
 
-			// Create unique constraint on (source_id, target_type, target_id)
-			uniqueConstraints := []psqldef.UniqueConstraint{
-				{
+			// Create unique constraint on (source_id, target_type, target_id), unless it is the primary key
+			uniqueConstraints := []psqldef.UniqueConstraint{}
+			if !modelsConfig.JunctionCompositeKeys {
+				uniqueConstraints = append(uniqueConstraints, psqldef.UniqueConstraint{
 					Name: GetPolymorphicJunctionTableUniqueConstraintName(
 						junctionTableName,
 						modelName, primaryIdName,
 						relationName,
 					),
-					TableName: junctionTableName,
-					ColumnNames: []string{
-						sourceColumnName,
-						typeColumnName,
-						idColumnName,
-					},
-				},
+					TableName:   junctionTableName,
+					ColumnNames: append(slices.Clone(sourceColumnNames), typeColumnName, idColumnName),
+				})
 			}
 
 			// Create indices for foreign keys
@@ -642,6 +916,18 @@ func getJunctionTablesForForManyPolyRelations(schema string, r *registry.Registr
 				UniqueConstraints: uniqueConstraints,
 			}
 
+			fieldsErr := addJunctionFields(config, r, junctionTable, sourceColumnNames, modelsConfig.ModelOptions[modelName].Relations[relationName])
+			if fieldsErr != nil {
+				return nil, fieldsErr
+			}
+
+			if modelsConfig.EnablePolymorphicTriggers {
+				polyTriggersErr := addPolymorphicTriggers(junctionTable, r, relationName, modelRelation)
+				if polyTriggersErr != nil {
+					return nil, polyTriggersErr
+				}
+			}
+
 			junctionTables = append(junctionTables, junctionTable)
 		}
 	}
@@ -649,6 +935,117 @@ func getJunctionTablesForForManyPolyRelations(schema string, r *registry.Registr
 	return junctionTables, nil
 }
 
+// getJunctionIdColumns returns the surrogate id column of a junction table, or none when junction tables use their
+// foreign key columns as a composite primary key
+func getJunctionIdColumns(modelsConfig cfg.MorpheModelsConfig) []psqldef.TableColumn {
+	if modelsConfig.JunctionCompositeKeys {
+		return []psqldef.TableColumn{}
+	}
+	typeMap, _ := getModelFieldTypeMaps(modelsConfig)
+	return []psqldef.TableColumn{
+		{
+			Name:       "id",
+			Type:       typeMap[yaml.ModelFieldTypeAutoIncrement],
+			PrimaryKey: true,
+		},
+	}
+}
+
+// getJunctionForeignKeyColumns returns the NOT NULL junction columns referencing the primary identifier of a model,
+// typed from the foreign type map like the model's primary fields
+func getJunctionForeignKeyColumns(modelsConfig cfg.MorpheModelsConfig, relatedTypeMap map[yaml.ModelFieldType]psqldef.PSQLType, model yaml.Model, columnNames []string) ([]psqldef.TableColumn, error) {
+	primaryID := model.Identifiers["primary"]
+
+	columns := make([]psqldef.TableColumn, len(columnNames))
+	for fieldIdx, primaryFieldName := range primaryID.Fields {
+		primaryField, primaryFieldExists := model.Fields[primaryFieldName]
+		if !primaryFieldExists {
+			return nil, fmt.Errorf("model %s primary identifier field %s not found", model.Name, primaryFieldName)
+		}
+		columnType, supported := relatedTypeMap[primaryField.Type]
+		if !supported {
+			return nil, fmt.Errorf("unsupported primary identifier field type %s for model %s", primaryField.Type, model.Name)
+		}
+		columns[fieldIdx] = psqldef.TableColumn{
+			Name:       columnNames[fieldIdx],
+			Type:       columnType,
+			NotNull:    true,
+			PrimaryKey: modelsConfig.JunctionCompositeKeys,
+		}
+	}
+	return columns, nil
+}
+
+// getPolymorphicIdColumnType returns the type of the primary field shared by all models a polymorphic relation is for,
+// falling back to TEXT when the models' primary fields map to different types
+func getPolymorphicIdColumnType(r *registry.Registry, typeMap map[yaml.ModelFieldType]psqldef.PSQLType, relation yaml.ModelRelation) (psqldef.PSQLType, error) {
+	var idColumnType psqldef.PSQLType
+	for _, forModelName := range relation.For {
+		forModel, modelErr := r.GetModel(forModelName)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+
+		primaryID, hasPrimary := forModel.Identifiers["primary"]
+		if !hasPrimary || len(primaryID.Fields) != 1 {
+			return psqldef.PSQLTypeText, nil
+		}
+		primaryField, primaryFieldExists := forModel.Fields[primaryID.Fields[0]]
+		if !primaryFieldExists {
+			return nil, fmt.Errorf("polymorphic target model %s primary identifier field %s not found", forModelName, primaryID.Fields[0])
+		}
+
+		forColumnType, supported := typeMap[primaryField.Type]
+		if !supported {
+			return psqldef.PSQLTypeText, nil
+		}
+		if idColumnType != nil && idColumnType.GetSyntax() != forColumnType.GetSyntax() {
+			return psqldef.PSQLTypeText, nil
+		}
+		idColumnType = forColumnType
+	}
+
+	if idColumnType == nil {
+		return psqldef.PSQLTypeText, nil
+	}
+	return idColumnType, nil
+}
+
+// addPolymorphicTriggersForForOnePolyRelations adds trigger functions enforcing the ForOnePoly relations of a model table
+func addPolymorphicTriggersForForOnePolyRelations(table *psqldef.Table, r *registry.Registry, relatedModels map[string]yaml.ModelRelation) error {
+	relationNames := core.MapKeysSorted(relatedModels)
+	for _, relationName := range relationNames {
+		modelRelation := relatedModels[relationName]
+		if !yamlops.IsRelationPolyFor(modelRelation.Type) || !yamlops.IsRelationPolyOne(modelRelation.Type) {
+			continue
+		}
+
+		polyTriggersErr := addPolymorphicTriggers(table, r, relationName, modelRelation)
+		if polyTriggersErr != nil {
+			return polyTriggersErr
+		}
+	}
+	return nil
+}
+
+// getForeignKeyColumnNames returns the foreign key column names referencing each primary identifier field of a related model
+func getForeignKeyColumnNames(relatedModelName string, primaryIdFields []string) []string {
+	columnNames := make([]string, len(primaryIdFields))
+	for fieldIdx, fieldName := range primaryIdFields {
+		columnNames[fieldIdx] = GetForeignKeyColumnName(relatedModelName, fieldName)
+	}
+	return columnNames
+}
+
+// getColumnNamesFromFields returns the column names for a list of field names
+func getColumnNamesFromFields(fieldNames []string) []string {
+	columnNames := make([]string, len(fieldNames))
+	for fieldIdx, fieldName := range fieldNames {
+		columnNames[fieldIdx] = GetColumnNameFromField(fieldName)
+	}
+	return columnNames
+}
+
 // addUniqueIndicesFromIdentifiers adds unique indices for model identifiers
 func addUniqueIndicesFromIdentifiers(table *psqldef.Table, identifiers map[string]yaml.ModelIdentifier) {
 	tableName := table.Name
@@ -727,6 +1124,12 @@ func quoteReservedColumnNames(table *psqldef.Table) {
 			}
 		}
 	}
+
+	for colIdx, colName := range table.PartitionColumns {
+		if reservedWords[colName] {
+			table.PartitionColumns[colIdx] = fmt.Sprintf("\"%s\"", colName)
+		}
+	}
 }
 
 // ensureNamedForeignKeyConstraints ensures all foreign keys have proper names and CASCADE behavior
@@ -745,6 +1148,33 @@ func ensureNamedForeignKeyConstraints(table *psqldef.Table) {
 	}
 }
 
+// validateForeignKeyActions ensures SET NULL referential actions are only used on foreign keys with nullable columns
+func validateForeignKeyActions(table *psqldef.Table) error {
+	nullableColumns := map[string]bool{}
+	for _, column := range table.Columns {
+		nullableColumns[column.Name] = !column.NotNull && !column.PrimaryKey
+	}
+
+	for _, fk := range table.ForeignKeys {
+		setNullColumnNames := []string{}
+		if fk.OnDelete == cfg.ReferentialActionSetNull {
+			setNullColumnNames = fk.ColumnNames
+			if len(fk.SetNullColumnNames) > 0 {
+				setNullColumnNames = fk.SetNullColumnNames
+			}
+		}
+		if fk.OnUpdate == cfg.ReferentialActionSetNull {
+			setNullColumnNames = fk.ColumnNames
+		}
+		for _, columnName := range setNullColumnNames {
+			if !nullableColumns[columnName] {
+				return fmt.Errorf("foreign key '%s' on table '%s' cannot use SET NULL because column '%s' is NOT NULL", fk.Name, table.Name, columnName)
+			}
+		}
+	}
+	return nil
+}
+
 // validatePolymorphicRelationships validates polymorphic relationships and their through properties
 func validatePolymorphicRelationships(r *registry.Registry, model yaml.Model) error {
 	for relationName, relation := range model.Related {
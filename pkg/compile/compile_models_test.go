@@ -3,6 +3,7 @@ package compile_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kalo-build/morphe-go/pkg/registry"
 	"github.com/kalo-build/morphe-go/pkg/yaml"
@@ -196,6 +197,191 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables() {
 	suite.Len(table0.UniqueConstraints, 0)
 }
 
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_FieldAttributes() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Age": {
+				Type:       yaml.ModelFieldTypeInteger,
+				Attributes: []string{"default:0", "min:0", "max:150"},
+			},
+			"Code": {
+				Type:       yaml.ModelFieldTypeString,
+				Attributes: []string{"default:it's", "maxLength:8", "pattern:^[A-Z]+$"},
+			},
+			"Active": {
+				Type:       yaml.ModelFieldTypeBoolean,
+				Attributes: []string{"default:true"},
+			},
+			"CreatedAt": {
+				Type:       yaml.ModelFieldTypeTime,
+				Attributes: []string{"defaultExpr:now()"},
+			},
+			"Label": {
+				Type:       yaml.ModelFieldTypeString,
+				Attributes: []string{"default:upper(x)"},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	table0 := allTables[0]
+
+	columns0 := table0.Columns
+	suite.Len(columns0, 6)
+
+	suite.Equal("active", columns0[0].Name)
+	suite.Equal("TRUE", columns0[0].Default)
+
+	suite.Equal("age", columns0[1].Name)
+	suite.Equal("0", columns0[1].Default)
+
+	suite.Equal("code", columns0[2].Name)
+	suite.Equal("'it''s'", columns0[2].Default)
+
+	suite.Equal("created_at", columns0[3].Name)
+	suite.Equal("now()", columns0[3].Default)
+
+	suite.Equal("id", columns0[4].Name)
+	suite.Equal("", columns0[4].Default)
+
+	suite.Equal("label", columns0[5].Name)
+	suite.Equal("'upper(x)'", columns0[5].Default)
+
+	suite.Equal([]psqldef.CheckConstraint{
+		{Schema: "public", Name: "ck_basics_age_min", TableName: "basics", Expression: "age >= 0"},
+		{Schema: "public", Name: "ck_basics_age_max", TableName: "basics", Expression: "age <= 150"},
+		{Schema: "public", Name: "ck_basics_code_max_length", TableName: "basics", Expression: "char_length(code) <= 8"},
+		{Schema: "public", Name: "ck_basics_code_pattern", TableName: "basics", Expression: "code ~ '^[A-Z]+$'"},
+	}, table0.CheckConstraints)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_FieldAttributes_InvalidBound() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Code": {
+				Type:       yaml.ModelFieldTypeString,
+				Attributes: []string{"min:1"},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.NotNil(allTablesErr)
+	suite.ErrorContains(allTablesErr, "morphe model field 'Code' of type 'TEXT' does not support the 'min' attribute")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_FieldAttributes_DefaultAndDefaultExpr() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"CreatedAt": {
+				Type:       yaml.ModelFieldTypeTime,
+				Attributes: []string{"default:2020-01-01", "defaultExpr:now()"},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.NotNil(allTablesErr)
+	suite.ErrorContains(allTablesErr, "morphe model field 'CreatedAt' has both a default and a default expression")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_FieldAttributes_LookupTableEnum() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nationality": {
+				Type:       "Nationality",
+				Attributes: []string{"default:US"},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetEnum("Nationality", enum0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.NotNil(allTablesErr)
+	suite.ErrorContains(allTablesErr, "morphe model field 'Nationality' of lookup table enum type 'Nationality' does not support the 'default' attribute")
+	suite.Nil(allTables)
+}
+
 func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_UseBigSerial() {
 	config := suite.getCompileConfig()
 	config.MorpheModelsConfig.UseBigSerial = true
@@ -496,122 +682,112 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne(
 	suite.Equal("basics", index0.TableName)
 	suite.Len(index0.Columns, 1)
 	suite.Equal("basic_parent_id", index0.Columns[0])
+	suite.False(index0.IsUnique)
 
 	suite.Len(table0.UniqueConstraints, 0)
 }
 
-func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_Aliased() {
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_CompositePrimary() {
 	config := suite.getCompileConfig()
 
-	// Person model with aliased relationships to Contact model
-	personModel := yaml.Model{
-		Name: "Person",
+	model0 := yaml.Model{
+		Name: "Basic",
 		Fields: map[string]yaml.ModelField{
 			"ID": {
 				Type: yaml.ModelFieldTypeAutoIncrement,
 			},
-			"Name": {
-				Type: yaml.ModelFieldTypeString,
-			},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
 			"primary": {
-				Fields: []string{"ID"},
+				Fields: []string{
+					"ID",
+				},
 			},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"WorkContact": {
-				Type:    "ForOne",
-				Aliased: "Contact",
-			},
-			"PersonalContact": {
-				Type:    "ForOne",
-				Aliased: "Contact",
+			"BasicParent": {
+				Type: "ForOne",
 			},
 		},
 	}
-
-	// Contact model that is referenced by aliased relationships
-	contactModel := yaml.Model{
-		Name: "Contact",
+	model1 := yaml.Model{
+		Name: "BasicParent",
 		Fields: map[string]yaml.ModelField{
-			"ID": {
-				Type: yaml.ModelFieldTypeAutoIncrement,
-			},
-			"Email": {
-				Type: yaml.ModelFieldTypeString,
+			"TenantID": {
+				Type: yaml.ModelFieldTypeInteger,
 			},
-			"Phone": {
+			"Code": {
 				Type: yaml.ModelFieldTypeString,
 			},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
 			"primary": {
-				Fields: []string{"ID"},
+				Fields: []string{
+					"TenantID",
+					"Code",
+				},
 			},
 		},
 		Related: map[string]yaml.ModelRelation{
-			"PersonsAsWork": {
-				Type:    "HasMany",
-				Aliased: "Person.WorkContact",
-			},
-			"PersonsAsPersonal": {
-				Type:    "HasMany",
-				Aliased: "Person.PersonalContact",
+			"Basic": {
+				Type: "HasMany",
 			},
 		},
 	}
-
 	r := registry.NewRegistry()
-	r.SetModel("Person", personModel)
-	r.SetModel("Contact", contactModel)
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
 
-	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, personModel)
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
 
 	suite.Nil(allTablesErr)
 	suite.Len(allTables, 1)
 
-	table := allTables[0]
+	table0 := allTables[0]
+	suite.Equal("basics", table0.Name)
 
-	// Check table basics
-	suite.Equal(config.MorpheConfig.MorpheModelsConfig.Schema, table.Schema)
-	suite.Equal("people", table.Name)
-	suite.Len(table.Columns, 4) // id, name, work_contact_id, personal_contact_id
+	columns0 := table0.Columns
+	suite.Len(columns0, 3)
 
-	// Check columns - they should use relationship names, not aliased target
-	suite.Equal("id", table.Columns[0].Name)
-	suite.Equal("name", table.Columns[1].Name)
-	// PersonalContact comes before WorkContact alphabetically
-	suite.Equal("personal_contact_id", table.Columns[2].Name)
-	suite.Equal(psqldef.PSQLTypeInteger, table.Columns[2].Type)
-	suite.Equal("work_contact_id", table.Columns[3].Name)
-	suite.Equal(psqldef.PSQLTypeInteger, table.Columns[3].Type)
+	columns01 := columns0[1]
+	suite.Equal("basic_parent_tenant_id", columns01.Name)
+	suite.Equal(psqldef.PSQLTypeInteger, columns01.Type)
+	suite.True(columns01.NotNull)
+	suite.False(columns01.PrimaryKey)
 
-	// Check foreign keys - they should reference the correct target table
-	suite.Len(table.ForeignKeys, 2)
+	columns02 := columns0[2]
+	suite.Equal("basic_parent_code", columns02.Name)
+	suite.Equal(psqldef.PSQLTypeText, columns02.Type)
+	suite.True(columns02.NotNull)
+	suite.False(columns02.PrimaryKey)
 
-	// Personal contact foreign key (comes first alphabetically)
-	suite.Equal("fk_people_personal_contact_id", table.ForeignKeys[0].Name)
-	suite.Equal("people", table.ForeignKeys[0].TableName)
-	suite.Equal([]string{"personal_contact_id"}, table.ForeignKeys[0].ColumnNames)
-	suite.Equal("contacts", table.ForeignKeys[0].RefTableName) // References Contact table
-	suite.Equal([]string{"id"}, table.ForeignKeys[0].RefColumnNames)
+	suite.Len(table0.ForeignKeys, 1)
 
-	// Work contact foreign key
-	suite.Equal("fk_people_work_contact_id", table.ForeignKeys[1].Name)
-	suite.Equal("people", table.ForeignKeys[1].TableName)
-	suite.Equal([]string{"work_contact_id"}, table.ForeignKeys[1].ColumnNames)
-	suite.Equal("contacts", table.ForeignKeys[1].RefTableName) // References Contact table
-	suite.Equal([]string{"id"}, table.ForeignKeys[1].RefColumnNames)
+	foreignKey0 := table0.ForeignKeys[0]
+	suite.Equal("fk_basics_basic_parent_tenant_id_basic_parent_code", foreignKey0.Name)
+	suite.Equal("basics", foreignKey0.TableName)
+	suite.Equal([]string{"basic_parent_tenant_id", "basic_parent_code"}, foreignKey0.ColumnNames)
+	suite.Equal("basic_parents", foreignKey0.RefTableName)
+	suite.Equal([]string{"tenant_id", "code"}, foreignKey0.RefColumnNames)
+	suite.Equal("CASCADE", foreignKey0.OnDelete)
 
-	// Check indices
-	suite.Len(table.Indices, 2)
-	suite.Equal("idx_people_personal_contact_id", table.Indices[0].Name)
-	suite.Equal("idx_people_work_contact_id", table.Indices[1].Name)
+	suite.Len(table0.Indices, 1)
+	index0 := table0.Indices[0]
+	suite.Equal("idx_basics_basic_parent_tenant_id_basic_parent_code", index0.Name)
+	suite.Equal([]string{"basic_parent_tenant_id", "basic_parent_code"}, index0.Columns)
+	suite.False(index0.IsUnique)
 }
 
-func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_HasOne() {
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_ReferentialActions() {
 	config := suite.getCompileConfig()
+	config.MorpheConfig.MorpheModelsConfig.DefaultOnUpdate = "NO ACTION"
+	config.MorpheConfig.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnDelete: "RESTRICT"},
+			},
+		},
+	}
 
 	model0 := yaml.Model{
 		Name: "Basic",
@@ -619,9 +795,6 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 			"ID": {
 				Type: yaml.ModelFieldTypeAutoIncrement,
 			},
-			"String": {
-				Type: yaml.ModelFieldTypeString,
-			},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
 			"primary": {
@@ -632,7 +805,7 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 		},
 		Related: map[string]yaml.ModelRelation{
 			"BasicParent": {
-				Type: "ForMany",
+				Type: "ForOne",
 			},
 		},
 	}
@@ -642,9 +815,6 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 			"ID": {
 				Type: yaml.ModelFieldTypeAutoIncrement,
 			},
-			"String": {
-				Type: yaml.ModelFieldTypeString,
-			},
 		},
 		Identifiers: map[string]yaml.ModelIdentifier{
 			"primary": {
@@ -655,7 +825,7 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 		},
 		Related: map[string]yaml.ModelRelation{
 			"Basic": {
-				Type: "HasOne",
+				Type: "HasMany",
 			},
 		},
 	}
@@ -666,117 +836,1660 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
 
 	suite.Nil(allTablesErr)
-	suite.Len(allTables, 2)
+	suite.Len(allTables, 1)
 
 	table0 := allTables[0]
+	suite.Len(table0.ForeignKeys, 1)
 
-	suite.Equal(config.MorpheConfig.MorpheModelsConfig.Schema, table0.Schema)
-	suite.Equal("basics", table0.Name)
+	foreignKey0 := table0.ForeignKeys[0]
+	suite.Equal("fk_basics_basic_parent_id", foreignKey0.Name)
+	suite.Equal("RESTRICT", foreignKey0.OnDelete)
+	suite.Equal("NO ACTION", foreignKey0.OnUpdate)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_SetNullOnNotNullColumn() {
+	config := suite.getCompileConfig()
+	config.MorpheConfig.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnDelete: "SET NULL"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {
+				Type: "ForOne",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {
+				Type: "HasMany",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorContains(allTablesErr, "foreign key 'fk_basics_basic_parent_id' on table 'basics' cannot use SET NULL because column 'basic_parent_id' is NOT NULL")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_Optional() {
+	config := suite.getCompileConfig()
+	config.MorpheConfig.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnDelete: "SET NULL", Optional: true},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {
+				Type: "ForOne",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {
+				Type: "HasMany",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	table0 := allTables[0]
+	suite.Len(table0.Columns, 2)
+
+	column01 := table0.Columns[1]
+	suite.Equal(column01.Name, "basic_parent_id")
+	suite.False(column01.NotNull)
+
+	suite.Len(table0.ForeignKeys, 1)
+	suite.Equal(table0.ForeignKeys[0].OnDelete, "SET NULL")
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_ReferentialActions() {
+	config := suite.getCompileConfig()
+	config.MorpheConfig.MorpheModelsConfig.DefaultOnDelete = "NO ACTION"
+	config.MorpheConfig.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnUpdate: "CASCADE"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {
+				Type: "ForMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {
+				Type: "HasMany",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 2)
+
+	junctionTable := allTables[1]
+	suite.Len(junctionTable.ForeignKeys, 2)
+	for _, foreignKey := range junctionTable.ForeignKeys {
+		suite.Equal("NO ACTION", foreignKey.OnDelete)
+		suite.Equal("CASCADE", foreignKey.OnUpdate)
+	}
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_InvalidReferentialAction() {
+	config := suite.getCompileConfig()
+	config.MorpheConfig.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnDelete: "DELETE"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {
+				Type: "ForOne",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {
+				Type: "HasMany",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, cfg.ErrInvalidReferentialAction)
+	suite.ErrorContains(allTablesErr, "model 'Basic': relation 'BasicParent'")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_AuditColumns() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.AuditColumns = true
+	config.MorpheModelsConfig.AuditJunctionTables = true
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {Type: "ForMany"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {Type: "HasMany"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 2)
+
+	for _, table := range allTables {
+		columnCount := len(table.Columns)
+		suite.GreaterOrEqual(columnCount, 2)
+
+		createdAtColumn := table.Columns[columnCount-2]
+		suite.Equal("created_at", createdAtColumn.Name)
+		suite.Equal(psqldef.PSQLTypeTimestampTZ, createdAtColumn.Type)
+		suite.True(createdAtColumn.NotNull)
+		suite.Equal("NOW()", createdAtColumn.Default)
+
+		updatedAtColumn := table.Columns[columnCount-1]
+		suite.Equal("updated_at", updatedAtColumn.Name)
+		suite.Equal(psqldef.PSQLTypeTimestampTZ, updatedAtColumn.Type)
+		suite.True(updatedAtColumn.NotNull)
+		suite.Equal("NOW()", updatedAtColumn.Default)
+
+		suite.Len(table.Functions, 1)
+		updatedAtFunction := table.Functions[0]
+		suite.Equal("public", updatedAtFunction.Schema)
+		suite.Equal("set_updated_at", updatedAtFunction.Name)
+		suite.Equal("trigger", updatedAtFunction.Returns)
+		suite.True(updatedAtFunction.Shared)
+		suite.Equal([]string{
+			"BEGIN",
+			"\tNEW.updated_at = NOW();",
+			"\tRETURN NEW;",
+			"END;",
+		}, updatedAtFunction.Body)
+
+		suite.Len(table.Triggers, 1)
+		updatedAtTrigger := table.Triggers[0]
+		suite.Equal("trg_set_updated_at", updatedAtTrigger.Name)
+		suite.Equal(table.Name, updatedAtTrigger.TableName)
+		suite.Equal("BEFORE", updatedAtTrigger.Timing)
+		suite.Equal([]string{"UPDATE"}, updatedAtTrigger.Events)
+		suite.Equal("set_updated_at", updatedAtTrigger.FunctionName)
+	}
+
+	// Junction tables are left as-is unless enabled separately
+	config.MorpheModelsConfig.AuditJunctionTables = false
+	modelOnlyTables, modelOnlyErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(modelOnlyErr)
+	suite.Len(modelOnlyTables[0].Triggers, 1)
+	suite.Len(modelOnlyTables[1].Triggers, 0)
+	suite.Len(modelOnlyTables[1].Columns, 3)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_AuditColumns_Conflict() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.AuditColumns = true
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID":        {Type: yaml.ModelFieldTypeAutoIncrement},
+			"CreatedAt": {Type: yaml.ModelFieldTypeTime},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, compile.ErrAuditColumnConflict)
+	suite.ErrorContains(allTablesErr, "basics.created_at")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_AuditJunctionTables_WithoutAuditColumns() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.AuditJunctionTables = true
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, cfg.ErrAuditJunctionTablesWithoutAuditColumns)
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_SoftDelete() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {SoftDelete: true},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID":    {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Email": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+			"email":   {Fields: []string{"Email"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	table0 := allTables[0]
+	suite.Len(table0.Columns, 3)
+	deletedAtColumn := table0.Columns[2]
+	suite.Equal("deleted_at", deletedAtColumn.Name)
+	suite.Equal(psqldef.PSQLTypeTimestampTZ, deletedAtColumn.Type)
+	suite.False(deletedAtColumn.NotNull)
+	suite.Equal("", deletedAtColumn.Default)
+
+	// Identifiers of deleted rows can be reused by live rows
+	suite.Len(table0.Indices, 1)
+	suite.Equal("idx_basics_email", table0.Indices[0].Name)
+	suite.True(table0.Indices[0].IsUnique)
+	suite.Equal("deleted_at IS NULL", table0.Indices[0].Where)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_SoftDelete_Conflict() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {SoftDelete: true},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID":        {Type: yaml.ModelFieldTypeAutoIncrement},
+			"DeletedAt": {Type: yaml.ModelFieldTypeTime},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, compile.ErrSoftDeleteColumnConflict)
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Tenancy() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.Tenancy = cfg.MorpheTenancyConfig{
+		ColumnName:  "tenant_id",
+		SettingName: "app.tenant_id",
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID":          {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Email":       {Type: yaml.ModelFieldTypeString},
+			"Nationality": {Type: "Nationality"},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+			"email":   {Fields: []string{"Email"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {Type: "ForOne"},
+			"BasicTag":    {Type: "ForMany"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {Type: "HasMany"},
+		},
+	}
+	model2 := yaml.Model{
+		Name: "BasicTag",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {Type: "HasMany"},
+		},
+	}
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+	r.SetModel("BasicTag", model2)
+	r.SetEnum("Nationality", enum0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 2)
+
+	tenantCondition := "tenant_id = current_setting('app.tenant_id')::UUID"
+	for _, table := range allTables {
+		tenantColumn := table.Columns[len(table.Columns)-1]
+		suite.Equal("tenant_id", tenantColumn.Name)
+		suite.Equal(psqldef.PSQLTypeUUID, tenantColumn.Type)
+		suite.True(tenantColumn.NotNull)
+		suite.Equal("current_setting('app.tenant_id')::UUID", tenantColumn.Default)
+
+		suite.True(table.RowLevelSecurity)
+		suite.Equal([]psqldef.Policy{
+			{
+				Name:      table.Name + "_tenant_isolation",
+				TableName: table.Name,
+				Command:   "ALL",
+				Using:     tenantCondition,
+				WithCheck: tenantCondition,
+			},
+		}, table.Policies)
+	}
+
+	table0 := allTables[0]
+
+	// Enum lookup tables are shared between tenants
+	suite.Len(table0.ForeignKeys, 2)
+	suite.Equal([]string{"nationality_id"}, table0.ForeignKeys[0].ColumnNames)
+	suite.Equal([]string{"id"}, table0.ForeignKeys[0].RefColumnNames)
+	suite.Equal([]string{"tenant_id", "basic_parent_id"}, table0.ForeignKeys[1].ColumnNames)
+	suite.Equal([]string{"tenant_id", "id"}, table0.ForeignKeys[1].RefColumnNames)
+
+	suite.Len(table0.Indices, 3)
+	suite.Equal([]string{"nationality_id"}, table0.Indices[0].Columns)
+	suite.Equal([]string{"tenant_id", "basic_parent_id"}, table0.Indices[1].Columns)
+	suite.Equal("idx_basics_email", table0.Indices[2].Name)
+	suite.Equal([]string{"tenant_id", "email"}, table0.Indices[2].Columns)
+
+	// Foreign keys within the tenant reference the tenant key
+	suite.Len(table0.UniqueConstraints, 1)
+	suite.Equal("uk_basics_tenant_id_id", table0.UniqueConstraints[0].Name)
+	suite.Equal([]string{"tenant_id", "id"}, table0.UniqueConstraints[0].ColumnNames)
+
+	junctionTable := allTables[1]
+	suite.Equal("basic_basic_tags", junctionTable.Name)
+	suite.Len(junctionTable.ForeignKeys, 2)
+	for _, foreignKey := range junctionTable.ForeignKeys {
+		suite.Equal("tenant_id", foreignKey.ColumnNames[0])
+		suite.Equal([]string{"tenant_id", "id"}, foreignKey.RefColumnNames)
+	}
+	suite.Len(junctionTable.UniqueConstraints, 1)
+	suite.Equal([]string{"tenant_id", "basic_id", "basic_tag_id"}, junctionTable.UniqueConstraints[0].ColumnNames)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Tenancy_SetNullRelation() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.Tenancy = cfg.MorpheTenancyConfig{
+		ColumnName:  "tenant_id",
+		SettingName: "app.tenant_id",
+	}
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnDelete: cfg.ReferentialActionSetNull, Optional: true},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {Type: "ForOne"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {Type: "HasMany"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	// The foreign key stays within the tenant, but deleting the parent only clears the relation column
+	table0 := allTables[0]
+	suite.Len(table0.ForeignKeys, 1)
+	foreignKey0 := table0.ForeignKeys[0]
+	suite.Equal([]string{"tenant_id", "basic_parent_id"}, foreignKey0.ColumnNames)
+	suite.Equal([]string{"tenant_id", "id"}, foreignKey0.RefColumnNames)
+	suite.Equal(cfg.ReferentialActionSetNull, foreignKey0.OnDelete)
+	suite.Equal([]string{"basic_parent_id"}, foreignKey0.SetNullColumnNames)
+
+	// ON UPDATE SET NULL cannot be limited to the relation columns
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Basic": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"BasicParent": {OnUpdate: cfg.ReferentialActionSetNull, Optional: true},
+			},
+		},
+	}
+	allTables, allTablesErr = compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, compile.ErrTenantSetNullOnUpdate)
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Tenancy_NoSetting() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.Tenancy = cfg.MorpheTenancyConfig{
+		ColumnName: "tenant_id",
+	}
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, cfg.ErrNoTenantSetting)
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Partition() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Event": {
+			Partition: cfg.MorphePartitionOptions{
+				Strategy:  cfg.PartitionStrategyRange,
+				KeyFields: []string{"OccurredAt"},
+				Partitions: append(
+					cfg.MonthlyRangePartitions(time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC), 2),
+					cfg.MorphePartition{Name: "default", Default: true},
+				),
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Event",
+		Fields: map[string]yaml.ModelField{
+			"ID":         {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Reference":  {Type: yaml.ModelFieldTypeString},
+			"OccurredAt": {Type: yaml.ModelFieldTypeTime},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary":   {Fields: []string{"ID"}},
+			"reference": {Fields: []string{"Reference"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Event", model0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	table0 := allTables[0]
+	suite.Equal("RANGE", table0.PartitionStrategy)
+	suite.Equal([]string{"occurred_at"}, table0.PartitionColumns)
+
+	// The primary key and unique keys include the partition key
+	primaryKeyColumnNames := []string{}
+	for _, column := range table0.Columns {
+		if column.PrimaryKey {
+			primaryKeyColumnNames = append(primaryKeyColumnNames, column.Name)
+		}
+	}
+	suite.Equal([]string{"id", "occurred_at"}, primaryKeyColumnNames)
+	suite.Len(table0.Indices, 1)
+	suite.Equal([]string{"reference", "occurred_at"}, table0.Indices[0].Columns)
+
+	suite.Equal([]psqldef.TablePartition{
+		{Name: "events_2024_12", Bound: "FROM ('2024-12-01') TO ('2025-01-01')"},
+		{Name: "events_2025_01", Bound: "FROM ('2025-01-01') TO ('2025-02-01')"},
+		{Name: "events_default", Bound: ""},
+	}, table0.Partitions)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Partition_ListAndHash() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Event",
+		Fields: map[string]yaml.ModelField{
+			"ID":     {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Region": {Type: yaml.ModelFieldTypeString},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Event", model0)
+
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Event": {
+			Partition: cfg.MorphePartitionOptions{
+				Strategy:  cfg.PartitionStrategyList,
+				KeyFields: []string{"Region"},
+				Partitions: []cfg.MorphePartition{
+					{Name: "europe", Values: []string{"eu-west", "eu-central"}},
+				},
+			},
+		},
+	}
+	listTables, listErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(listErr)
+	suite.Equal([]psqldef.TablePartition{
+		{Name: "events_europe", Bound: "IN ('eu-west', 'eu-central')"},
+	}, listTables[0].Partitions)
+
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Event": {
+			Partition: cfg.MorphePartitionOptions{
+				Strategy:  cfg.PartitionStrategyHash,
+				KeyFields: []string{"ID"},
+				Partitions: []cfg.MorphePartition{
+					{Name: "p0", Modulus: 2, Remainder: 0},
+					{Name: "p1", Modulus: 2, Remainder: 1},
+				},
+			},
+		},
+	}
+	hashTables, hashErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(hashErr)
+	suite.Equal([]psqldef.TablePartition{
+		{Name: "events_p0", Bound: "WITH (MODULUS 2, REMAINDER 0)"},
+		{Name: "events_p1", Bound: "WITH (MODULUS 2, REMAINDER 1)"},
+	}, hashTables[0].Partitions)
+
+	// Hash partitions cannot be default partitions
+	config.MorpheModelsConfig.ModelOptions["Event"].Partition.Partitions[1] = cfg.MorphePartition{Name: "p1", Default: true}
+	invalidTables, invalidErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(invalidErr, cfg.ErrInvalidPartitionBounds)
+	suite.ErrorContains(invalidErr, "model 'Event': partition 'p1'")
+	suite.Nil(invalidTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Partition_ForeignKeyTarget() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Event": {
+			Partition: cfg.MorphePartitionOptions{
+				Strategy:  cfg.PartitionStrategyRange,
+				KeyFields: []string{"OccurredAt"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Event",
+		Fields: map[string]yaml.ModelField{
+			"ID":         {Type: yaml.ModelFieldTypeAutoIncrement},
+			"OccurredAt": {Type: yaml.ModelFieldTypeTime},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Attachment": {Type: "HasMany"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Attachment",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Event": {Type: "ForOne"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Event", model0)
+	r.SetModel("Attachment", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model1)
+
+	suite.ErrorIs(allTablesErr, compile.ErrPartitionedForeignKeyTarget)
+	suite.ErrorContains(allTablesErr, "foreign key 'fk_attachments_event_id' on table 'attachments' references partitioned table 'events'")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Partition_EnumKey() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Event": {
+			Partition: cfg.MorphePartitionOptions{
+				Strategy:  cfg.PartitionStrategyHash,
+				KeyFields: []string{"Nationality"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Event",
+		Fields: map[string]yaml.ModelField{
+			"ID":          {Type: yaml.ModelFieldTypeAutoIncrement},
+			"Nationality": {Type: "Nationality"},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Event", model0)
+	r.SetEnum("Nationality", enum0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	// Enum fields are stored as references to their lookup table
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+	suite.Equal([]string{"nationality_id"}, allTables[0].PartitionColumns)
+
+	config.MorpheEnumsConfig.UseNativeEnums = true
+	nativeTables, nativeErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(nativeErr)
+	suite.Equal([]string{"nationality"}, nativeTables[0].PartitionColumns)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Partition_OneToOne() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"ContactInfo": {
+			Partition: cfg.MorphePartitionOptions{
+				Strategy:  cfg.PartitionStrategyRange,
+				KeyFields: []string{"CreatedAt"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "ContactInfo",
+		Fields: map[string]yaml.ModelField{
+			"ID":        {Type: yaml.ModelFieldTypeAutoIncrement},
+			"CreatedAt": {Type: yaml.ModelFieldTypeTime},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person": {Type: "ForOne"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"ContactInfo": {Type: "HasOne"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("ContactInfo", model0)
+	r.SetModel("Person", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	// A unique index on (person_id, created_at) would allow one contact info per person and timestamp
+	suite.ErrorIs(allTablesErr, compile.ErrPartitionedOneToOneRelation)
+	suite.ErrorContains(allTablesErr, "ContactInfo.Person is one-to-one with Person.ContactInfo")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_KeyTypes() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.UseBigSerial = true
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"UUID": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"UUID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {Type: "ForMany"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {Type: "HasMany"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 2)
+
+	junctionTable := allTables[1]
+	suite.Equal("basic_basic_parents", junctionTable.Name)
+	suite.Equal([]psqldef.TableColumn{
+		{Name: "id", Type: psqldef.PSQLTypeBigSerial, PrimaryKey: true},
+		{Name: "basic_uuid", Type: psqldef.PSQLTypeUUID, NotNull: true},
+		{Name: "basic_parent_id", Type: psqldef.PSQLTypeBigInt, NotNull: true},
+	}, junctionTable.Columns)
+	suite.Len(junctionTable.UniqueConstraints, 1)
+
+	// The foreign key columns can replace the surrogate id as a composite primary key
+	config.MorpheModelsConfig.JunctionCompositeKeys = true
+	compositeTables, compositeErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(compositeErr)
+	compositeJunctionTable := compositeTables[1]
+	suite.Equal([]psqldef.TableColumn{
+		{Name: "basic_uuid", Type: psqldef.PSQLTypeUUID, NotNull: true, PrimaryKey: true},
+		{Name: "basic_parent_id", Type: psqldef.PSQLTypeBigInt, NotNull: true, PrimaryKey: true},
+	}, compositeJunctionTable.Columns)
+	suite.Len(compositeJunctionTable.UniqueConstraints, 0)
+	suite.Len(compositeJunctionTable.ForeignKeys, 2)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_JunctionFields() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Playlist": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Tracks": {
+					Fields: map[string]yaml.ModelField{
+						"Position": {Type: yaml.ModelFieldTypeInteger},
+						"AddedBy":  {Type: yaml.ModelFieldTypeString, Attributes: []string{"optional", "default:owner"}},
+					},
+					OrderField: "Position",
+				},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Playlist",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Tracks": {Type: "ForMany", Aliased: "Track"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Track",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Playlist", model0)
+	r.SetModel("Track", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 2)
+
+	junctionTable := allTables[1]
+	suite.Equal("playlist_tracks", junctionTable.Name)
+	suite.Equal([]psqldef.TableColumn{
+		{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+		{Name: "playlist_id", Type: psqldef.PSQLTypeInteger, NotNull: true},
+		{Name: "tracks_id", Type: psqldef.PSQLTypeInteger, NotNull: true},
+		{Name: "added_by", Type: psqldef.PSQLTypeText, Default: "'owner'"},
+		{Name: "position", Type: psqldef.PSQLTypeInteger, NotNull: true},
+	}, junctionTable.Columns)
+
+	suite.Len(junctionTable.Indices, 3)
+	orderIndex := junctionTable.Indices[2]
+	suite.Equal("idx_playlist_tracks_playlist_id_position", orderIndex.Name)
+	suite.Equal("playlist_tracks", orderIndex.TableName)
+	suite.Equal([]string{"playlist_id", "position"}, orderIndex.Columns)
+	suite.False(orderIndex.IsUnique)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_JunctionFields_Invalid() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Playlist",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Owner":  {Type: "ForOne", Aliased: "Track"},
+			"Tracks": {Type: "ForMany", Aliased: "Track"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Track",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Playlist", model0)
+	r.SetModel("Track", model1)
+
+	// Fields need a junction table
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Playlist": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Owner": {Fields: map[string]yaml.ModelField{"Position": {Type: yaml.ModelFieldTypeInteger}}},
+			},
+		},
+	}
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, compile.ErrJunctionFieldsWithoutJunctionTable)
+	suite.Nil(allTables)
+
+	// Fields cannot shadow the junction table's own columns
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Playlist": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Tracks": {Fields: map[string]yaml.ModelField{"PlaylistID": {Type: yaml.ModelFieldTypeInteger}}},
+			},
+		},
+	}
+	allTables, allTablesErr = compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, compile.ErrJunctionFieldConflict)
+	suite.Nil(allTables)
+
+	// The order field must be one of the fields
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Playlist": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Tracks": {Fields: map[string]yaml.ModelField{"Position": {Type: yaml.ModelFieldTypeInteger}}, OrderField: "Rank"},
+			},
+		},
+	}
+	allTables, allTablesErr = compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, cfg.ErrUnknownOrderField)
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_Reciprocal_JunctionFields() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Project": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Members": {Fields: map[string]yaml.ModelField{"Role": {Type: yaml.ModelFieldTypeString}}},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Projects": {Type: "ForMany", Aliased: "Project"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Project",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Members": {Type: "ForMany", Aliased: "Person"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Project", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model1)
+
+	suite.ErrorIs(allTablesErr, compile.ErrJunctionFieldsOnReciprocalRelation)
+	suite.ErrorContains(allTablesErr, "Project.Members shares the junction table of Person.Projects")
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_Reciprocal_OptionConflict() {
+	config := suite.getCompileConfig()
+	config.MorpheModelsConfig.ModelOptions = map[string]cfg.MorpheModelOptions{
+		"Person": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Projects": {OnDelete: "CASCADE"},
+			},
+		},
+		"Project": {
+			Relations: map[string]cfg.MorpheRelationOptions{
+				"Members": {OnDelete: "RESTRICT", OnUpdate: "CASCADE"},
+			},
+		},
+	}
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Projects": {Type: "ForMany", Aliased: "Project"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Project",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Members": {Type: "ForMany", Aliased: "Person"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Project", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model1)
+
+	suite.ErrorIs(allTablesErr, compile.ErrReciprocalRelationOptionConflict)
+	suite.ErrorContains(allTablesErr, "OnDelete, OnUpdate of Project.Members disagree with Person.Projects")
+	suite.Nil(allTables)
+
+	// Options repeating the owning relation's options are accepted
+	config.MorpheModelsConfig.ModelOptions["Project"] = cfg.MorpheModelOptions{
+		Relations: map[string]cfg.MorpheRelationOptions{
+			"Members": {OnDelete: "CASCADE"},
+		},
+	}
+
+	allTables, allTablesErr = compile.MorpheModelToPSQLTables(config, r, model1)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_HasOne() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "ContactInfo",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Person": {Type: "ForOne"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"ContactInfo": {Type: "HasOne"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("ContactInfo", model0)
+	r.SetModel("Person", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	// A ForOne relationship with a HasOne inverse is one-to-one
+	table0 := allTables[0]
+	suite.Len(table0.Indices, 1)
+	index0 := table0.Indices[0]
+	suite.Equal("idx_contact_infos_person_id", index0.Name)
+	suite.Equal([]string{"person_id"}, index0.Columns)
+	suite.True(index0.IsUnique)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_HasOne_Aliased() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Contact",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"WorkPerson":     {Type: "ForOne", Aliased: "Person"},
+			"PersonalPerson": {Type: "ForOne", Aliased: "Person"},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"ID"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"WorkContact":      {Type: "HasOne", Aliased: "Contact.WorkPerson"},
+			"PersonalContacts": {Type: "HasMany", Aliased: "Contact.PersonalPerson"},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Contact", model0)
+	r.SetModel("Person", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	// Only the relationship paired with the HasOne inverse is one-to-one
+	table0 := allTables[0]
+	suite.Len(table0.Indices, 2)
+	index0 := table0.Indices[0]
+	suite.Equal([]string{"personal_person_id"}, index0.Columns)
+	suite.False(index0.IsUnique)
+
+	index1 := table0.Indices[1]
+	suite.Equal([]string{"work_person_id"}, index1.Columns)
+	suite.True(index1.IsUnique)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOne_Aliased() {
+	config := suite.getCompileConfig()
+
+	// Person model with aliased relationships to Contact model
+	personModel := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Name": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"WorkContact": {
+				Type:    "ForOne",
+				Aliased: "Contact",
+			},
+			"PersonalContact": {
+				Type:    "ForOne",
+				Aliased: "Contact",
+			},
+		},
+	}
+
+	// Contact model that is referenced by aliased relationships
+	contactModel := yaml.Model{
+		Name: "Contact",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Email": {
+				Type: yaml.ModelFieldTypeString,
+			},
+			"Phone": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{"ID"},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"PersonsAsWork": {
+				Type:    "HasMany",
+				Aliased: "Person.WorkContact",
+			},
+			"PersonsAsPersonal": {
+				Type:    "HasMany",
+				Aliased: "Person.PersonalContact",
+			},
+		},
+	}
 
-	columns0 := table0.Columns
-	suite.Len(columns0, 2)
+	r := registry.NewRegistry()
+	r.SetModel("Person", personModel)
+	r.SetModel("Contact", contactModel)
 
-	columns00 := columns0[0]
-	suite.Equal("id", columns00.Name)
-	suite.Equal(psqldef.PSQLTypeSerial, columns00.Type)
-	suite.True(columns00.NotNull)
-	suite.True(columns00.PrimaryKey)
-	suite.Equal("", columns00.Default)
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, personModel)
 
-	columns01 := columns0[1]
-	suite.Equal("string", columns01.Name)
-	suite.Equal(psqldef.PSQLTypeText, columns01.Type)
-	suite.True(columns01.NotNull)
-	suite.False(columns01.PrimaryKey)
-	suite.Equal("", columns01.Default)
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
 
-	suite.Len(table0.ForeignKeys, 0)
-	suite.Len(table0.Indices, 0)
-	suite.Len(table0.UniqueConstraints, 0)
+	table := allTables[0]
 
-	// Junction table basics <-> basic_parents
-	table1 := allTables[1]
+	// Check table basics
+	suite.Equal(config.MorpheConfig.MorpheModelsConfig.Schema, table.Schema)
+	suite.Equal("people", table.Name)
+	suite.Len(table.Columns, 4) // id, name, work_contact_id, personal_contact_id
 
-	suite.Equal(config.MorpheConfig.MorpheModelsConfig.Schema, table1.Schema)
-	suite.Equal("basic_basic_parents", table1.Name)
+	// Check columns - they should use relationship names, not aliased target
+	suite.Equal("id", table.Columns[0].Name)
+	suite.Equal("name", table.Columns[1].Name)
+	// PersonalContact comes before WorkContact alphabetically
+	suite.Equal("personal_contact_id", table.Columns[2].Name)
+	suite.Equal(psqldef.PSQLTypeInteger, table.Columns[2].Type)
+	suite.Equal("work_contact_id", table.Columns[3].Name)
+	suite.Equal(psqldef.PSQLTypeInteger, table.Columns[3].Type)
 
-	columns1 := table1.Columns
-	suite.Len(columns1, 3)
+	// Check foreign keys - they should reference the correct target table
+	suite.Len(table.ForeignKeys, 2)
 
-	columns10 := columns1[0]
-	suite.Equal("id", columns10.Name)
-	suite.Equal(psqldef.PSQLTypeSerial, columns10.Type)
-	suite.False(columns10.NotNull)
-	suite.True(columns10.PrimaryKey)
-	suite.Equal("", columns10.Default)
+	// Personal contact foreign key (comes first alphabetically)
+	suite.Equal("fk_people_personal_contact_id", table.ForeignKeys[0].Name)
+	suite.Equal("people", table.ForeignKeys[0].TableName)
+	suite.Equal([]string{"personal_contact_id"}, table.ForeignKeys[0].ColumnNames)
+	suite.Equal("contacts", table.ForeignKeys[0].RefTableName) // References Contact table
+	suite.Equal([]string{"id"}, table.ForeignKeys[0].RefColumnNames)
 
-	columns11 := columns1[1]
-	suite.Equal("basic_id", columns11.Name)
-	suite.Equal(psqldef.PSQLTypeInteger, columns11.Type)
-	suite.False(columns11.NotNull)
-	suite.False(columns11.PrimaryKey)
-	suite.Equal("", columns11.Default)
+	// Work contact foreign key
+	suite.Equal("fk_people_work_contact_id", table.ForeignKeys[1].Name)
+	suite.Equal("people", table.ForeignKeys[1].TableName)
+	suite.Equal([]string{"work_contact_id"}, table.ForeignKeys[1].ColumnNames)
+	suite.Equal("contacts", table.ForeignKeys[1].RefTableName) // References Contact table
+	suite.Equal([]string{"id"}, table.ForeignKeys[1].RefColumnNames)
 
-	columns12 := columns1[2]
-	suite.Equal("basic_parent_id", columns12.Name)
-	suite.Equal(psqldef.PSQLTypeInteger, columns12.Type)
-	suite.False(columns12.NotNull)
-	suite.False(columns12.PrimaryKey)
-	suite.Equal("", columns12.Default)
+	// Check indices
+	suite.Len(table.Indices, 2)
+	suite.Equal("idx_people_personal_contact_id", table.Indices[0].Name)
+	suite.Equal("idx_people_work_contact_id", table.Indices[1].Name)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_HasOne() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"String": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {
+				Type: "ForMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"String": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {
+				Type: "HasOne",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.ErrorIs(allTablesErr, compile.ErrRelationCardinalityMismatch)
+	suite.ErrorContains(allTablesErr, "Basic.BasicParent is ForMany but its inverse BasicParent.Basic is HasOne")
+	suite.Nil(allTables)
+
+	allTables, allTablesErr = compile.MorpheModelToPSQLTables(config, r, model1)
+
+	suite.ErrorIs(allTablesErr, compile.ErrRelationCardinalityMismatch)
+	suite.Nil(allTables)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_Reciprocal() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Projects": {
+				Type:    "ForMany",
+				Aliased: "Project",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Project",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Members": {
+				Type:    "ForMany",
+				Aliased: "Person",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Project", model1)
+
+	// The model sorting first owns the junction table
+	allTables0, allTablesErr0 := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr0)
+	suite.Len(allTables0, 2)
+	suite.Equal("people", allTables0[0].Name)
+
+	table1 := allTables0[1]
+	suite.Equal("person_projects", table1.Name)
+	suite.Len(table1.Columns, 3)
+	suite.Equal("id", table1.Columns[0].Name)
+	suite.Equal("person_id", table1.Columns[1].Name)
+	suite.Equal("projects_id", table1.Columns[2].Name)
 
 	suite.Len(table1.ForeignKeys, 2)
-	foreignKey10 := table1.ForeignKeys[0]
-	suite.Equal("public", foreignKey10.Schema)
-	suite.Equal("fk_basic_basic_parents_basic_id", foreignKey10.Name)
-	suite.Equal("basic_basic_parents", foreignKey10.TableName)
-	suite.Len(foreignKey10.ColumnNames, 1)
-	fkColumn10 := foreignKey10.ColumnNames[0]
-	suite.Equal("basic_id", fkColumn10)
-	suite.Equal("public", foreignKey10.RefSchema)
-	suite.Equal("basics", foreignKey10.RefTableName)
-	suite.Len(foreignKey10.RefColumnNames, 1)
-	fkColumnRef10 := foreignKey10.RefColumnNames[0]
-	suite.Equal("id", fkColumnRef10)
-	suite.Equal("CASCADE", foreignKey10.OnDelete)
-	suite.Equal("", foreignKey10.OnUpdate)
+	suite.Equal("people", table1.ForeignKeys[0].RefTableName)
+	suite.Equal("projects", table1.ForeignKeys[1].RefTableName)
+
+	// The reciprocal relationship reuses it rather than creating its own
+	allTables1, allTablesErr1 := compile.MorpheModelToPSQLTables(config, r, model1)
+
+	suite.Nil(allTablesErr1)
+	suite.Len(allTables1, 1)
+	suite.Equal("projects", allTables1[0].Name)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_Reciprocal_Ambiguous() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Person",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"WorkProjects": {
+				Type:    "ForMany",
+				Aliased: "Project",
+			},
+			"PersonalProjects": {
+				Type:    "ForMany",
+				Aliased: "Project",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "Project",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Members": {
+				Type:    "ForMany",
+				Aliased: "Person",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Person", model0)
+	r.SetModel("Project", model1)
 
-	foreignKey11 := table1.ForeignKeys[1]
-	suite.Equal("public", foreignKey11.Schema)
-	suite.Equal("fk_basic_basic_parents_basic_parent_id", foreignKey11.Name)
-	suite.Equal("basic_basic_parents", foreignKey11.TableName)
-	suite.Len(foreignKey11.ColumnNames, 1)
-	fkColumn11 := foreignKey11.ColumnNames[0]
-	suite.Equal("basic_parent_id", fkColumn11)
-	suite.Equal("public", foreignKey11.RefSchema)
-	suite.Equal("basic_parents", foreignKey11.RefTableName)
-	suite.Len(foreignKey11.RefColumnNames, 1)
-	fkColumnRef11 := foreignKey11.RefColumnNames[0]
-	suite.Equal("id", fkColumnRef11)
-	suite.Equal("CASCADE", foreignKey11.OnDelete)
-	suite.Equal("", foreignKey11.OnUpdate)
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
 
-	suite.Len(table1.Indices, 2)
-	index10 := table1.Indices[0]
-	suite.Equal("idx_basic_basic_parents_basic_id", index10.Name)
-	suite.Equal("basic_basic_parents", index10.TableName)
-	suite.Len(index10.Columns, 1)
-	suite.Equal("basic_id", index10.Columns[0])
-	suite.False(index10.IsUnique)
+	suite.ErrorIs(allTablesErr, compile.ErrAmbiguousReciprocalRelation)
+	suite.ErrorContains(allTablesErr, "model Person declares ForMany relationships (PersonalProjects, WorkProjects) to model Project, which declares ForMany relationships (Members) back")
+	suite.Nil(allTables)
 
-	index11 := table1.Indices[1]
-	suite.Equal("idx_basic_basic_parents_basic_parent_id", index11.Name)
-	suite.Equal("basic_basic_parents", index11.TableName)
-	suite.Len(index11.Columns, 1)
-	suite.Equal("basic_parent_id", index11.Columns[0])
-	suite.False(index11.IsUnique)
+	allTables, allTablesErr = compile.MorpheModelToPSQLTables(config, r, model1)
 
-	suite.Len(table1.UniqueConstraints, 1)
-	uniqueConstraint10 := table1.UniqueConstraints[0]
-	suite.Equal("uk_basic_basic_parents_basic_id_basic_parent_id", uniqueConstraint10.Name)
-	suite.Equal("basic_basic_parents", uniqueConstraint10.TableName)
-	suite.Len(uniqueConstraint10.ColumnNames, 2)
-	suite.Equal("basic_id", uniqueConstraint10.ColumnNames[0])
-	suite.Equal("basic_parent_id", uniqueConstraint10.ColumnNames[1])
+	suite.ErrorIs(allTablesErr, compile.ErrAmbiguousReciprocalRelation)
+	suite.Nil(allTables)
 }
 
 func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_HasMany() {
@@ -882,14 +2595,14 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 	columns11 := columns1[1]
 	suite.Equal("basic_id", columns11.Name)
 	suite.Equal(psqldef.PSQLTypeInteger, columns11.Type)
-	suite.False(columns11.NotNull)
+	suite.True(columns11.NotNull)
 	suite.False(columns11.PrimaryKey)
 	suite.Equal("", columns11.Default)
 
 	columns12 := columns1[2]
 	suite.Equal("basic_parent_id", columns12.Name)
 	suite.Equal(psqldef.PSQLTypeInteger, columns12.Type)
-	suite.False(columns12.NotNull)
+	suite.True(columns12.NotNull)
 	suite.False(columns12.PrimaryKey)
 	suite.Equal("", columns12.Default)
 
@@ -948,6 +2661,94 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 	suite.Equal("basic_parent_id", uniqueConstraint10.ColumnNames[1])
 }
 
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_CompositePrimary() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"BasicParent": {
+				Type: "ForMany",
+			},
+		},
+	}
+	model1 := yaml.Model{
+		Name: "BasicParent",
+		Fields: map[string]yaml.ModelField{
+			"TenantID": {
+				Type: yaml.ModelFieldTypeInteger,
+			},
+			"Code": {
+				Type: yaml.ModelFieldTypeString,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"TenantID",
+					"Code",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Basic": {
+				Type: "HasMany",
+			},
+		},
+	}
+	r := registry.NewRegistry()
+	r.SetModel("Basic", model0)
+	r.SetModel("BasicParent", model1)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 2)
+
+	junctionTable := allTables[1]
+	suite.Equal("basic_basic_parents", junctionTable.Name)
+
+	columns := junctionTable.Columns
+	suite.Len(columns, 4)
+	suite.Equal("id", columns[0].Name)
+	suite.True(columns[0].PrimaryKey)
+	suite.Equal("basic_id", columns[1].Name)
+	suite.Equal("basic_parent_tenant_id", columns[2].Name)
+	suite.Equal("basic_parent_code", columns[3].Name)
+
+	suite.Len(junctionTable.ForeignKeys, 2)
+
+	foreignKey0 := junctionTable.ForeignKeys[0]
+	suite.Equal([]string{"basic_id"}, foreignKey0.ColumnNames)
+	suite.Equal("basics", foreignKey0.RefTableName)
+	suite.Equal([]string{"id"}, foreignKey0.RefColumnNames)
+
+	foreignKey1 := junctionTable.ForeignKeys[1]
+	suite.Equal("fk_basic_basic_parents_basic_parent_tenant_id_code", foreignKey1.Name)
+	suite.Equal([]string{"basic_parent_tenant_id", "basic_parent_code"}, foreignKey1.ColumnNames)
+	suite.Equal("basic_parents", foreignKey1.RefTableName)
+	suite.Equal([]string{"tenant_id", "code"}, foreignKey1.RefColumnNames)
+
+	suite.Len(junctionTable.UniqueConstraints, 1)
+	suite.Equal([]string{"basic_id", "basic_parent_tenant_id", "basic_parent_code"}, junctionTable.UniqueConstraints[0].ColumnNames)
+
+	suite.Len(junctionTable.Indices, 2)
+	suite.Equal([]string{"basic_id"}, junctionTable.Indices[0].Columns)
+	suite.Equal([]string{"basic_parent_tenant_id", "basic_parent_code"}, junctionTable.Indices[1].Columns)
+}
+
 func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany_Aliased() {
 	config := suite.getCompileConfig()
 
@@ -1784,6 +3585,123 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_EnumField() {
 	suite.Equal(foreignKey0.RefColumnNames, []string{"id"})
 }
 
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_EnumField_Optional() {
+	config := suite.getCompileConfig()
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"ID": {
+				Type: yaml.ModelFieldTypeAutoIncrement,
+			},
+			"Nationality": {
+				Type: "Nationality",
+				Attributes: []string{
+					"optional",
+				},
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"ID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetEnum("Nationality", enum0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	columns0 := allTables[0].Columns
+	suite.Len(columns0, 2)
+
+	column01 := columns0[1]
+	suite.Equal(column01.Name, "nationality_id")
+	suite.False(column01.NotNull)
+
+	config.MorpheEnumsConfig.UseNativeEnums = true
+	nativeTables, nativeTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(nativeTablesErr)
+	suite.Len(nativeTables, 1)
+
+	nativeColumn01 := nativeTables[0].Columns[1]
+	suite.Equal(nativeColumn01.Name, "nationality")
+	suite.False(nativeColumn01.NotNull)
+}
+
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_EnumField_NativeEnums() {
+	config := suite.getCompileConfig()
+	config.MorpheEnumsConfig.UseNativeEnums = true
+
+	model0 := yaml.Model{
+		Name: "Basic",
+		Fields: map[string]yaml.ModelField{
+			"Nationality": {
+				Type: "Nationality",
+			},
+			"UUID": {
+				Type: yaml.ModelFieldTypeUUID,
+			},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {
+				Fields: []string{
+					"UUID",
+				},
+			},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	enum0 := yaml.Enum{
+		Name: "Nationality",
+		Type: yaml.EnumTypeString,
+		Entries: map[string]any{
+			"US": "American",
+			"DE": "German",
+			"FR": "French",
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetEnum("Nationality", enum0)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, model0)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	table0 := allTables[0]
+	suite.Len(table0.Columns, 2)
+
+	column00 := table0.Columns[0]
+	suite.Equal("nationality", column00.Name)
+	suite.Equal(psqldef.PSQLTypeEnum{
+		Values: []string{"DE", "FR", "US"},
+		Schema: "public",
+		Name:   "nationality",
+	}, column00.Type)
+	suite.True(column00.NotNull)
+
+	suite.Len(table0.ForeignKeys, 0)
+}
+
 func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOnePoly() {
 	config := suite.getCompileConfig()
 
@@ -1850,13 +3768,72 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOneP
 	suite.False(table.Columns[2].PrimaryKey)
 
 	suite.Equal("commentable_id", table.Columns[3].Name)
-	suite.Equal(psqldef.PSQLTypeText, table.Columns[3].Type)
+	suite.Equal(psqldef.PSQLTypeUUID, table.Columns[3].Type)
 	suite.True(table.Columns[3].NotNull)
 	suite.False(table.Columns[3].PrimaryKey)
 
 	suite.Len(table.ForeignKeys, 0)
 }
 
+func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOnePoly_MixedPrimaryTypes() {
+	config := suite.getCompileConfig()
+
+	postModel := yaml.Model{
+		Name: "Post",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	articleModel := yaml.Model{
+		Name: "Article",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeAutoIncrement},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{},
+	}
+
+	commentModel := yaml.Model{
+		Name: "Comment",
+		Fields: map[string]yaml.ModelField{
+			"id": {Type: yaml.ModelFieldTypeUUID},
+		},
+		Identifiers: map[string]yaml.ModelIdentifier{
+			"primary": {Fields: []string{"id"}},
+		},
+		Related: map[string]yaml.ModelRelation{
+			"Commentable": {
+				Type: "ForOnePoly",
+				For:  []string{"Post", "Article"},
+			},
+		},
+	}
+
+	r := registry.NewRegistry()
+	r.SetModel("Post", postModel)
+	r.SetModel("Article", articleModel)
+	r.SetModel("Comment", commentModel)
+
+	allTables, allTablesErr := compile.MorpheModelToPSQLTables(config, r, commentModel)
+
+	suite.Nil(allTablesErr)
+	suite.Len(allTables, 1)
+
+	table := allTables[0]
+	suite.Len(table.Columns, 3)
+
+	// The targets' primary keys map to different types, so the id is stored as text
+	suite.Equal("commentable_id", table.Columns[2].Name)
+	suite.Equal(psqldef.PSQLTypeText, table.Columns[2].Type)
+}
+
 func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOnePoly_LongRelationName() {
 	config := suite.getCompileConfig()
 
@@ -1923,7 +3900,7 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOneP
 	suite.False(table.Columns[2].PrimaryKey)
 
 	suite.Equal("auditable_resource_id", table.Columns[3].Name)
-	suite.Equal(psqldef.PSQLTypeText, table.Columns[3].Type)
+	suite.Equal(psqldef.PSQLTypeUUID, table.Columns[3].Type)
 	suite.True(table.Columns[3].NotNull)
 	suite.False(table.Columns[3].PrimaryKey)
 
@@ -2063,21 +4040,21 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 	columns11 := columns1[1]
 	suite.Equal("tag_id", columns11.Name)
 	suite.Equal(psqldef.PSQLTypeInteger, columns11.Type)
-	suite.False(columns11.NotNull)
+	suite.True(columns11.NotNull)
 	suite.False(columns11.PrimaryKey)
 	suite.Equal("", columns11.Default)
 
 	columns12 := columns1[2]
 	suite.Equal("taggable_type", columns12.Name)
 	suite.Equal(psqldef.PSQLTypeText, columns12.Type)
-	suite.False(columns12.NotNull)
+	suite.True(columns12.NotNull)
 	suite.False(columns12.PrimaryKey)
 	suite.Equal("", columns12.Default)
 
 	columns13 := columns1[3]
 	suite.Equal("taggable_id", columns13.Name)
-	suite.Equal(psqldef.PSQLTypeText, columns13.Type)
-	suite.False(columns13.NotNull)
+	suite.Equal(psqldef.PSQLTypeInteger, columns13.Type)
+	suite.True(columns13.NotNull)
 	suite.False(columns13.PrimaryKey)
 	suite.Equal("", columns13.Default)
 
@@ -2619,7 +4596,7 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForOneP
 	suite.True(commentTable.Columns[2].NotNull)
 
 	suite.Equal("commentable_resource_id", commentTable.Columns[3].Name)
-	suite.Equal(psqldef.PSQLTypeText, commentTable.Columns[3].Type)
+	suite.Equal(psqldef.PSQLTypeInteger, commentTable.Columns[3].Type)
 	suite.True(commentTable.Columns[3].NotNull)
 
 	// No foreign keys for polymorphic columns
@@ -2754,7 +4731,7 @@ func (suite *CompileModelsTestSuite) TestMorpheModelToPSQLTables_Related_ForMany
 	suite.NotNil(typeCol)
 	suite.Equal(psqldef.PSQLTypeText, typeCol.Type)
 	suite.NotNil(idCol)
-	suite.Equal(psqldef.PSQLTypeText, idCol.Type)
+	suite.Equal(psqldef.PSQLTypeInteger, idCol.Type)
 
 	// Only one foreign key for the source model
 	suite.Len(junctionTable.ForeignKeys, 1)
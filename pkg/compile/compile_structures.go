@@ -36,6 +36,11 @@ func WriteStructureTableDefinition(hooks hook.WritePSQLTable, writer write.PSQLT
 	return WriteModelTableDefinition(hooks, writer, structureTable)
 }
 
+// WriteStructureTableDefinitionWithOrder writes the structure table definition with an order prefix
+func WriteStructureTableDefinitionWithOrder(hooks hook.WritePSQLTable, writer write.PSQLTableWriter, structureTable *psqldef.Table, order int) (*psqldef.Table, []byte, error) {
+	return WriteModelTableDefinitionWithOrder(hooks, writer, structureTable, order)
+}
+
 // createStandardStructureTable creates the standard structure table
 func createStandardStructureTable(config cfg.MorpheStructuresConfig) *psqldef.Table {
 	idType := psqldef.PSQLTypeSerial
@@ -3,6 +3,7 @@ package compile_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -12,6 +13,9 @@ import (
 	"github.com/kalo-build/plugin-morphe-psql-types/internal/testutils"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/hook"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 )
 
 type CompileTestSuite struct {
@@ -182,3 +186,181 @@ func (suite *CompileTestSuite) TestMorpheToPSQL() {
 	suite.FileExists(entityPath3)
 	suite.FileEquals(entityPath3, gtEntityPath3)
 }
+
+func (suite *CompileTestSuite) TestMorpheToPSQL_Rollbacks() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	gtRollbacksDirPath := filepath.Join(suite.TestDirPath, "ground-truth", "compile-minimal-rollbacks")
+
+	config := compile.DefaultMorpheCompileConfig(filepath.Join(suite.TestDirPath, "registry", "minimal"), workingDirPath)
+	config.EnableOrderedMigrations = true
+	config.SnapshotWriter = nil
+	config.EnumWriter = &compile.MorpheTableFileWriter{
+		Type:            compile.MorpheTableTypeEnums,
+		TargetDirPath:   workingDirPath + "/enums",
+		EnableRollbacks: true,
+	}
+	config.ModelWriter = &compile.MorpheTableFileWriter{
+		Type:            compile.MorpheTableTypeModels,
+		TargetDirPath:   workingDirPath + "/models",
+		EnableRollbacks: true,
+	}
+	config.EntityWriter = &compile.MorpheViewFileWriter{
+		TargetDirPath:   workingDirPath + "/entities",
+		EnableRollbacks: true,
+	}
+
+	compileErr := compile.MorpheToPSQL(config)
+
+	suite.NoError(compileErr)
+
+	enumUpPath0 := workingDirPath + "/enums/001_nationalities.up.sql"
+	suite.FileExists(enumUpPath0)
+	suite.FileEquals(enumUpPath0, suite.TestGroundTruthDirPath+"/enums/nationalities.sql")
+
+	enumDownPath0 := workingDirPath + "/enums/001_nationalities.down.sql"
+	suite.FileExists(enumDownPath0)
+	suite.FileEquals(enumDownPath0, gtRollbacksDirPath+"/enums/001_nationalities.down.sql")
+
+	modelUpPath0 := workingDirPath + "/models/006_people.up.sql"
+	suite.FileExists(modelUpPath0)
+	suite.FileEquals(modelUpPath0, suite.TestGroundTruthDirPath+"/models/people.sql")
+
+	modelDownPath0 := workingDirPath + "/models/006_people.down.sql"
+	suite.FileExists(modelDownPath0)
+	suite.FileEquals(modelDownPath0, gtRollbacksDirPath+"/models/006_people.down.sql")
+
+	entityDownPath0 := workingDirPath + "/entities/012_person_entities.down.sql"
+	suite.FileExists(entityDownPath0)
+	suite.FileEquals(entityDownPath0, gtRollbacksDirPath+"/entities/012_person_entities.down.sql")
+}
+
+func (suite *CompileTestSuite) TestMorpheToPSQL_PolymorphicTriggers() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	gtPolymorphicTriggersDirPath := filepath.Join(suite.TestDirPath, "ground-truth", "compile-minimal-polymorphic-triggers")
+
+	config := compile.DefaultMorpheCompileConfig(filepath.Join(suite.TestDirPath, "registry", "minimal"), workingDirPath)
+	config.SnapshotWriter = nil
+	config.MorpheModelsConfig.EnablePolymorphicTriggers = true
+
+	compileErr := compile.MorpheToPSQL(config)
+
+	suite.NoError(compileErr)
+
+	modelPath0 := workingDirPath + "/models/comments.sql"
+	suite.FileExists(modelPath0)
+	suite.FileEquals(modelPath0, gtPolymorphicTriggersDirPath+"/models/comments.sql")
+
+	modelPath1 := workingDirPath + "/models/tag_taggables.sql"
+	suite.FileExists(modelPath1)
+	suite.FileEquals(modelPath1, gtPolymorphicTriggersDirPath+"/models/tag_taggables.sql")
+
+	// Cleanup triggers fire on the target tables, so they are written once all tables exist
+	triggersPath := workingDirPath + "/models/cross_table_triggers.sql"
+	suite.FileExists(triggersPath)
+	suite.FileEquals(triggersPath, gtPolymorphicTriggersDirPath+"/models/cross_table_triggers.sql")
+
+	// Tables without polymorphic relations are unchanged
+	modelPath2 := workingDirPath + "/models/people.sql"
+	suite.FileExists(modelPath2)
+	suite.FileEquals(modelPath2, suite.TestGroundTruthDirPath+"/models/people.sql")
+}
+
+func (suite *CompileTestSuite) TestMorpheToPSQL_OrderedMigrations() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	config := compile.DefaultMorpheCompileConfig(filepath.Join(suite.TestDirPath, "registry", "minimal"), workingDirPath)
+	config.EnableOrderedMigrations = true
+	config.SnapshotWriter = nil
+
+	compileErr := compile.MorpheToPSQL(config)
+
+	suite.NoError(compileErr)
+
+	// Enums, models, structures and entities share one global ordering
+	suite.FileExists(workingDirPath + "/enums/001_nationalities.sql")
+	suite.FileExists(workingDirPath + "/enums/002_universal_numbers.sql")
+	suite.FileExists(workingDirPath + "/models/003_comments.sql")
+	suite.FileExists(workingDirPath + "/models/006_people.sql")
+	suite.FileExists(workingDirPath + "/models/008_contact_infos.sql")
+	suite.FileExists(workingDirPath + "/structures/009_morphe_structures.sql")
+	suite.FileExists(workingDirPath + "/entities/010_comment_entities.sql")
+	suite.FileExists(workingDirPath + "/entities/011_company_entities.sql")
+	suite.FileExists(workingDirPath + "/entities/012_person_entities.sql")
+	suite.FileExists(workingDirPath + "/entities/013_tag_entities.sql")
+
+	suite.FileEquals(workingDirPath+"/structures/009_morphe_structures.sql", suite.TestGroundTruthDirPath+"/structures/morphe_structures.sql")
+	suite.FileEquals(workingDirPath+"/entities/012_person_entities.sql", suite.TestGroundTruthDirPath+"/entities/person_entities.sql")
+}
+
+func (suite *CompileTestSuite) TestMorpheToPSQL_ConsolidatedSchema() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	config := compile.DefaultMorpheCompileConfig(filepath.Join(suite.TestDirPath, "registry", "minimal"), workingDirPath)
+	config.SnapshotWriter = nil
+	config.SchemaWriter = &compile.MorpheSchemaFileWriter{
+		TargetFilePath: filepath.Join(workingDirPath, compile.SchemaFileName),
+	}
+
+	compileErr := compile.MorpheToPSQL(config)
+
+	suite.NoError(compileErr)
+
+	schemaPath := filepath.Join(workingDirPath, compile.SchemaFileName)
+	gtSchemaPath := filepath.Join(suite.TestDirPath, "ground-truth", "compile-minimal-schema", compile.SchemaFileName)
+	suite.FileExists(schemaPath)
+	suite.FileEquals(schemaPath, gtSchemaPath)
+
+	suite.NoDirExists(filepath.Join(workingDirPath, "models"))
+	suite.NoDirExists(filepath.Join(workingDirPath, "entities"))
+}
+
+func (suite *CompileTestSuite) TestMorpheToPSQL_ConsolidatedSchema_WriteHooks() {
+	workingDirPath := suite.TestDirPath + "/working"
+	suite.Nil(os.Mkdir(workingDirPath, 0644))
+	defer os.RemoveAll(workingDirPath)
+
+	config := compile.DefaultMorpheCompileConfig(filepath.Join(suite.TestDirPath, "registry", "minimal"), workingDirPath)
+	config.SnapshotWriter = nil
+	config.SchemaWriter = &compile.MorpheSchemaFileWriter{
+		TargetFilePath: filepath.Join(workingDirPath, compile.SchemaFileName),
+	}
+
+	writtenTableNames := []string{}
+	config.WriteTableHooks = hook.WritePSQLTable{
+		OnWritePSQLTableStart: func(writer write.PSQLTableWriter, table *psqldef.Table) (write.PSQLTableWriter, *psqldef.Table, error) {
+			if table.Name == "companies" {
+				table.Name = "hooked_companies"
+			}
+			return writer, table, nil
+		},
+		OnWritePSQLTableSuccess: func(table *psqldef.Table, tableContents []byte) (*psqldef.Table, []byte, error) {
+			writtenTableNames = append(writtenTableNames, table.Name)
+			suite.True(strings.Contains(string(tableContents), "CREATE TABLE IF NOT EXISTS public.hooked_companies"))
+			return table, tableContents, nil
+		},
+	}
+	writtenViewNames := []string{}
+	config.WriteViewHooks = hook.WritePSQLView{
+		OnWritePSQLViewSuccess: func(view *psqldef.View, viewContents []byte) (*psqldef.View, []byte, error) {
+			writtenViewNames = append(writtenViewNames, view.Name)
+			return view, viewContents, nil
+		},
+	}
+
+	compileErr := compile.MorpheToPSQL(config)
+
+	suite.NoError(compileErr)
+	suite.Contains(writtenTableNames, "hooked_companies")
+	suite.NotContains(writtenTableNames, "companies")
+	suite.NotEmpty(writtenViewNames)
+}
@@ -0,0 +1,8 @@
+package compile
+
+import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+
+type CompiledEnumType struct {
+	EnumType         *psqldef.PSQLTypeEnum
+	EnumTypeContents []byte
+}
@@ -1,6 +1,9 @@
 package compile
 
-import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+import (
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
 
 // CompiledMorpheTables maps Morphe.Name -> MorpheTable.Name -> CompiledTable
 type CompiledMorpheTables map[string]map[string]CompiledTable
@@ -34,3 +37,15 @@ func (tables CompiledMorpheTables) GetCompiledMorpheTable(morpheName string, tab
 	}
 	return compiledTable
 }
+
+// GetAllTables returns all compiled tables sorted by Morphe name and table name
+func (tables CompiledMorpheTables) GetAllTables() []*psqldef.Table {
+	allTables := []*psqldef.Table{}
+	for _, morpheName := range core.MapKeysSorted(tables) {
+		morpheTables := tables[morpheName]
+		for _, tableName := range core.MapKeysSorted(morpheTables) {
+			allTables = append(allTables, morpheTables[tableName].Table)
+		}
+	}
+	return allTables
+}
@@ -1,6 +1,9 @@
 package compile
 
-import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+import (
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
 
 // CompiledMorpheViews maps Morphe.Name -> MorpheView.Name -> CompiledView
 type CompiledMorpheViews map[string]map[string]CompiledView
@@ -34,3 +37,15 @@ func (views CompiledMorpheViews) GetCompiledMorpheView(morpheName string, viewNa
 	}
 	return compiledView
 }
+
+// GetAllViews returns all compiled views sorted by Morphe name and view name
+func (views CompiledMorpheViews) GetAllViews() []*psqldef.View {
+	allViews := []*psqldef.View{}
+	for _, morpheName := range core.MapKeysSorted(views) {
+		morpheViews := views[morpheName]
+		for _, viewName := range core.MapKeysSorted(morpheViews) {
+			allViews = append(allViews, morpheViews[viewName].View)
+		}
+	}
+	return allViews
+}
@@ -2,14 +2,15 @@ package compile
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 )
 
-// TableDependencyGraph represents the dependency relationships between tables
+// TableDependencyGraph represents the dependency relationships between tables and the views selecting from them
 type TableDependencyGraph struct {
-	// tableDeps maps table name -> list of tables it depends on (via FK)
+	// tableDeps maps table or view name -> list of tables it depends on (via FK, FROM or JOIN)
 	tableDeps map[string][]string
 	// allTables is the set of all known table names
 	allTables map[string]bool
@@ -23,7 +24,8 @@ func NewTableDependencyGraph() *TableDependencyGraph {
 	}
 }
 
-// AddTable adds a table and its FK dependencies to the graph
+// AddTable adds a table and its FK dependencies to the graph. Triggers a table creates on other tables are no
+// dependencies, as they are written after all tables.
 func (g *TableDependencyGraph) AddTable(table *psqldef.Table) {
 	tableName := table.Name
 	g.allTables[tableName] = true
@@ -39,6 +41,25 @@ func (g *TableDependencyGraph) AddTable(table *psqldef.Table) {
 	g.tableDeps[tableName] = deps
 }
 
+// AddView adds a view and its dependencies on the tables it selects from and joins to the graph
+func (g *TableDependencyGraph) AddView(view *psqldef.View) {
+	viewName := view.Name
+	g.allTables[viewName] = true
+
+	deps := []string{}
+	if view.FromTable != "" && view.FromTable != viewName {
+		deps = append(deps, view.FromTable)
+		g.allTables[view.FromTable] = true
+	}
+	for _, join := range view.Joins {
+		if join.Table != viewName && !slices.Contains(deps, join.Table) {
+			deps = append(deps, join.Table)
+			g.allTables[join.Table] = true
+		}
+	}
+	g.tableDeps[viewName] = deps
+}
+
 // TopologicalSort returns tables sorted so that dependencies come before dependents
 // Returns an error if there's a circular dependency
 func (g *TableDependencyGraph) TopologicalSort() ([]string, error) {
@@ -123,3 +144,35 @@ func SortTablesByDependency(tables []*psqldef.Table) ([]*psqldef.Table, error) {
 
 	return sortedTables, nil
 }
+
+// SortSchemaDefinitionsByDependency sorts tables and views together so that dependencies come before dependents
+func SortSchemaDefinitionsByDependency(tables []*psqldef.Table, views []*psqldef.View) ([]psqldef.SchemaDefinition, error) {
+	graph := NewTableDependencyGraph()
+	definitionMap := make(map[string]psqldef.SchemaDefinition)
+
+	for _, table := range tables {
+		graph.AddTable(table)
+		definitionMap[table.Name] = psqldef.SchemaDefinition{Table: table}
+	}
+	for _, view := range views {
+		if _, exists := definitionMap[view.Name]; exists {
+			return nil, fmt.Errorf("view '%s' has the same name as another schema definition", view.Name)
+		}
+		graph.AddView(view)
+		definitionMap[view.Name] = psqldef.SchemaDefinition{View: view}
+	}
+
+	sortedNames, err := graph.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+
+	sortedDefinitions := make([]psqldef.SchemaDefinition, 0, len(sortedNames))
+	for _, name := range sortedNames {
+		if definition, exists := definitionMap[name]; exists {
+			sortedDefinitions = append(sortedDefinitions, definition)
+		}
+	}
+
+	return sortedDefinitions, nil
+}
@@ -0,0 +1,97 @@
+package compile_test
+
+import (
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/stretchr/testify/suite"
+)
+
+type DependencySortTestSuite struct {
+	suite.Suite
+}
+
+func TestDependencySortTestSuite(t *testing.T) {
+	suite.Run(t, new(DependencySortTestSuite))
+}
+
+func (suite *DependencySortTestSuite) TestSortSchemaDefinitionsByDependency() {
+	nationalities := &psqldef.Table{Schema: "public", Name: "nationalities"}
+	people := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		ForeignKeys: []psqldef.ForeignKey{
+			{ColumnNames: []string{"nationality_id"}, RefSchema: "public", RefTableName: "nationalities", RefColumnNames: []string{"id"}},
+			{ColumnNames: []string{"company_id"}, RefSchema: "public", RefTableName: "companies", RefColumnNames: []string{"id"}},
+		},
+	}
+	companies := &psqldef.Table{Schema: "public", Name: "companies"}
+	contactInfos := &psqldef.Table{
+		Schema: "public",
+		Name:   "contact_infos",
+		ForeignKeys: []psqldef.ForeignKey{
+			{ColumnNames: []string{"person_id"}, RefSchema: "public", RefTableName: "people", RefColumnNames: []string{"id"}},
+		},
+	}
+	personEntities := &psqldef.View{
+		Schema:    "public",
+		Name:      "a_person_entities",
+		FromTable: "people",
+		Joins: []psqldef.JoinClause{
+			{Type: "LEFT", Schema: "public", Table: "contact_infos"},
+		},
+	}
+
+	sortedDefinitions, sortErr := compile.SortSchemaDefinitionsByDependency(
+		[]*psqldef.Table{people, contactInfos, nationalities, companies},
+		[]*psqldef.View{personEntities},
+	)
+
+	suite.Nil(sortErr)
+	suite.Len(sortedDefinitions, 5)
+
+	sortedNames := []string{}
+	for _, definition := range sortedDefinitions {
+		sortedNames = append(sortedNames, definition.GetName())
+	}
+	suite.Equal([]string{"companies", "nationalities", "people", "contact_infos", "a_person_entities"}, sortedNames)
+	suite.NotNil(sortedDefinitions[4].View)
+	suite.Nil(sortedDefinitions[4].Table)
+}
+
+func (suite *DependencySortTestSuite) TestSortSchemaDefinitionsByDependency_NameCollision() {
+	people := &psqldef.Table{Schema: "public", Name: "people"}
+	peopleView := &psqldef.View{Schema: "public", Name: "people", FromTable: "people"}
+
+	sortedDefinitions, sortErr := compile.SortSchemaDefinitionsByDependency([]*psqldef.Table{people}, []*psqldef.View{peopleView})
+
+	suite.ErrorContains(sortErr, "view 'people' has the same name as another schema definition")
+	suite.Nil(sortedDefinitions)
+}
+
+func (suite *DependencySortTestSuite) TestSortTablesByDependency_TriggersOnOtherTables() {
+	comments := &psqldef.Table{
+		Schema: "public",
+		Name:   "comments",
+		Triggers: []psqldef.Trigger{
+			{Schema: "public", Name: "trg_check_comments_commentable", TableName: "comments"},
+			{Schema: "public", Name: "trg_cleanup_comments_commentable", TableName: "people"},
+		},
+	}
+	people := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		ForeignKeys: []psqldef.ForeignKey{
+			{Schema: "public", TableName: "people", ColumnNames: []string{"pinned_comment_id"}, RefTableName: "comments", RefColumnNames: []string{"id"}},
+		},
+	}
+
+	sortedTables, sortErr := compile.SortTablesByDependency([]*psqldef.Table{comments, people})
+
+	// Triggers on other tables are written after all tables, so they form no cycle with the foreign key
+	suite.Nil(sortErr)
+	suite.Len(sortedTables, 2)
+	suite.Equal("comments", sortedTables[0].Name)
+	suite.Equal("people", sortedTables[1].Name)
+}
@@ -0,0 +1,645 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/clone"
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// DiffTables compares the previous set of table definitions against the current one and returns the
+// table diffs required to migrate the database.
+//
+// Created and altered tables are returned in dependency order of the current tables, followed by the triggers
+// they create on other tables and by dropped tables in reverse dependency order of the previous tables. Tables
+// without changes are omitted.
+func DiffTables(previousTables []*psqldef.Table, currentTables []*psqldef.Table) ([]*psqldef.TableDiff, error) {
+	sortedCurrentTables, sortCurrentErr := SortTablesByDependency(currentTables)
+	if sortCurrentErr != nil {
+		return nil, sortCurrentErr
+	}
+	sortedPreviousTables, sortPreviousErr := SortTablesByDependency(previousTables)
+	if sortPreviousErr != nil {
+		return nil, sortPreviousErr
+	}
+
+	previousTableMap := make(map[string]*psqldef.Table, len(sortedPreviousTables))
+	for _, previousTable := range sortedPreviousTables {
+		previousTableMap[getQualifiedTableName(previousTable)] = previousTable
+	}
+	currentTableMap := make(map[string]*psqldef.Table, len(sortedCurrentTables))
+	for _, currentTable := range sortedCurrentTables {
+		currentTableMap[getQualifiedTableName(currentTable)] = currentTable
+	}
+
+	previousEnumTypes := getEnumTypesOfTables(sortedPreviousTables)
+	diffedEnumTypes := map[string]bool{}
+
+	allDiffs := []*psqldef.TableDiff{}
+	crossTableTriggerDiffs := []*psqldef.TableDiff{}
+	for _, currentTable := range sortedCurrentTables {
+		var tableDiff *psqldef.TableDiff
+		previousTable, previousExists := previousTableMap[getQualifiedTableName(currentTable)]
+		if !previousExists {
+			tableClone := currentTable.DeepClone()
+			tableDiff = &psqldef.TableDiff{
+				Schema: currentTable.Schema,
+				Name:   currentTable.Name,
+				Change: psqldef.TableChangeCreate,
+				Table:  &tableClone,
+			}
+		} else {
+			var tableDiffErr error
+			tableDiff, tableDiffErr = diffTable(previousTable, currentTable)
+			if tableDiffErr != nil {
+				return nil, tableDiffErr
+			}
+		}
+
+		// Native enum types are migrated with the first table using them
+		enumTypesErr := diffTableEnumTypes(tableDiff, currentTable, previousEnumTypes, diffedEnumTypes)
+		if enumTypesErr != nil {
+			return nil, enumTypesErr
+		}
+
+		// Triggers on other tables are created once all tables are migrated, like in the table files
+		if crossTableTriggerDiff := splitCrossTableTriggers(tableDiff, currentTable); crossTableTriggerDiff != nil {
+			crossTableTriggerDiffs = append(crossTableTriggerDiffs, crossTableTriggerDiff)
+		}
+		if tableDiff.IsEmpty() {
+			continue
+		}
+		allDiffs = append(allDiffs, tableDiff)
+	}
+	allDiffs = append(allDiffs, crossTableTriggerDiffs...)
+
+	for previousIdx := len(sortedPreviousTables) - 1; previousIdx >= 0; previousIdx-- {
+		previousTable := sortedPreviousTables[previousIdx]
+		if _, currentExists := currentTableMap[getQualifiedTableName(previousTable)]; currentExists {
+			continue
+		}
+		tableClone := previousTable.DeepClone()
+		dropDiff := &psqldef.TableDiff{
+			Schema: previousTable.Schema,
+			Name:   previousTable.Name,
+			Change: psqldef.TableChangeDrop,
+			Table:  &tableClone,
+		}
+
+		// Dropping the table removes its own triggers, but not the ones on remaining tables nor its functions
+		for _, trigger := range getCrossTableTriggers(previousTable) {
+			if _, triggerTableExists := currentTableMap[getQualifiedName(trigger.Schema, trigger.TableName)]; triggerTableExists {
+				dropDiff.DroppedTriggers = append(dropDiff.DroppedTriggers, trigger.DeepClone())
+			}
+		}
+		diffTableFunctions(dropDiff, previousTable.Functions, nil)
+		allDiffs = append(allDiffs, dropDiff)
+	}
+
+	diffDroppedEnumTypes(allDiffs, previousTableMap, previousEnumTypes, getEnumTypesOfTables(sortedCurrentTables))
+
+	return allDiffs, nil
+}
+
+// diffDroppedEnumTypes drops the native enum types no longer used by any table with the last table diff migrating a
+// table that used them. Each previous table using a dropped type is altered or dropped, so such a diff exists.
+func diffDroppedEnumTypes(allDiffs []*psqldef.TableDiff, previousTableMap map[string]*psqldef.Table, previousEnumTypes map[string]psqldef.PSQLTypeEnum, currentEnumTypes map[string]psqldef.PSQLTypeEnum) {
+	for _, enumTypeName := range core.MapKeysSorted(previousEnumTypes) {
+		if _, currentExists := currentEnumTypes[enumTypeName]; currentExists {
+			continue
+		}
+		for diffIdx := len(allDiffs) - 1; diffIdx >= 0; diffIdx-- {
+			tableDiff := allDiffs[diffIdx]
+			if tableDiff.Change == psqldef.TableChangeTriggers {
+				continue
+			}
+			previousTable, previousExists := previousTableMap[getQualifiedName(tableDiff.Schema, tableDiff.Name)]
+			if !previousExists {
+				continue
+			}
+			if _, usesEnumType := getEnumTypesOfTables([]*psqldef.Table{previousTable})[enumTypeName]; usesEnumType {
+				tableDiff.DroppedEnumTypes = append(tableDiff.DroppedEnumTypes, previousEnumTypes[enumTypeName].DeepClone())
+				break
+			}
+		}
+	}
+}
+
+// splitCrossTableTriggers moves the added triggers a table creates on other tables into a separate table diff,
+// returning nil if there are none
+func splitCrossTableTriggers(tableDiff *psqldef.TableDiff, currentTable *psqldef.Table) *psqldef.TableDiff {
+	crossTableTriggers := []psqldef.Trigger{}
+	switch tableDiff.Change {
+	case psqldef.TableChangeCreate:
+		// Created tables only write their own triggers
+		crossTableTriggers = getCrossTableTriggers(currentTable)
+	case psqldef.TableChangeAlter:
+		ownTriggers := []psqldef.Trigger{}
+		for _, trigger := range tableDiff.AddedTriggers {
+			if trigger.TableName == tableDiff.Name {
+				ownTriggers = append(ownTriggers, trigger)
+				continue
+			}
+			crossTableTriggers = append(crossTableTriggers, trigger)
+		}
+		tableDiff.AddedTriggers = ownTriggers
+	}
+	if len(crossTableTriggers) == 0 {
+		return nil
+	}
+
+	tableClone := currentTable.DeepClone()
+	return &psqldef.TableDiff{
+		Schema:        currentTable.Schema,
+		Name:          currentTable.Name,
+		Change:        psqldef.TableChangeTriggers,
+		Table:         &tableClone,
+		AddedTriggers: crossTableTriggers,
+	}
+}
+
+// InvertTableDiff returns the table diff rolling back a table diff, or an error if the changes cannot be reversed,
+// e.g. added native enum values or dropped NOT NULL columns without a default
+func InvertTableDiff(tableDiff *psqldef.TableDiff) (*psqldef.TableDiff, error) {
+	if tableDiff.Table == nil {
+		return nil, fmt.Errorf("migration for table '%s' has no table definition", tableDiff.Name)
+	}
+	if len(tableDiff.AddedEnumValues) > 0 {
+		return nil, fmt.Errorf("%w: values added to enum type '%s' with table '%s' cannot be removed",
+			ErrIrreversibleMigration, tableDiff.AddedEnumValues[0].GetSyntax(), tableDiff.Name)
+	}
+
+	tableClone := tableDiff.Table.DeepClone()
+	switch tableDiff.Change {
+	case psqldef.TableChangeCreate:
+		invertedDiff := &psqldef.TableDiff{
+			Schema:           tableDiff.Schema,
+			Name:             tableDiff.Name,
+			Change:           psqldef.TableChangeDrop,
+			Table:            &tableClone,
+			DroppedEnumTypes: clone.DeepCloneSlice(tableDiff.AddedEnumTypes),
+		}
+		diffTableFunctions(invertedDiff, tableDiff.Table.Functions, nil)
+		return invertedDiff, nil
+	case psqldef.TableChangeDrop:
+		// The triggers dropped from remaining tables are recreated with the table
+		return &psqldef.TableDiff{
+			Schema:         tableDiff.Schema,
+			Name:           tableDiff.Name,
+			Change:         psqldef.TableChangeCreate,
+			Table:          &tableClone,
+			AddedEnumTypes: clone.DeepCloneSlice(tableDiff.DroppedEnumTypes),
+			AddedTriggers:  clone.DeepCloneSlice(tableDiff.DroppedTriggers),
+		}, nil
+	case psqldef.TableChangeAlter:
+		if tableDiff.PreviousTable == nil {
+			return nil, fmt.Errorf("migration altering table '%s' has no previous table definition", tableDiff.Name)
+		}
+		invertedDiff, invertErr := diffTable(tableDiff.Table, tableDiff.PreviousTable)
+		if invertErr != nil {
+			return nil, fmt.Errorf("%w: %w", ErrIrreversibleMigration, invertErr)
+		}
+		invertedDiff.AddedEnumTypes = clone.DeepCloneSlice(tableDiff.DroppedEnumTypes)
+		invertedDiff.DroppedEnumTypes = clone.DeepCloneSlice(tableDiff.AddedEnumTypes)
+		// Seed rows missing from the current definition are never deleted, so only the added rows are rolled back
+		invertedDiff.AddedSeedData = nil
+		invertedDiff.DroppedSeedData = clone.DeepCloneSlice(tableDiff.AddedSeedData)
+		return invertedDiff, nil
+	case psqldef.TableChangeTriggers:
+		return &psqldef.TableDiff{
+			Schema:          tableDiff.Schema,
+			Name:            tableDiff.Name,
+			Change:          psqldef.TableChangeAlter,
+			Table:           &tableClone,
+			DroppedTriggers: clone.DeepCloneSlice(tableDiff.AddedTriggers),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported table change '%s' for table '%s'", tableDiff.Change, tableDiff.Name)
+}
+
+func diffTable(previousTable *psqldef.Table, currentTable *psqldef.Table) (*psqldef.TableDiff, error) {
+	tableClone := currentTable.DeepClone()
+	previousTableClone := previousTable.DeepClone()
+	tableDiff := &psqldef.TableDiff{
+		Schema:        currentTable.Schema,
+		Name:          currentTable.Name,
+		Change:        psqldef.TableChangeAlter,
+		Table:         &tableClone,
+		PreviousTable: &previousTableClone,
+	}
+
+	columnsErr := diffTableColumns(tableDiff, previousTable.Columns, currentTable.Columns)
+	if columnsErr != nil {
+		return nil, columnsErr
+	}
+	diffTableForeignKeys(tableDiff, previousTable.ForeignKeys, currentTable.ForeignKeys)
+	diffTableUniqueConstraints(tableDiff, previousTable.UniqueConstraints, currentTable.UniqueConstraints)
+	diffTableCheckConstraints(tableDiff, previousTable.CheckConstraints, currentTable.CheckConstraints)
+	diffTableIndices(tableDiff, previousTable, currentTable)
+	diffTableFunctions(tableDiff, previousTable.Functions, currentTable.Functions)
+	diffTableTriggers(tableDiff, previousTable.Triggers, currentTable.Triggers)
+	diffTableRowLevelSecurity(tableDiff, previousTable, currentTable)
+	partitionsErr := diffTablePartitions(tableDiff, previousTable, currentTable)
+	if partitionsErr != nil {
+		return nil, partitionsErr
+	}
+	diffTableSeedData(tableDiff, previousTable.SeedData, currentTable.SeedData)
+
+	return tableDiff, nil
+}
+
+func diffTableColumns(tableDiff *psqldef.TableDiff, previousColumns []psqldef.TableColumn, currentColumns []psqldef.TableColumn) error {
+	previousColumnMap := make(map[string]psqldef.TableColumn, len(previousColumns))
+	for _, previousColumn := range previousColumns {
+		previousColumnMap[previousColumn.Name] = previousColumn
+	}
+	currentColumnMap := make(map[string]psqldef.TableColumn, len(currentColumns))
+	for _, currentColumn := range currentColumns {
+		currentColumnMap[currentColumn.Name] = currentColumn
+	}
+
+	for _, previousColumn := range previousColumns {
+		if _, currentExists := currentColumnMap[previousColumn.Name]; !currentExists {
+			tableDiff.DroppedColumns = append(tableDiff.DroppedColumns, previousColumn.DeepClone())
+		}
+	}
+
+	for _, currentColumn := range currentColumns {
+		previousColumn, previousExists := previousColumnMap[currentColumn.Name]
+		if !previousExists {
+			// Existing rows can only be filled in by the column default
+			if currentColumn.NotNull && currentColumn.Default == "" && !isSerialColumnType(currentColumn.Type) {
+				return fmt.Errorf("%w: column '%s' in table '%s' needs a default, or must be added optional and made required once backfilled",
+					ErrNotNullColumnWithoutDefault, currentColumn.Name, tableDiff.Name)
+			}
+			tableDiff.AddedColumns = append(tableDiff.AddedColumns, currentColumn.DeepClone())
+			continue
+		}
+
+		columnDiff := psqldef.ColumnDiff{
+			Name:     currentColumn.Name,
+			Previous: previousColumn.DeepClone(),
+			Current:  currentColumn.DeepClone(),
+		}
+		if previousColumn.PrimaryKey != currentColumn.PrimaryKey {
+			return fmt.Errorf("changing the primary key of column '%s' in table '%s' is not supported", currentColumn.Name, tableDiff.Name)
+		}
+		if columnDiff.TypeChanged() || columnDiff.NotNullChanged() || columnDiff.DefaultChanged() {
+			tableDiff.AlteredColumns = append(tableDiff.AlteredColumns, columnDiff)
+		}
+	}
+
+	return nil
+}
+
+func diffTableForeignKeys(tableDiff *psqldef.TableDiff, previousForeignKeys []psqldef.ForeignKey, currentForeignKeys []psqldef.ForeignKey) {
+	previousForeignKeyMap := make(map[string]psqldef.ForeignKey, len(previousForeignKeys))
+	for _, previousForeignKey := range previousForeignKeys {
+		previousForeignKeyMap[getForeignKeyIdentity(tableDiff.Name, previousForeignKey)] = previousForeignKey
+	}
+	currentForeignKeyMap := make(map[string]psqldef.ForeignKey, len(currentForeignKeys))
+	for _, currentForeignKey := range currentForeignKeys {
+		currentForeignKeyMap[getForeignKeyIdentity(tableDiff.Name, currentForeignKey)] = currentForeignKey
+	}
+
+	for _, previousForeignKey := range previousForeignKeys {
+		currentForeignKey, currentExists := currentForeignKeyMap[getForeignKeyIdentity(tableDiff.Name, previousForeignKey)]
+		if !currentExists || !isForeignKeyEqual(previousForeignKey, currentForeignKey) {
+			tableDiff.DroppedForeignKeys = append(tableDiff.DroppedForeignKeys, previousForeignKey.DeepClone())
+		}
+	}
+
+	for _, currentForeignKey := range currentForeignKeys {
+		previousForeignKey, previousExists := previousForeignKeyMap[getForeignKeyIdentity(tableDiff.Name, currentForeignKey)]
+		if !previousExists || !isForeignKeyEqual(previousForeignKey, currentForeignKey) {
+			tableDiff.AddedForeignKeys = append(tableDiff.AddedForeignKeys, currentForeignKey.DeepClone())
+		}
+	}
+}
+
+func diffTableCheckConstraints(tableDiff *psqldef.TableDiff, previousConstraints []psqldef.CheckConstraint, currentConstraints []psqldef.CheckConstraint) {
+	previousConstraintMap := make(map[string]psqldef.CheckConstraint, len(previousConstraints))
+	for _, previousConstraint := range previousConstraints {
+		previousConstraintMap[previousConstraint.Name] = previousConstraint
+	}
+	currentConstraintMap := make(map[string]psqldef.CheckConstraint, len(currentConstraints))
+	for _, currentConstraint := range currentConstraints {
+		currentConstraintMap[currentConstraint.Name] = currentConstraint
+	}
+
+	// A changed expression is migrated by dropping and re-adding the constraint
+	for _, previousConstraint := range previousConstraints {
+		currentConstraint, currentExists := currentConstraintMap[previousConstraint.Name]
+		if !currentExists || currentConstraint.Expression != previousConstraint.Expression {
+			tableDiff.DroppedCheckConstraints = append(tableDiff.DroppedCheckConstraints, previousConstraint.DeepClone())
+		}
+	}
+
+	for _, currentConstraint := range currentConstraints {
+		previousConstraint, previousExists := previousConstraintMap[currentConstraint.Name]
+		if !previousExists || previousConstraint.Expression != currentConstraint.Expression {
+			tableDiff.AddedCheckConstraints = append(tableDiff.AddedCheckConstraints, currentConstraint.DeepClone())
+		}
+	}
+}
+
+func diffTableUniqueConstraints(tableDiff *psqldef.TableDiff, previousConstraints []psqldef.UniqueConstraint, currentConstraints []psqldef.UniqueConstraint) {
+	previousConstraintSet := make(map[string]bool, len(previousConstraints))
+	for _, previousConstraint := range previousConstraints {
+		previousConstraintSet[strings.Join(previousConstraint.ColumnNames, ",")] = true
+	}
+	currentConstraintSet := make(map[string]bool, len(currentConstraints))
+	for _, currentConstraint := range currentConstraints {
+		currentConstraintSet[strings.Join(currentConstraint.ColumnNames, ",")] = true
+	}
+
+	for _, previousConstraint := range previousConstraints {
+		if !currentConstraintSet[strings.Join(previousConstraint.ColumnNames, ",")] {
+			tableDiff.DroppedUniqueConstraints = append(tableDiff.DroppedUniqueConstraints, previousConstraint.DeepClone())
+		}
+	}
+
+	for _, currentConstraint := range currentConstraints {
+		if !previousConstraintSet[strings.Join(currentConstraint.ColumnNames, ",")] {
+			tableDiff.AddedUniqueConstraints = append(tableDiff.AddedUniqueConstraints, currentConstraint.DeepClone())
+		}
+	}
+}
+
+func diffTableIndices(tableDiff *psqldef.TableDiff, previousTable *psqldef.Table, currentTable *psqldef.Table) {
+	previousIndexMap := make(map[string]psqldef.Index, len(previousTable.Indices))
+	for _, previousIndex := range previousTable.Indices {
+		previousIndexMap[getIndexDefinitionName(previousTable, previousIndex)] = previousIndex
+	}
+	currentIndexMap := make(map[string]psqldef.Index, len(currentTable.Indices))
+	for _, currentIndex := range currentTable.Indices {
+		currentIndexMap[getIndexDefinitionName(currentTable, currentIndex)] = currentIndex
+	}
+
+	for _, previousIndex := range previousTable.Indices {
+		currentIndex, currentExists := currentIndexMap[getIndexDefinitionName(previousTable, previousIndex)]
+		if !currentExists || !isIndexEqual(previousIndex, currentIndex) {
+			tableDiff.DroppedIndices = append(tableDiff.DroppedIndices, previousIndex.DeepClone())
+		}
+	}
+
+	for _, currentIndex := range currentTable.Indices {
+		previousIndex, previousExists := previousIndexMap[getIndexDefinitionName(currentTable, currentIndex)]
+		if !previousExists || !isIndexEqual(previousIndex, currentIndex) {
+			tableDiff.AddedIndices = append(tableDiff.AddedIndices, currentIndex.DeepClone())
+		}
+	}
+}
+
+// diffTableEnumTypes adds the native enum types used by the table that are new, or gained values, and were not
+// diffed with a previous table
+func diffTableEnumTypes(tableDiff *psqldef.TableDiff, currentTable *psqldef.Table, previousEnumTypes map[string]psqldef.PSQLTypeEnum, diffedEnumTypes map[string]bool) error {
+	for _, column := range currentTable.Columns {
+		currentEnumType, isEnumType := column.Type.(psqldef.PSQLTypeEnum)
+		if !isEnumType || diffedEnumTypes[currentEnumType.GetSyntax()] {
+			continue
+		}
+		diffedEnumTypes[currentEnumType.GetSyntax()] = true
+
+		previousEnumType, previousExists := previousEnumTypes[currentEnumType.GetSyntax()]
+		if !previousExists {
+			tableDiff.AddedEnumTypes = append(tableDiff.AddedEnumTypes, currentEnumType.DeepClone())
+			continue
+		}
+
+		for _, previousValue := range previousEnumType.Values {
+			if !slices.Contains(currentEnumType.Values, previousValue) {
+				return fmt.Errorf("%w: value '%s' of enum type '%s'", ErrEnumValueRemoved, previousValue, currentEnumType.GetSyntax())
+			}
+		}
+		addedValues := []string{}
+		for _, currentValue := range currentEnumType.Values {
+			if !slices.Contains(previousEnumType.Values, currentValue) {
+				addedValues = append(addedValues, currentValue)
+			}
+		}
+		if len(addedValues) > 0 {
+			tableDiff.AddedEnumValues = append(tableDiff.AddedEnumValues, psqldef.PSQLTypeEnum{
+				Schema: currentEnumType.Schema,
+				Name:   currentEnumType.Name,
+				Values: addedValues,
+			})
+		}
+	}
+	return nil
+}
+
+// diffTableFunctions adds new and changed functions, which are created or replaced, and drops removed functions
+// unless they are shared with other tables
+func diffTableFunctions(tableDiff *psqldef.TableDiff, previousFunctions []psqldef.Function, currentFunctions []psqldef.Function) {
+	previousFunctionMap := make(map[string]psqldef.Function, len(previousFunctions))
+	for _, previousFunction := range previousFunctions {
+		previousFunctionMap[getQualifiedName(previousFunction.Schema, previousFunction.Name)] = previousFunction
+	}
+	currentFunctionMap := make(map[string]psqldef.Function, len(currentFunctions))
+	for _, currentFunction := range currentFunctions {
+		currentFunctionMap[getQualifiedName(currentFunction.Schema, currentFunction.Name)] = currentFunction
+	}
+
+	for _, previousFunction := range previousFunctions {
+		_, currentExists := currentFunctionMap[getQualifiedName(previousFunction.Schema, previousFunction.Name)]
+		if !currentExists && !previousFunction.Shared {
+			tableDiff.DroppedFunctions = append(tableDiff.DroppedFunctions, previousFunction.DeepClone())
+		}
+	}
+
+	for _, currentFunction := range currentFunctions {
+		previousFunction, previousExists := previousFunctionMap[getQualifiedName(currentFunction.Schema, currentFunction.Name)]
+		if !previousExists || !isFunctionEqual(previousFunction, currentFunction) {
+			tableDiff.AddedFunctions = append(tableDiff.AddedFunctions, currentFunction.DeepClone())
+		}
+	}
+}
+
+// diffTableTriggers adds new and changed triggers, which are dropped and recreated, and drops removed triggers
+func diffTableTriggers(tableDiff *psqldef.TableDiff, previousTriggers []psqldef.Trigger, currentTriggers []psqldef.Trigger) {
+	previousTriggerMap := make(map[string]psqldef.Trigger, len(previousTriggers))
+	for _, previousTrigger := range previousTriggers {
+		previousTriggerMap[getTriggerIdentity(previousTrigger)] = previousTrigger
+	}
+	currentTriggerMap := make(map[string]psqldef.Trigger, len(currentTriggers))
+	for _, currentTrigger := range currentTriggers {
+		currentTriggerMap[getTriggerIdentity(currentTrigger)] = currentTrigger
+	}
+
+	for _, previousTrigger := range previousTriggers {
+		if _, currentExists := currentTriggerMap[getTriggerIdentity(previousTrigger)]; !currentExists {
+			tableDiff.DroppedTriggers = append(tableDiff.DroppedTriggers, previousTrigger.DeepClone())
+		}
+	}
+
+	for _, currentTrigger := range currentTriggers {
+		previousTrigger, previousExists := previousTriggerMap[getTriggerIdentity(currentTrigger)]
+		if !previousExists || !isTriggerEqual(previousTrigger, currentTrigger) {
+			tableDiff.AddedTriggers = append(tableDiff.AddedTriggers, currentTrigger.DeepClone())
+		}
+	}
+}
+
+// diffTableRowLevelSecurity toggles row-level security, adds new and changed policies, which are dropped and
+// recreated, and drops removed policies
+func diffTableRowLevelSecurity(tableDiff *psqldef.TableDiff, previousTable *psqldef.Table, currentTable *psqldef.Table) {
+	tableDiff.EnableRowLevelSecurity = !previousTable.RowLevelSecurity && currentTable.RowLevelSecurity
+	tableDiff.DisableRowLevelSecurity = previousTable.RowLevelSecurity && !currentTable.RowLevelSecurity
+
+	previousPolicyMap := make(map[string]psqldef.Policy, len(previousTable.Policies))
+	for _, previousPolicy := range previousTable.Policies {
+		previousPolicyMap[previousPolicy.Name] = previousPolicy
+	}
+	currentPolicyMap := make(map[string]psqldef.Policy, len(currentTable.Policies))
+	for _, currentPolicy := range currentTable.Policies {
+		currentPolicyMap[currentPolicy.Name] = currentPolicy
+	}
+
+	for _, previousPolicy := range previousTable.Policies {
+		if _, currentExists := currentPolicyMap[previousPolicy.Name]; !currentExists {
+			tableDiff.DroppedPolicies = append(tableDiff.DroppedPolicies, previousPolicy.DeepClone())
+		}
+	}
+
+	for _, currentPolicy := range currentTable.Policies {
+		previousPolicy, previousExists := previousPolicyMap[currentPolicy.Name]
+		if !previousExists || previousPolicy != currentPolicy {
+			tableDiff.AddedPolicies = append(tableDiff.AddedPolicies, currentPolicy.DeepClone())
+		}
+	}
+}
+
+// diffTablePartitions adds and drops the partitions of a table. Tables cannot be repartitioned and partitions cannot
+// be rebound in place, since both require moving the stored rows.
+func diffTablePartitions(tableDiff *psqldef.TableDiff, previousTable *psqldef.Table, currentTable *psqldef.Table) error {
+	if previousTable.PartitionStrategy != currentTable.PartitionStrategy || !slices.Equal(previousTable.PartitionColumns, currentTable.PartitionColumns) {
+		return fmt.Errorf("%w: table '%s'", ErrPartitionKeyChanged, tableDiff.Name)
+	}
+
+	previousPartitionMap := make(map[string]psqldef.TablePartition, len(previousTable.Partitions))
+	for _, previousPartition := range previousTable.Partitions {
+		previousPartitionMap[previousPartition.Name] = previousPartition
+	}
+	currentPartitionMap := make(map[string]psqldef.TablePartition, len(currentTable.Partitions))
+	for _, currentPartition := range currentTable.Partitions {
+		currentPartitionMap[currentPartition.Name] = currentPartition
+	}
+
+	for _, previousPartition := range previousTable.Partitions {
+		if _, currentExists := currentPartitionMap[previousPartition.Name]; !currentExists {
+			tableDiff.DroppedPartitions = append(tableDiff.DroppedPartitions, previousPartition.DeepClone())
+		}
+	}
+
+	for _, currentPartition := range currentTable.Partitions {
+		previousPartition, previousExists := previousPartitionMap[currentPartition.Name]
+		if !previousExists {
+			tableDiff.AddedPartitions = append(tableDiff.AddedPartitions, currentPartition.DeepClone())
+			continue
+		}
+		if previousPartition.Bound != currentPartition.Bound {
+			return fmt.Errorf("%w: partition '%s' of table '%s'", ErrPartitionBoundChanged, currentPartition.Name, tableDiff.Name)
+		}
+	}
+	return nil
+}
+
+// diffTableSeedData adds the seed rows that are not part of the previous seed data, e.g. new enum entries
+func diffTableSeedData(tableDiff *psqldef.TableDiff, previousSeedData []psqldef.InsertStatement, currentSeedData []psqldef.InsertStatement) {
+	previousRowSet := map[string]bool{}
+	for _, previousInsert := range previousSeedData {
+		for _, previousRow := range previousInsert.Values {
+			previousRowSet[getSeedRowIdentity(previousInsert, previousRow)] = true
+		}
+	}
+
+	for _, currentInsert := range currentSeedData {
+		addedRows := [][]any{}
+		for _, currentRow := range currentInsert.Values {
+			if !previousRowSet[getSeedRowIdentity(currentInsert, currentRow)] {
+				addedRows = append(addedRows, slices.Clone(currentRow))
+			}
+		}
+		if len(addedRows) == 0 {
+			continue
+		}
+		tableDiff.AddedSeedData = append(tableDiff.AddedSeedData, psqldef.InsertStatement{
+			Schema:    currentInsert.Schema,
+			TableName: currentInsert.TableName,
+			Columns:   slices.Clone(currentInsert.Columns),
+			Values:    addedRows,
+		})
+	}
+}
+
+// getEnumTypesOfTables returns the native enum types used by the columns of the tables by qualified name
+func getEnumTypesOfTables(tables []*psqldef.Table) map[string]psqldef.PSQLTypeEnum {
+	enumTypes := map[string]psqldef.PSQLTypeEnum{}
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			if enumType, isEnumType := column.Type.(psqldef.PSQLTypeEnum); isEnumType {
+				enumTypes[enumType.GetSyntax()] = enumType
+			}
+		}
+	}
+	return enumTypes
+}
+
+func getQualifiedTableName(table *psqldef.Table) string {
+	if table.Schema == "" {
+		return table.Name
+	}
+	return table.Schema + "." + table.Name
+}
+
+func getForeignKeyIdentity(tableName string, foreignKey psqldef.ForeignKey) string {
+	if foreignKey.Name != "" {
+		return foreignKey.Name
+	}
+	return GetForeignKeyConstraintName(tableName, strings.Join(foreignKey.ColumnNames, "_"))
+}
+
+func isForeignKeyEqual(previousForeignKey psqldef.ForeignKey, currentForeignKey psqldef.ForeignKey) bool {
+	return slices.Equal(previousForeignKey.ColumnNames, currentForeignKey.ColumnNames) &&
+		previousForeignKey.RefSchema == currentForeignKey.RefSchema &&
+		previousForeignKey.RefTableName == currentForeignKey.RefTableName &&
+		slices.Equal(previousForeignKey.RefColumnNames, currentForeignKey.RefColumnNames) &&
+		previousForeignKey.OnDelete == currentForeignKey.OnDelete &&
+		previousForeignKey.OnUpdate == currentForeignKey.OnUpdate &&
+		slices.Equal(previousForeignKey.SetNullColumnNames, currentForeignKey.SetNullColumnNames)
+}
+
+func getTriggerIdentity(trigger psqldef.Trigger) string {
+	return getQualifiedName(trigger.Schema, trigger.TableName) + "." + trigger.Name
+}
+
+// getSeedRowIdentity formats a seed row with its columns, comparing values independent of their Go type, e.g. after a
+// schema snapshot round trip
+func getSeedRowIdentity(insert psqldef.InsertStatement, row []any) string {
+	return fmt.Sprintf("%s(%s)%v", insert.TableName, strings.Join(insert.Columns, ","), row)
+}
+
+func isFunctionEqual(previousFunction psqldef.Function, currentFunction psqldef.Function) bool {
+	return previousFunction.Returns == currentFunction.Returns &&
+		previousFunction.Language == currentFunction.Language &&
+		slices.Equal(previousFunction.Body, currentFunction.Body)
+}
+
+func isTriggerEqual(previousTrigger psqldef.Trigger, currentTrigger psqldef.Trigger) bool {
+	return previousTrigger.Timing == currentTrigger.Timing &&
+		slices.Equal(previousTrigger.Events, currentTrigger.Events) &&
+		previousTrigger.FunctionSchema == currentTrigger.FunctionSchema &&
+		previousTrigger.FunctionName == currentTrigger.FunctionName &&
+		slices.Equal(previousTrigger.Arguments, currentTrigger.Arguments)
+}
+
+func isIndexEqual(previousIndex psqldef.Index, currentIndex psqldef.Index) bool {
+	return slices.Equal(previousIndex.Columns, currentIndex.Columns) &&
+		previousIndex.IsUnique == currentIndex.IsUnique &&
+		strings.EqualFold(previousIndex.Using, currentIndex.Using) &&
+		previousIndex.Where == currentIndex.Where
+}
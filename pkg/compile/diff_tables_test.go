@@ -0,0 +1,814 @@
+package compile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffTablesTestSuite struct {
+	suite.Suite
+}
+
+func TestDiffTablesTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffTablesTestSuite))
+}
+
+func (suite *DiffTablesTestSuite) getCompaniesTable() *psqldef.Table {
+	return &psqldef.Table{
+		Schema: "public",
+		Name:   "companies",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "name", Type: psqldef.PSQLTypeText, NotNull: true},
+		},
+	}
+}
+
+func (suite *DiffTablesTestSuite) getPeopleTable() *psqldef.Table {
+	return &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "first_name", Type: psqldef.PSQLTypeText, NotNull: true},
+			{Name: "nickname", Type: psqldef.PSQLTypeText, NotNull: true},
+		},
+		Indices: []psqldef.Index{
+			{Name: "idx_people_first_name", TableName: "people", Columns: []string{"first_name"}},
+		},
+		ForeignKeys:       []psqldef.ForeignKey{},
+		UniqueConstraints: []psqldef.UniqueConstraint{},
+	}
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_NoChanges() {
+	previousTables := []*psqldef.Table{suite.getCompaniesTable(), suite.getPeopleTable()}
+	currentTables := []*psqldef.Table{suite.getCompaniesTable(), suite.getPeopleTable()}
+
+	allDiffs, diffErr := compile.DiffTables(previousTables, currentTables)
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 0)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_AlterTable() {
+	previousPeople := suite.getPeopleTable()
+
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = []psqldef.TableColumn{
+		{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+		{Name: "first_name", Type: psqldef.PSQLTypeVarchar, NotNull: false},
+		{Name: "company_id", Type: psqldef.PSQLTypeInteger, NotNull: false},
+	}
+	currentPeople.ForeignKeys = []psqldef.ForeignKey{
+		{
+			Schema:         "public",
+			Name:           "fk_people_company_id",
+			TableName:      "people",
+			ColumnNames:    []string{"company_id"},
+			RefSchema:      "public",
+			RefTableName:   "companies",
+			RefColumnNames: []string{"id"},
+			OnDelete:       "CASCADE",
+		},
+	}
+	currentPeople.Indices = []psqldef.Index{
+		{Name: "idx_people_first_name", TableName: "people", Columns: []string{"first_name"}, IsUnique: true},
+		{Name: "idx_people_company_id", TableName: "people", Columns: []string{"company_id"}},
+	}
+	currentPeople.UniqueConstraints = []psqldef.UniqueConstraint{
+		{TableName: "people", ColumnNames: []string{"first_name", "company_id"}},
+	}
+
+	previousTables := []*psqldef.Table{suite.getCompaniesTable(), previousPeople}
+	currentTables := []*psqldef.Table{suite.getCompaniesTable(), currentPeople}
+
+	allDiffs, diffErr := compile.DiffTables(previousTables, currentTables)
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	peopleDiff := allDiffs[0]
+	suite.Equal("public", peopleDiff.Schema)
+	suite.Equal("people", peopleDiff.Name)
+	suite.Equal(psqldef.TableChangeAlter, peopleDiff.Change)
+
+	suite.Len(peopleDiff.AddedColumns, 1)
+	suite.Equal("company_id", peopleDiff.AddedColumns[0].Name)
+
+	suite.Len(peopleDiff.DroppedColumns, 1)
+	suite.Equal("nickname", peopleDiff.DroppedColumns[0].Name)
+
+	suite.Len(peopleDiff.AlteredColumns, 1)
+	alteredColumn := peopleDiff.AlteredColumns[0]
+	suite.Equal("first_name", alteredColumn.Name)
+	suite.True(alteredColumn.TypeChanged())
+	suite.True(alteredColumn.NotNullChanged())
+	suite.False(alteredColumn.DefaultChanged())
+
+	suite.Len(peopleDiff.AddedForeignKeys, 1)
+	suite.Equal("fk_people_company_id", peopleDiff.AddedForeignKeys[0].Name)
+	suite.Len(peopleDiff.DroppedForeignKeys, 0)
+
+	suite.Len(peopleDiff.AddedUniqueConstraints, 1)
+	suite.Len(peopleDiff.DroppedUniqueConstraints, 0)
+
+	suite.Len(peopleDiff.DroppedIndices, 1)
+	suite.Equal("idx_people_first_name", peopleDiff.DroppedIndices[0].Name)
+	suite.Len(peopleDiff.AddedIndices, 2)
+	suite.Equal("idx_people_first_name", peopleDiff.AddedIndices[0].Name)
+	suite.True(peopleDiff.AddedIndices[0].IsUnique)
+	suite.Equal("idx_people_company_id", peopleDiff.AddedIndices[1].Name)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_CreateAndDropTables() {
+	previousContacts := &psqldef.Table{
+		Schema: "public",
+		Name:   "contacts",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+		},
+	}
+
+	previousTables := []*psqldef.Table{previousContacts, suite.getPeopleTable()}
+	currentTables := []*psqldef.Table{suite.getPeopleTable(), suite.getCompaniesTable()}
+
+	allDiffs, diffErr := compile.DiffTables(previousTables, currentTables)
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 2)
+
+	suite.Equal("companies", allDiffs[0].Name)
+	suite.Equal(psqldef.TableChangeCreate, allDiffs[0].Change)
+	suite.NotNil(allDiffs[0].Table)
+
+	suite.Equal("contacts", allDiffs[1].Name)
+	suite.Equal(psqldef.TableChangeDrop, allDiffs[1].Change)
+	suite.NotNil(allDiffs[1].Table)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_PrimaryKeyChange() {
+	previousPeople := suite.getPeopleTable()
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns[1].PrimaryKey = true
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.ErrorContains(diffErr, "changing the primary key of column 'first_name' in table 'people' is not supported")
+	suite.Nil(allDiffs)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_CheckConstraints() {
+	previousPeople := suite.getPeopleTable()
+	previousPeople.CheckConstraints = []psqldef.CheckConstraint{
+		{Schema: "public", Name: "ck_people_first_name_max_length", TableName: "people", Expression: "char_length(first_name) <= 8"},
+		{Schema: "public", Name: "ck_people_nickname_pattern", TableName: "people", Expression: "nickname ~ '^[a-z]+$'"},
+	}
+	currentPeople := suite.getPeopleTable()
+	currentPeople.CheckConstraints = []psqldef.CheckConstraint{
+		{Schema: "public", Name: "ck_people_first_name_max_length", TableName: "people", Expression: "char_length(first_name) <= 16"},
+		{Schema: "public", Name: "ck_people_nickname_pattern", TableName: "people", Expression: "nickname ~ '^[a-z]+$'"},
+	}
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	peopleDiff := allDiffs[0]
+	suite.Len(peopleDiff.DroppedCheckConstraints, 1)
+	suite.Equal("char_length(first_name) <= 8", peopleDiff.DroppedCheckConstraints[0].Expression)
+	suite.Len(peopleDiff.AddedCheckConstraints, 1)
+	suite.Equal("char_length(first_name) <= 16", peopleDiff.AddedCheckConstraints[0].Expression)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(peopleDiff)
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+ALTER TABLE public.people DROP CONSTRAINT IF EXISTS ck_people_first_name_max_length;
+
+ALTER TABLE public.people ADD CONSTRAINT ck_people_first_name_max_length CHECK (char_length(first_name) <= 16);
+
+`, string(migrationContents))
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_AddedNotNullColumn() {
+	previousPeople := suite.getPeopleTable()
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = append(currentPeople.Columns, psqldef.TableColumn{Name: "age", Type: psqldef.PSQLTypeInteger, NotNull: true})
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.ErrorIs(diffErr, compile.ErrNotNullColumnWithoutDefault)
+	suite.ErrorContains(diffErr, "column 'age' in table 'people'")
+	suite.Nil(allDiffs)
+
+	currentPeople.Columns[3].Default = "0"
+
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+	suite.Len(allDiffs[0].AddedColumns, 1)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_SerialColumn() {
+	previousPeople := suite.getPeopleTable()
+	previousPeople.Columns = append(previousPeople.Columns, psqldef.TableColumn{Name: "badge_number", Type: psqldef.PSQLTypeInteger, NotNull: true})
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = append(currentPeople.Columns, psqldef.TableColumn{Name: "badge_number", Type: psqldef.PSQLTypeSerial, NotNull: true})
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[0])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+CREATE SEQUENCE IF NOT EXISTS public.people_badge_number_seq AS INTEGER OWNED BY public.people.badge_number;
+SELECT setval('public.people_badge_number_seq', COALESCE(MAX(badge_number), 0) + 1, false) FROM public.people;
+ALTER TABLE public.people ALTER COLUMN badge_number SET DEFAULT nextval('public.people_badge_number_seq');
+
+`, string(migrationContents))
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_FunctionsAndTriggers() {
+	previousPeople := suite.getPeopleTable()
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = append(currentPeople.Columns,
+		psqldef.TableColumn{Name: "updated_at", Type: psqldef.PSQLTypeTimestampTZ, NotNull: true, Default: "NOW()"})
+	currentPeople.Functions = []psqldef.Function{
+		{
+			Schema:   "public",
+			Name:     "set_updated_at",
+			Returns:  "trigger",
+			Language: "plpgsql",
+			Body:     []string{"BEGIN", "\tNEW.updated_at = NOW();", "\tRETURN NEW;", "END;"},
+			Shared:   true,
+		},
+	}
+	currentPeople.Triggers = []psqldef.Trigger{
+		{
+			Schema:         "public",
+			Name:           "trg_people_updated_at",
+			TableName:      "people",
+			Timing:         "BEFORE",
+			Events:         []string{"UPDATE"},
+			FunctionSchema: "public",
+			FunctionName:   "set_updated_at",
+		},
+	}
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+	suite.Len(allDiffs[0].AddedFunctions, 1)
+	suite.Len(allDiffs[0].AddedTriggers, 1)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[0])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+ALTER TABLE public.people ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE OR REPLACE FUNCTION public.set_updated_at()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	NEW.updated_at = NOW();
+	RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trg_people_updated_at ON public.people;
+CREATE TRIGGER trg_people_updated_at BEFORE UPDATE ON public.people
+	FOR EACH ROW EXECUTE FUNCTION public.set_updated_at();
+
+`, string(migrationContents))
+
+	// Shared functions are kept when the trigger is removed
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{currentPeople}, []*psqldef.Table{previousPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+	suite.Len(allDiffs[0].DroppedTriggers, 1)
+	suite.Len(allDiffs[0].DroppedFunctions, 0)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_CrossTableTriggers() {
+	comments := &psqldef.Table{
+		Schema: "public",
+		Name:   "comments",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+		},
+		Functions: []psqldef.Function{
+			{Schema: "public", Name: "cleanup_comments_commentable", Returns: "trigger", Language: "plpgsql", Body: []string{"BEGIN", "\tRETURN OLD;", "END;"}},
+		},
+		Triggers: []psqldef.Trigger{
+			{
+				Schema:         "public",
+				Name:           "trg_cleanup_comments_commentable",
+				TableName:      "people",
+				Timing:         "AFTER",
+				Events:         []string{"DELETE"},
+				FunctionSchema: "public",
+				FunctionName:   "cleanup_comments_commentable",
+				Arguments:      []string{"Person", "id"},
+			},
+		},
+	}
+	people := suite.getPeopleTable()
+	people.Columns = append(people.Columns, psqldef.TableColumn{Name: "pinned_comment_id", Type: psqldef.PSQLTypeInteger})
+	people.ForeignKeys = []psqldef.ForeignKey{
+		{Schema: "public", TableName: "people", ColumnNames: []string{"pinned_comment_id"}, RefSchema: "public", RefTableName: "comments", RefColumnNames: []string{"id"}},
+	}
+
+	allDiffs, diffErr := compile.DiffTables(nil, []*psqldef.Table{people, comments})
+
+	// The cleanup trigger on people is created once both tables exist
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 3)
+	suite.Equal("comments", allDiffs[0].Name)
+	suite.Equal(psqldef.TableChangeCreate, allDiffs[0].Change)
+	suite.Equal("people", allDiffs[1].Name)
+	suite.Equal(psqldef.TableChangeCreate, allDiffs[1].Change)
+	suite.Equal("comments", allDiffs[2].Name)
+	suite.Equal(psqldef.TableChangeTriggers, allDiffs[2].Change)
+	suite.Len(allDiffs[2].AddedTriggers, 1)
+
+	workingDirPath := suite.T().TempDir()
+	writer := &compile.MorpheMigrationFileWriter{TargetDirPath: workingDirPath}
+
+	createContents, createErr := writer.WriteMigrationWithOrder(allDiffs[0], 1)
+	suite.Nil(createErr)
+	suite.NotContains(string(createContents), "TRIGGER")
+
+	triggerContents, triggerErr := writer.WriteMigrationWithOrder(allDiffs[2], 3)
+	suite.Nil(triggerErr)
+	suite.Equal(`-- Migration creating triggers of comments on other tables
+
+DROP TRIGGER IF EXISTS trg_cleanup_comments_commentable ON public.people;
+CREATE TRIGGER trg_cleanup_comments_commentable AFTER DELETE ON public.people
+	FOR EACH ROW EXECUTE FUNCTION public.cleanup_comments_commentable('Person', 'id');
+
+`, string(triggerContents))
+	suite.FileExists(filepath.Join(workingDirPath, "003_triggers_comments.sql"))
+
+	// Dropping comments removes its trigger on the remaining people table and its functions
+	people.Columns = people.Columns[:len(people.Columns)-1]
+	people.ForeignKeys = []psqldef.ForeignKey{}
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{comments, suite.getPeopleTable()}, []*psqldef.Table{people})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+	suite.Equal(psqldef.TableChangeDrop, allDiffs[0].Change)
+
+	dropContents, dropErr := writer.WriteMigration(allDiffs[0])
+	suite.Nil(dropErr)
+	suite.Equal(`-- Migration dropping comments
+
+DROP TRIGGER IF EXISTS trg_cleanup_comments_commentable ON public.people;
+
+DROP TABLE IF EXISTS public.comments;
+
+DROP FUNCTION IF EXISTS public.cleanup_comments_commentable();
+
+`, string(dropContents))
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_RowLevelSecurityAndPartitions() {
+	previousPeople := suite.getPeopleTable()
+	previousPeople.PartitionStrategy = "LIST"
+	previousPeople.PartitionColumns = []string{"nickname"}
+	previousPeople.Partitions = []psqldef.TablePartition{
+		{Name: "people_default"},
+	}
+	currentPeople := suite.getPeopleTable()
+	currentPeople.PartitionStrategy = "LIST"
+	currentPeople.PartitionColumns = []string{"nickname"}
+	currentPeople.Partitions = []psqldef.TablePartition{
+		{Name: "people_default"},
+		{Name: "people_bob", Bound: "IN ('bob')"},
+	}
+	currentPeople.RowLevelSecurity = true
+	currentPeople.Policies = []psqldef.Policy{
+		{Name: "people_nickname_isolation", TableName: "people", Command: "ALL", Using: "nickname = current_user"},
+	}
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[0])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+CREATE TABLE IF NOT EXISTS public.people_bob PARTITION OF public.people FOR VALUES IN ('bob');
+
+ALTER TABLE public.people ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS people_nickname_isolation ON public.people;
+CREATE POLICY people_nickname_isolation ON public.people
+	FOR ALL
+	USING (nickname = current_user);
+
+`, string(migrationContents))
+
+	currentPeople.PartitionColumns = []string{"first_name"}
+
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.ErrorIs(diffErr, compile.ErrPartitionKeyChanged)
+	suite.Nil(allDiffs)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_EnumTypes() {
+	previousStatus := psqldef.PSQLTypeEnum{Schema: "public", Name: "status", Values: []string{"ACTIVE", "INACTIVE"}}
+	currentStatus := psqldef.PSQLTypeEnum{Schema: "public", Name: "status", Values: []string{"ABANDONED", "ACTIVE", "ARCHIVED", "INACTIVE"}}
+	kind := psqldef.PSQLTypeEnum{Schema: "public", Name: "kind", Values: []string{"PERSON"}}
+
+	previousPeople := suite.getPeopleTable()
+	previousPeople.Columns = append(previousPeople.Columns, psqldef.TableColumn{Name: "status", Type: previousStatus, NotNull: true, Default: "'ACTIVE'"})
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = append(currentPeople.Columns,
+		psqldef.TableColumn{Name: "status", Type: currentStatus, NotNull: true, Default: "'ACTIVE'"},
+		psqldef.TableColumn{Name: "kind", Type: kind},
+	)
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[0])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+DO $$ BEGIN
+	CREATE TYPE public.kind AS ENUM ('PERSON');
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END $$;
+ALTER TYPE public.status ADD VALUE IF NOT EXISTS 'ABANDONED' BEFORE 'ACTIVE';
+ALTER TYPE public.status ADD VALUE IF NOT EXISTS 'ARCHIVED' AFTER 'ACTIVE';
+
+ALTER TABLE public.people ADD COLUMN IF NOT EXISTS kind public.kind;
+
+`, string(migrationContents))
+
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{currentPeople}, []*psqldef.Table{previousPeople})
+
+	suite.ErrorIs(diffErr, compile.ErrEnumValueRemoved)
+	suite.Nil(allDiffs)
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_DroppedEnumTypes() {
+	status := psqldef.PSQLTypeEnum{Schema: "public", Name: "status", Values: []string{"ACTIVE"}}
+	kind := psqldef.PSQLTypeEnum{Schema: "public", Name: "kind", Values: []string{"PERSON"}}
+
+	previousCompanies := suite.getCompaniesTable()
+	previousCompanies.Columns = append(previousCompanies.Columns, psqldef.TableColumn{Name: "status", Type: status})
+	previousPeople := suite.getPeopleTable()
+	previousPeople.Columns = append(previousPeople.Columns,
+		psqldef.TableColumn{Name: "status", Type: status},
+		psqldef.TableColumn{Name: "kind", Type: kind},
+	)
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = append(currentPeople.Columns, psqldef.TableColumn{Name: "status", Type: status})
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousCompanies, previousPeople}, []*psqldef.Table{currentPeople})
+
+	// Types are dropped once no remaining table uses them
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 2)
+	suite.Equal("people", allDiffs[0].Name)
+	suite.Len(allDiffs[0].DroppedEnumTypes, 1)
+	suite.Equal("kind", allDiffs[0].DroppedEnumTypes[0].Name)
+	suite.Equal("companies", allDiffs[1].Name)
+	suite.Len(allDiffs[1].DroppedEnumTypes, 0)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[0])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+ALTER TABLE public.people DROP COLUMN IF EXISTS kind;
+
+DROP TYPE IF EXISTS public.kind;
+
+`, string(migrationContents))
+
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{previousCompanies, previousPeople}, []*psqldef.Table{})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 2)
+	suite.Equal("companies", allDiffs[1].Name)
+	suite.Len(allDiffs[1].DroppedEnumTypes, 1)
+	suite.Equal("status", allDiffs[1].DroppedEnumTypes[0].Name)
+
+	migrationContents, writeErr = (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[1])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration dropping companies
+
+DROP TABLE IF EXISTS public.companies;
+
+DROP TYPE IF EXISTS public.status;
+
+`, string(migrationContents))
+}
+
+func (suite *DiffTablesTestSuite) TestDiffTables_SeedData() {
+	previousNationalities := &psqldef.Table{
+		Schema: "public",
+		Name:   "nationalities",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "key", Type: psqldef.PSQLTypeText, NotNull: true},
+		},
+		SeedData: []psqldef.InsertStatement{
+			{Schema: "public", TableName: "nationalities", Columns: []string{"key"}, Values: [][]any{{"US"}}},
+		},
+	}
+	currentNationalities := previousNationalities.DeepClone()
+	currentNationalities.SeedData[0].Values = append(currentNationalities.SeedData[0].Values, []any{"DE"})
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousNationalities}, []*psqldef.Table{&currentNationalities})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	migrationContents, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir()}).WriteMigration(allDiffs[0])
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering nationalities
+
+-- Seed Data
+INSERT INTO public.nationalities (key) VALUES ('DE');
+
+`, string(migrationContents))
+}
+
+func (suite *DiffTablesTestSuite) TestMorpheMigrationFileWriter_GetLastMigrationOrder() {
+	workingDirPath := suite.T().TempDir()
+	writer := &compile.MorpheMigrationFileWriter{
+		TargetDirPath: filepath.Join(workingDirPath, "migrations"),
+	}
+
+	lastOrder, lastOrderErr := writer.GetLastMigrationOrder()
+	suite.Nil(lastOrderErr)
+	suite.Equal(0, lastOrder)
+
+	_, writeErr := writer.WriteMigrationWithOrder(&psqldef.TableDiff{Schema: "public", Name: "contacts", Change: psqldef.TableChangeDrop}, 12)
+	suite.Nil(writeErr)
+
+	lastOrder, lastOrderErr = writer.GetLastMigrationOrder()
+	suite.Nil(lastOrderErr)
+	suite.Equal(12, lastOrder)
+}
+
+func (suite *DiffTablesTestSuite) TestMorpheMigrationFileWriter_WriteMigrationWithOrder() {
+	workingDirPath := suite.T().TempDir()
+
+	writer := &compile.MorpheMigrationFileWriter{
+		TargetDirPath: workingDirPath,
+	}
+
+	tableDiff := &psqldef.TableDiff{
+		Schema: "public",
+		Name:   "people",
+		Change: psqldef.TableChangeAlter,
+		AddedColumns: []psqldef.TableColumn{
+			{Name: "company_id", Type: psqldef.PSQLTypeInteger, NotNull: true},
+		},
+		DroppedColumns: []psqldef.TableColumn{
+			{Name: "nickname", Type: psqldef.PSQLTypeText, NotNull: true},
+		},
+		AlteredColumns: []psqldef.ColumnDiff{
+			{
+				Name:     "first_name",
+				Previous: psqldef.TableColumn{Name: "first_name", Type: psqldef.PSQLTypeText, NotNull: true},
+				Current:  psqldef.TableColumn{Name: "first_name", Type: psqldef.PSQLTypeVarchar, NotNull: false},
+			},
+		},
+		AddedForeignKeys: []psqldef.ForeignKey{
+			{
+				Name:           "fk_people_company_id",
+				TableName:      "people",
+				ColumnNames:    []string{"company_id"},
+				RefSchema:      "public",
+				RefTableName:   "companies",
+				RefColumnNames: []string{"id"},
+				OnDelete:       "CASCADE",
+			},
+		},
+		DroppedIndices: []psqldef.Index{
+			{Name: "idx_people_nickname", TableName: "people", Columns: []string{"nickname"}},
+		},
+		AddedIndices: []psqldef.Index{
+			{Name: "idx_people_company_id", TableName: "people", Columns: []string{"company_id"}},
+		},
+	}
+
+	migrationContents, writeErr := writer.WriteMigrationWithOrder(tableDiff, 3)
+
+	suite.Nil(writeErr)
+
+	expectedContents := `-- Migration altering people
+
+DROP INDEX IF EXISTS public.idx_people_nickname;
+ALTER TABLE public.people DROP COLUMN IF EXISTS nickname;
+
+ALTER TABLE public.people ADD COLUMN IF NOT EXISTS company_id INTEGER NOT NULL;
+ALTER TABLE public.people ALTER COLUMN first_name TYPE VARCHAR USING first_name::VARCHAR;
+ALTER TABLE public.people ALTER COLUMN first_name DROP NOT NULL;
+
+ALTER TABLE public.people ADD CONSTRAINT fk_people_company_id FOREIGN KEY (company_id) REFERENCES public.companies (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_people_company_id ON public.people (company_id);
+
+`
+	suite.Equal(expectedContents, string(migrationContents))
+
+	fileContents, readErr := os.ReadFile(filepath.Join(workingDirPath, "003_alter_people.sql"))
+	suite.Nil(readErr)
+	suite.Equal(expectedContents, string(fileContents))
+}
+
+func (suite *DiffTablesTestSuite) TestMorpheMigrationFileWriter_WriteMigrationWithOrder_Rollbacks() {
+	previousPeople := suite.getPeopleTable()
+	previousPeople.Columns[2].Default = "''"
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = []psqldef.TableColumn{
+		{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+		{Name: "first_name", Type: psqldef.PSQLTypeVarchar, NotNull: true},
+		{Name: "kind", Type: psqldef.PSQLTypeText},
+	}
+	currentPeople.SeedData = []psqldef.InsertStatement{
+		{Schema: "public", TableName: "people", Columns: []string{"first_name", "kind"}, Values: [][]any{{"Root", "ADMIN"}}},
+	}
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	workingDirPath := suite.T().TempDir()
+	writer := &compile.MorpheMigrationFileWriter{
+		TargetDirPath:   workingDirPath,
+		EnableRollbacks: true,
+	}
+
+	migrationContents, writeErr := writer.WriteMigrationWithOrder(allDiffs[0], 2)
+
+	suite.Nil(writeErr)
+	suite.Equal(`-- Migration altering people
+
+ALTER TABLE public.people DROP COLUMN IF EXISTS nickname;
+
+ALTER TABLE public.people ADD COLUMN IF NOT EXISTS kind TEXT;
+ALTER TABLE public.people ALTER COLUMN first_name TYPE VARCHAR USING first_name::VARCHAR;
+
+-- Seed Data
+INSERT INTO public.people (first_name, kind) VALUES ('Root', 'ADMIN');
+
+`, string(migrationContents))
+	suite.FileExists(filepath.Join(workingDirPath, "002_alter_people.up.sql"))
+
+	// The rollback deletes the seed rows before dropping the columns they are matched by
+	rollbackContents, readErr := os.ReadFile(filepath.Join(workingDirPath, "002_alter_people.down.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Migration altering people
+
+-- Seed Data
+DELETE FROM public.people WHERE first_name = 'Root' AND kind = 'ADMIN';
+
+ALTER TABLE public.people DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE public.people ADD COLUMN IF NOT EXISTS nickname TEXT NOT NULL DEFAULT '';
+ALTER TABLE public.people ALTER COLUMN first_name TYPE TEXT USING first_name::TEXT;
+
+`, string(rollbackContents))
+}
+
+func (suite *DiffTablesTestSuite) TestInvertTableDiff() {
+	status := psqldef.PSQLTypeEnum{Schema: "public", Name: "status", Values: []string{"ACTIVE"}}
+	cleanupTrigger := psqldef.Trigger{Schema: "public", Name: "trg_cleanup_comments_commentable", TableName: "people"}
+	comments := &psqldef.Table{
+		Schema:  "public",
+		Name:    "comments",
+		Columns: []psqldef.TableColumn{{Name: "status", Type: status}},
+		Functions: []psqldef.Function{
+			{Schema: "public", Name: "cleanup_comments_commentable"},
+			{Schema: "public", Name: "set_updated_at", Shared: true},
+		},
+		Triggers: []psqldef.Trigger{cleanupTrigger},
+	}
+
+	// Created tables are dropped with their own functions and enum types
+	invertedDiff, invertErr := compile.InvertTableDiff(&psqldef.TableDiff{
+		Schema:         "public",
+		Name:           "comments",
+		Change:         psqldef.TableChangeCreate,
+		Table:          comments,
+		AddedEnumTypes: []psqldef.PSQLTypeEnum{status},
+	})
+
+	suite.Nil(invertErr)
+	suite.Equal(psqldef.TableChangeDrop, invertedDiff.Change)
+	suite.Len(invertedDiff.DroppedEnumTypes, 1)
+	suite.Len(invertedDiff.DroppedFunctions, 1)
+	suite.Equal("cleanup_comments_commentable", invertedDiff.DroppedFunctions[0].Name)
+
+	// Dropped tables are recreated with the triggers dropped from remaining tables
+	invertedDiff, invertErr = compile.InvertTableDiff(&psqldef.TableDiff{
+		Schema:           "public",
+		Name:             "comments",
+		Change:           psqldef.TableChangeDrop,
+		Table:            comments,
+		DroppedTriggers:  []psqldef.Trigger{cleanupTrigger},
+		DroppedEnumTypes: []psqldef.PSQLTypeEnum{status},
+	})
+
+	suite.Nil(invertErr)
+	suite.Equal(psqldef.TableChangeCreate, invertedDiff.Change)
+	suite.Len(invertedDiff.AddedEnumTypes, 1)
+	suite.Len(invertedDiff.AddedTriggers, 1)
+
+	// Triggers created on other tables are dropped
+	invertedDiff, invertErr = compile.InvertTableDiff(&psqldef.TableDiff{
+		Schema:        "public",
+		Name:          "comments",
+		Change:        psqldef.TableChangeTriggers,
+		Table:         comments,
+		AddedTriggers: []psqldef.Trigger{cleanupTrigger},
+	})
+
+	suite.Nil(invertErr)
+	suite.Equal(psqldef.TableChangeAlter, invertedDiff.Change)
+	suite.Len(invertedDiff.DroppedTriggers, 1)
+}
+
+func (suite *DiffTablesTestSuite) TestInvertTableDiff_Irreversible() {
+	previousStatus := psqldef.PSQLTypeEnum{Schema: "public", Name: "status", Values: []string{"ACTIVE"}}
+	currentStatus := psqldef.PSQLTypeEnum{Schema: "public", Name: "status", Values: []string{"ACTIVE", "ARCHIVED"}}
+	previousPeople := suite.getPeopleTable()
+	previousPeople.Columns = append(previousPeople.Columns, psqldef.TableColumn{Name: "status", Type: previousStatus})
+	currentPeople := suite.getPeopleTable()
+	currentPeople.Columns = append(currentPeople.Columns, psqldef.TableColumn{Name: "status", Type: currentStatus})
+
+	allDiffs, diffErr := compile.DiffTables([]*psqldef.Table{previousPeople}, []*psqldef.Table{currentPeople})
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	// PostgreSQL cannot remove enum values
+	invertedDiff, invertErr := compile.InvertTableDiff(allDiffs[0])
+
+	suite.ErrorIs(invertErr, compile.ErrIrreversibleMigration)
+	suite.ErrorContains(invertErr, "public.status")
+	suite.Nil(invertedDiff)
+
+	// Dropped NOT NULL columns without a default cannot be added back to existing rows
+	currentPeople = suite.getPeopleTable()
+	currentPeople.Columns = currentPeople.Columns[:2]
+
+	allDiffs, diffErr = compile.DiffTables([]*psqldef.Table{suite.getPeopleTable()}, []*psqldef.Table{currentPeople})
+	suite.Nil(diffErr)
+	suite.Len(allDiffs, 1)
+
+	invertedDiff, invertErr = compile.InvertTableDiff(allDiffs[0])
+
+	suite.ErrorIs(invertErr, compile.ErrIrreversibleMigration)
+	suite.ErrorIs(invertErr, compile.ErrNotNullColumnWithoutDefault)
+	suite.Nil(invertedDiff)
+
+	_, writeErr := (&compile.MorpheMigrationFileWriter{TargetDirPath: suite.T().TempDir(), EnableRollbacks: true}).WriteMigration(allDiffs[0])
+
+	suite.ErrorIs(writeErr, compile.ErrIrreversibleMigration)
+}
+
+func (suite *DiffTablesTestSuite) TestMorpheMigrationFileWriter_WriteMigration_DropTable() {
+	workingDirPath := suite.T().TempDir()
+
+	writer := &compile.MorpheMigrationFileWriter{
+		TargetDirPath: workingDirPath,
+	}
+
+	tableDiff := &psqldef.TableDiff{
+		Schema: "public",
+		Name:   "contacts",
+		Change: psqldef.TableChangeDrop,
+	}
+
+	migrationContents, writeErr := writer.WriteMigration(tableDiff)
+
+	suite.Nil(writeErr)
+	suite.Equal("-- Migration dropping contacts\n\nDROP TABLE IF EXISTS public.contacts;\n\n", string(migrationContents))
+	suite.FileExists(filepath.Join(workingDirPath, "drop_contacts.sql"))
+}
@@ -0,0 +1,127 @@
+package compile
+
+import (
+	"slices"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// DiffViews compares the previous set of view definitions against the current one and returns the view diffs
+// required to migrate the database around the given table diffs.
+//
+// The drop diffs run before the table migrations and drop removed and changed views, as well as views selecting from
+// tables that are dropped or drop or retype columns, which PostgreSQL rejects while a view depends on them. The create
+// diffs run after the table migrations and (re)create the new, changed and dropped current views.
+func DiffViews(previousViews []*psqldef.View, currentViews []*psqldef.View, allTableDiffs []*psqldef.TableDiff) ([]*psqldef.ViewDiff, []*psqldef.ViewDiff, error) {
+	previousViewMap := make(map[string]*psqldef.View, len(previousViews))
+	for _, previousView := range previousViews {
+		previousViewMap[getQualifiedName(previousView.Schema, previousView.Name)] = previousView
+	}
+	currentViewMap := make(map[string]*psqldef.View, len(currentViews))
+	for _, currentView := range currentViews {
+		currentViewMap[getQualifiedName(currentView.Schema, currentView.Name)] = currentView
+	}
+
+	recreatedTableNames := getViewDependentTableNames(allTableDiffs)
+
+	dropDiffs := []*psqldef.ViewDiff{}
+	droppedViewNames := map[string]bool{}
+	for _, previousView := range previousViews {
+		viewName := getQualifiedName(previousView.Schema, previousView.Name)
+		currentView, currentExists := currentViewMap[viewName]
+		if currentExists && !isViewSelectingFrom(previousView, recreatedTableNames) {
+			viewEqual, viewEqualErr := isViewEqual(previousView, currentView)
+			if viewEqualErr != nil {
+				return nil, nil, viewEqualErr
+			}
+			if viewEqual {
+				continue
+			}
+		}
+
+		viewClone := previousView.DeepClone()
+		dropDiffs = append(dropDiffs, &psqldef.ViewDiff{
+			Schema: previousView.Schema,
+			Name:   previousView.Name,
+			Change: psqldef.ViewChangeDrop,
+			View:   &viewClone,
+		})
+		droppedViewNames[viewName] = true
+	}
+
+	createDiffs := []*psqldef.ViewDiff{}
+	for _, currentView := range currentViews {
+		viewName := getQualifiedName(currentView.Schema, currentView.Name)
+		if _, previousExists := previousViewMap[viewName]; previousExists && !droppedViewNames[viewName] {
+			continue
+		}
+
+		viewClone := currentView.DeepClone()
+		createDiffs = append(createDiffs, &psqldef.ViewDiff{
+			Schema: currentView.Schema,
+			Name:   currentView.Name,
+			Change: psqldef.ViewChangeCreate,
+			View:   &viewClone,
+		})
+	}
+
+	return dropDiffs, createDiffs, nil
+}
+
+// InvertViewDiff returns the view diff rolling back a view diff, recreating dropped views and dropping created ones
+func InvertViewDiff(viewDiff *psqldef.ViewDiff) *psqldef.ViewDiff {
+	invertedDiff := viewDiff.DeepClone()
+	invertedDiff.Change = psqldef.ViewChangeDrop
+	if viewDiff.Change == psqldef.ViewChangeDrop {
+		invertedDiff.Change = psqldef.ViewChangeCreate
+	}
+	return &invertedDiff
+}
+
+// getViewDependentTableNames returns the qualified names of the tables whose migrations fail while views select from
+// them, i.e. dropped tables and tables dropping or retyping columns
+func getViewDependentTableNames(allTableDiffs []*psqldef.TableDiff) map[string]bool {
+	tableNames := map[string]bool{}
+	for _, tableDiff := range allTableDiffs {
+		tableName := getViewSourceTableName(tableDiff.Schema, tableDiff.Name)
+		if tableDiff.Change == psqldef.TableChangeDrop || len(tableDiff.DroppedColumns) > 0 {
+			tableNames[tableName] = true
+			continue
+		}
+		if slices.ContainsFunc(tableDiff.AlteredColumns, psqldef.ColumnDiff.TypeChanged) {
+			tableNames[tableName] = true
+		}
+	}
+	return tableNames
+}
+
+func isViewSelectingFrom(view *psqldef.View, tableNames map[string]bool) bool {
+	if tableNames[getViewSourceTableName(view.FromSchema, view.FromTable)] {
+		return true
+	}
+	return slices.ContainsFunc(view.Joins, func(join psqldef.JoinClause) bool {
+		return tableNames[getViewSourceTableName(join.Schema, join.Table)]
+	})
+}
+
+// getViewSourceTableName returns the qualified name of a table, defaulting to the public schema like view sources
+func getViewSourceTableName(schema string, tableName string) string {
+	if schema == "" {
+		schema = "public"
+	}
+	return schema + "." + tableName
+}
+
+// isViewEqual compares the written view definitions, independent of how the definitions were read
+func isViewEqual(previousView *psqldef.View, currentView *psqldef.View) (bool, error) {
+	viewWriter := &MorpheViewFileWriter{}
+	previousLines, previousLinesErr := viewWriter.getAllViewLines(previousView)
+	if previousLinesErr != nil {
+		return false, previousLinesErr
+	}
+	currentLines, currentLinesErr := viewWriter.getAllViewLines(currentView)
+	if currentLinesErr != nil {
+		return false, currentLinesErr
+	}
+	return slices.Equal(previousLines, currentLines), nil
+}
@@ -0,0 +1,241 @@
+package compile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffViewsTestSuite struct {
+	suite.Suite
+}
+
+func TestDiffViewsTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffViewsTestSuite))
+}
+
+func (suite *DiffViewsTestSuite) getPersonEntitiesView() *psqldef.View {
+	return &psqldef.View{
+		Schema: "public",
+		Name:   "person_entities",
+		Columns: []psqldef.ViewColumn{
+			{Name: "id", SourceRef: "people.id"},
+			{Name: "company_name", SourceRef: "companies.name"},
+		},
+		FromSchema: "public",
+		FromTable:  "people",
+		Joins: []psqldef.JoinClause{
+			{
+				Type:   "LEFT",
+				Schema: "public",
+				Table:  "companies",
+				Conditions: []psqldef.JoinCondition{
+					{LeftRef: "people.company_id", RightRef: "companies.id"},
+				},
+			},
+		},
+	}
+}
+
+func (suite *DiffViewsTestSuite) TestDiffViews_NoChanges() {
+	tableDiffs := []*psqldef.TableDiff{
+		{
+			Schema:       "public",
+			Name:         "companies",
+			Change:       psqldef.TableChangeAlter,
+			AddedColumns: []psqldef.TableColumn{{Name: "website", Type: psqldef.PSQLTypeText}},
+		},
+	}
+
+	dropDiffs, createDiffs, diffErr := compile.DiffViews(
+		[]*psqldef.View{suite.getPersonEntitiesView()}, []*psqldef.View{suite.getPersonEntitiesView()}, tableDiffs)
+
+	// Added columns do not affect the views selecting from the table
+	suite.Nil(diffErr)
+	suite.Len(dropDiffs, 0)
+	suite.Len(createDiffs, 0)
+}
+
+func (suite *DiffViewsTestSuite) TestDiffViews_CreateAndDropViews() {
+	companyEntities := &psqldef.View{
+		Schema:     "public",
+		Name:       "company_entities",
+		Columns:    []psqldef.ViewColumn{{Name: "id", SourceRef: "companies.id"}},
+		FromSchema: "public",
+		FromTable:  "companies",
+	}
+
+	dropDiffs, createDiffs, diffErr := compile.DiffViews(
+		[]*psqldef.View{companyEntities}, []*psqldef.View{suite.getPersonEntitiesView()}, nil)
+
+	suite.Nil(diffErr)
+	suite.Len(dropDiffs, 1)
+	suite.Equal("company_entities", dropDiffs[0].Name)
+	suite.Equal(psqldef.ViewChangeDrop, dropDiffs[0].Change)
+	suite.Len(createDiffs, 1)
+	suite.Equal("person_entities", createDiffs[0].Name)
+	suite.Equal(psqldef.ViewChangeCreate, createDiffs[0].Change)
+}
+
+func (suite *DiffViewsTestSuite) TestDiffViews_ChangedView() {
+	currentView := suite.getPersonEntitiesView()
+	currentView.Columns = currentView.Columns[:1]
+
+	dropDiffs, createDiffs, diffErr := compile.DiffViews(
+		[]*psqldef.View{suite.getPersonEntitiesView()}, []*psqldef.View{currentView}, nil)
+
+	// Views cannot drop columns in place, so changed views are dropped and recreated
+	suite.Nil(diffErr)
+	suite.Len(dropDiffs, 1)
+	suite.Len(dropDiffs[0].View.Columns, 2)
+	suite.Len(createDiffs, 1)
+	suite.Len(createDiffs[0].View.Columns, 1)
+}
+
+func (suite *DiffViewsTestSuite) TestDiffViews_AlteredTables() {
+	tableDiffs := []*psqldef.TableDiff{
+		{
+			Schema: "public",
+			Name:   "companies",
+			Change: psqldef.TableChangeAlter,
+			AlteredColumns: []psqldef.ColumnDiff{
+				{
+					Name:     "name",
+					Previous: psqldef.TableColumn{Name: "name", Type: psqldef.PSQLTypeText},
+					Current:  psqldef.TableColumn{Name: "name", Type: psqldef.PSQLTypeVarchar},
+				},
+			},
+		},
+	}
+
+	dropDiffs, createDiffs, diffErr := compile.DiffViews(
+		[]*psqldef.View{suite.getPersonEntitiesView()}, []*psqldef.View{suite.getPersonEntitiesView()}, tableDiffs)
+
+	// Unchanged views joining a retyped column are recreated around the table migrations
+	suite.Nil(diffErr)
+	suite.Len(dropDiffs, 1)
+	suite.Equal("person_entities", dropDiffs[0].Name)
+	suite.Len(createDiffs, 1)
+	suite.Equal("person_entities", createDiffs[0].Name)
+
+	tableDiffs = []*psqldef.TableDiff{
+		{
+			Schema:         "public",
+			Name:           "people",
+			Change:         psqldef.TableChangeAlter,
+			DroppedColumns: []psqldef.TableColumn{{Name: "nickname", Type: psqldef.PSQLTypeText}},
+		},
+	}
+
+	dropDiffs, createDiffs, diffErr = compile.DiffViews(
+		[]*psqldef.View{suite.getPersonEntitiesView()}, []*psqldef.View{suite.getPersonEntitiesView()}, tableDiffs)
+
+	suite.Nil(diffErr)
+	suite.Len(dropDiffs, 1)
+	suite.Len(createDiffs, 1)
+}
+
+func (suite *DiffViewsTestSuite) TestMorpheMigrationFileWriter_WriteViewMigrationWithOrder() {
+	workingDirPath := suite.T().TempDir()
+	writer := &compile.MorpheMigrationFileWriter{TargetDirPath: workingDirPath}
+
+	dropContents, dropErr := writer.WriteViewMigrationWithOrder(&psqldef.ViewDiff{
+		Schema: "public",
+		Name:   "person_entities",
+		Change: psqldef.ViewChangeDrop,
+		View:   suite.getPersonEntitiesView(),
+	}, 1)
+
+	suite.Nil(dropErr)
+	suite.Equal(`-- Rollback of view definition for person_entities
+
+DROP VIEW IF EXISTS public.person_entities;
+
+`, string(dropContents))
+	suite.FileExists(filepath.Join(workingDirPath, "001_drop_view_person_entities.sql"))
+
+	createContents, createErr := writer.WriteViewMigrationWithOrder(&psqldef.ViewDiff{
+		Schema: "public",
+		Name:   "person_entities",
+		Change: psqldef.ViewChangeCreate,
+		View:   suite.getPersonEntitiesView(),
+	}, 3)
+
+	suite.Nil(createErr)
+	suite.Equal(`-- View definition for person_entities
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE OR REPLACE VIEW public.person_entities AS
+SELECT
+	people.id,
+	companies.name AS company_name
+FROM public.people
+LEFT JOIN public.companies
+	ON people.company_id = companies.id;
+
+`, string(createContents))
+	suite.FileExists(filepath.Join(workingDirPath, "003_create_view_person_entities.sql"))
+}
+
+func (suite *DiffViewsTestSuite) TestMorpheMigrationFileWriter_WriteViewMigrationWithOrder_Rollbacks() {
+	workingDirPath := suite.T().TempDir()
+	writer := &compile.MorpheMigrationFileWriter{
+		TargetDirPath:   workingDirPath,
+		EnableRollbacks: true,
+	}
+
+	_, dropErr := writer.WriteViewMigrationWithOrder(&psqldef.ViewDiff{
+		Schema: "public",
+		Name:   "person_entities",
+		Change: psqldef.ViewChangeDrop,
+		View:   suite.getPersonEntitiesView(),
+	}, 1)
+
+	// Rolling back a dropped view recreates its previous definition
+	suite.Nil(dropErr)
+	suite.FileExists(filepath.Join(workingDirPath, "001_drop_view_person_entities.up.sql"))
+	rollbackContents, readErr := os.ReadFile(filepath.Join(workingDirPath, "001_drop_view_person_entities.down.sql"))
+	suite.Nil(readErr)
+	suite.Contains(string(rollbackContents), "CREATE OR REPLACE VIEW public.person_entities AS")
+
+	_, createErr := writer.WriteViewMigrationWithOrder(&psqldef.ViewDiff{
+		Schema: "public",
+		Name:   "person_entities",
+		Change: psqldef.ViewChangeCreate,
+		View:   suite.getPersonEntitiesView(),
+	}, 3)
+
+	suite.Nil(createErr)
+	rollbackContents, readErr = os.ReadFile(filepath.Join(workingDirPath, "003_create_view_person_entities.down.sql"))
+	suite.Nil(readErr)
+	suite.Contains(string(rollbackContents), "DROP VIEW IF EXISTS public.person_entities;")
+}
+
+func (suite *DiffViewsTestSuite) TestWriteAllViewMigrations_NoViewMigrationWriter() {
+	config := compile.MorpheCompileConfig{
+		MigrationWriter: &tableOnlyMigrationWriter{},
+	}
+	viewDiffs := []*psqldef.ViewDiff{
+		{Schema: "public", Name: "person_entities", Change: psqldef.ViewChangeDrop, View: suite.getPersonEntitiesView()},
+	}
+
+	_, _, writeErr := compile.WriteAllViewMigrations(config, viewDiffs, 0)
+
+	suite.ErrorIs(writeErr, compile.ErrNoViewMigrationWriter)
+
+	_, nextOrder, writeErr := compile.WriteAllViewMigrations(config, nil, 4)
+
+	suite.Nil(writeErr)
+	suite.Equal(4, nextOrder)
+}
+
+type tableOnlyMigrationWriter struct{}
+
+func (w *tableOnlyMigrationWriter) WriteMigration(*psqldef.TableDiff) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,16 @@
+package hook
+
+import (
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+type WritePSQLEnumType struct {
+	OnWritePSQLEnumTypeStart   OnWritePSQLEnumTypeStartHook
+	OnWritePSQLEnumTypeSuccess OnWritePSQLEnumTypeSuccessHook
+	OnWritePSQLEnumTypeFailure OnWritePSQLEnumTypeFailureHook
+}
+
+type OnWritePSQLEnumTypeStartHook = func(writer write.PSQLEnumTypeWriter, enumType *psqldef.PSQLTypeEnum) (write.PSQLEnumTypeWriter, *psqldef.PSQLTypeEnum, error)
+type OnWritePSQLEnumTypeSuccessHook = func(enumType *psqldef.PSQLTypeEnum, enumTypeContents []byte) (*psqldef.PSQLTypeEnum, []byte, error)
+type OnWritePSQLEnumTypeFailureHook = func(writer write.PSQLEnumTypeWriter, enumType *psqldef.PSQLTypeEnum, failureErr error) error
@@ -0,0 +1,16 @@
+package hook
+
+import (
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+type WritePSQLMigration struct {
+	OnWritePSQLMigrationStart   OnWritePSQLMigrationStartHook
+	OnWritePSQLMigrationSuccess OnWritePSQLMigrationSuccessHook
+	OnWritePSQLMigrationFailure OnWritePSQLMigrationFailureHook
+}
+
+type OnWritePSQLMigrationStartHook = func(writer write.PSQLMigrationWriter, tableDiff *psqldef.TableDiff) (write.PSQLMigrationWriter, *psqldef.TableDiff, error)
+type OnWritePSQLMigrationSuccessHook = func(tableDiff *psqldef.TableDiff, migrationContents []byte) (*psqldef.TableDiff, []byte, error)
+type OnWritePSQLMigrationFailureHook = func(writer write.PSQLMigrationWriter, tableDiff *psqldef.TableDiff, failureErr error) error
@@ -0,0 +1,100 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// validateJunctionFieldRelations validates that junction fields are only declared for a model's ForMany and
+// ForManyPoly relations
+func validateJunctionFieldRelations(modelsConfig cfg.MorpheModelsConfig, model yaml.Model) error {
+	relationsOptions := modelsConfig.ModelOptions[model.Name].Relations
+	for _, relationName := range core.MapKeysSorted(relationsOptions) {
+		if len(relationsOptions[relationName].Fields) == 0 {
+			continue
+		}
+		relation, relationExists := model.Related[relationName]
+		if !relationExists || !yamlops.IsRelationFor(relation.Type) || !yamlops.IsRelationMany(relation.Type) {
+			return fmt.Errorf("%w: %s.%s", ErrJunctionFieldsWithoutJunctionTable, model.Name, relationName)
+		}
+	}
+	return nil
+}
+
+// addJunctionFields adds the attribute columns declared for a relation to its junction table, compiled like model
+// fields, and indexes the relation's order field alongside the source columns
+func addJunctionFields(config cfg.MorpheConfig, r *registry.Registry, table *psqldef.Table, sourceColumnNames []string, relationOptions cfg.MorpheRelationOptions) error {
+	if len(relationOptions.Fields) == 0 {
+		return nil
+	}
+
+	typeMap, _ := getModelFieldTypeMaps(config.MorpheModelsConfig)
+	fieldColumns, enumForeignKeys, checkConstraints, fieldColumnsErr := getColumnsForModelFields(config, r, typeMap, table.Name, yaml.ModelIdentifier{}, relationOptions.Fields)
+	if fieldColumnsErr != nil {
+		return fieldColumnsErr
+	}
+
+	for _, fieldColumn := range fieldColumns {
+		for _, column := range table.Columns {
+			if column.Name == fieldColumn.Name {
+				return fmt.Errorf("%w: %s.%s", ErrJunctionFieldConflict, table.Name, column.Name)
+			}
+		}
+	}
+
+	table.Columns = append(table.Columns, fieldColumns...)
+	table.ForeignKeys = append(table.ForeignKeys, enumForeignKeys...)
+	table.Indices = append(table.Indices, getIndicesForForeignKeys(table.Schema, table.Name, enumForeignKeys)...)
+	table.CheckConstraints = append(table.CheckConstraints, checkConstraints...)
+
+	if relationOptions.OrderField != "" {
+		orderColumnNames := append(slices.Clone(sourceColumnNames), GetColumnNameFromField(relationOptions.OrderField))
+		table.Indices = append(table.Indices, psqldef.Index{
+			Name:      GetIndexName(table.Name, strings.Join(orderColumnNames, "_")),
+			TableName: table.Name,
+			Columns:   orderColumnNames,
+		})
+	}
+	return nil
+}
+
+// addJunctionFieldViewColumns exposes the attribute columns of a joined junction table in the view, prefixed with
+// the alias of the table joined through it, projecting lookup table enums by their key or value
+func addJunctionFieldViewColumns(ctx *entityCompileContext, junction forManyJunction, junctionAlias string, joinAlias string) error {
+	fields := ctx.config.MorpheModelsConfig.ModelOptions[junction.OwnerModelName].Relations[junction.OwnerRelationName].Fields
+	for _, fieldName := range core.MapKeysSorted(fields) {
+		columnName := joinAlias + "_" + strcase.ToSnakeCaseLower(fieldName)
+		for _, column := range ctx.view.Columns {
+			if column.Name == columnName {
+				return fmt.Errorf("%w: %s.%s", ErrJunctionFieldConflict, ctx.view.Name, columnName)
+			}
+		}
+
+		enum, enumErr := ctx.registry.GetEnum(string(fields[fieldName].Type))
+		if enumErr != nil || ctx.config.MorpheEnumsConfig.UseNativeEnums {
+			addRegularColumn(ctx, columnName, junctionAlias, fieldName)
+			continue
+		}
+
+		enumTableAlias := AbbreviateIdentifier(junctionAlias+"_"+GetColumnNameFromField(fieldName), true)
+		addEnumJoinClause(ctx, enumTableAlias, joinInfo{
+			sourceTableName: junctionAlias,
+			enumName:        enum.Name,
+			enumFieldName:   fieldName,
+		})
+		ctx.view.Columns = append(ctx.view.Columns, psqldef.ViewColumn{
+			Name:      columnName,
+			SourceRef: enumTableAlias + "." + ctx.config.MorpheEntitiesConfig.GetEnumColumn(),
+		})
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/hook"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 )
 
 type MorpheCompileConfig struct {
@@ -19,8 +20,9 @@ type MorpheCompileConfig struct {
 	ModelWriter write.PSQLTableWriter
 	ModelHooks  hook.CompileMorpheModel
 
-	EnumWriter write.PSQLTableWriter
-	EnumHooks  hook.CompileMorpheEnum
+	EnumWriter     write.PSQLTableWriter
+	EnumTypeWriter write.PSQLEnumTypeWriter
+	EnumHooks      hook.CompileMorpheEnum
 
 	StructureWriter write.PSQLTableWriter
 	StructureHooks  hook.CompileMorpheStructure
@@ -28,8 +30,24 @@ type MorpheCompileConfig struct {
 	EntityWriter write.PSQLViewWriter
 	EntityHooks  hook.CompileMorpheEntity
 
-	WriteTableHooks hook.WritePSQLTable
-	WriteViewHooks  hook.WritePSQLView
+	MigrationWriter write.PSQLMigrationWriter
+	SnapshotWriter  write.PSQLSnapshotWriter
+
+	// SchemaWriter writes all definitions as a single dependency-ordered schema. When set, it is used instead of
+	// the enum, model, structure and entity writers, and the write hooks receive the consolidated schema contents.
+	SchemaWriter write.PSQLSchemaWriter
+
+	WriteTableHooks     hook.WritePSQLTable
+	WriteEnumTypeHooks  hook.WritePSQLEnumType
+	WriteViewHooks      hook.WritePSQLView
+	WriteMigrationHooks hook.WritePSQLMigration
+
+	// PreviousTables are the table definitions of a previous compilation. When set, the compiled tables are
+	// diffed against them and ALTER TABLE migrations are written with the MigrationWriter.
+	PreviousTables []*psqldef.Table
+	// PreviousViews are the view definitions of the same previous compilation. Views are dropped and recreated
+	// around the table migrations when they change or the tables they select from drop or retype columns.
+	PreviousViews []*psqldef.View
 
 	// EnableOrderedMigrations enables numeric prefixes on output files (e.g., 001_users.sql)
 	// to ensure correct dependency ordering for database migrations.
@@ -91,6 +109,9 @@ func DefaultMorpheCompileConfig(
 		EnumWriter: &MorpheTableFileWriter{
 			TargetDirPath: path.Join(baseOutputDirPath, "enums"),
 		},
+		EnumTypeWriter: &MorpheEnumTypeFileWriter{
+			TargetDirPath: path.Join(baseOutputDirPath, "enums"),
+		},
 		EnumHooks: hook.CompileMorpheEnum{},
 
 		ModelWriter: &MorpheTableFileWriter{
@@ -103,8 +124,18 @@ func DefaultMorpheCompileConfig(
 		},
 		EntityHooks: hook.CompileMorpheEntity{},
 
-		WriteTableHooks: hook.WritePSQLTable{},
-		WriteViewHooks:  hook.WritePSQLView{},
+		MigrationWriter: &MorpheMigrationFileWriter{
+			TargetDirPath: path.Join(baseOutputDirPath, "migrations"),
+		},
+
+		SnapshotWriter: &MorpheSnapshotFileWriter{
+			TargetFilePath: path.Join(baseOutputDirPath, SchemaSnapshotFileName),
+		},
+
+		WriteTableHooks:     hook.WritePSQLTable{},
+		WriteEnumTypeHooks:  hook.WritePSQLEnumType{},
+		WriteViewHooks:      hook.WritePSQLView{},
+		WriteMigrationHooks: hook.WritePSQLMigration{},
 
 		StructureWriter: &MorpheTableFileWriter{
 			TargetDirPath: path.Join(baseOutputDirPath, "structures"),
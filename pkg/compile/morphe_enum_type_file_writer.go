@@ -0,0 +1,92 @@
+package compile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/sqlfile"
+)
+
+type MorpheEnumTypeFileWriter struct {
+	TargetDirPath string
+
+	// EnableRollbacks writes each enum type as an up/down migration pair (e.g., "001_nationality.up.sql" and
+	// "001_nationality.down.sql") instead of a single definition file.
+	EnableRollbacks bool
+}
+
+func (w *MorpheEnumTypeFileWriter) WriteEnumType(enumType *psqldef.PSQLTypeEnum) ([]byte, error) {
+	return w.WriteEnumTypeWithOrder(enumType, 0)
+}
+
+func (w *MorpheEnumTypeFileWriter) WriteEnumTypeWithOrder(enumType *psqldef.PSQLTypeEnum, order int) ([]byte, error) {
+	allEnumTypeLines, allLinesErr := w.getAllEnumTypeLines(enumType)
+	if allLinesErr != nil {
+		return nil, allLinesErr
+	}
+
+	enumTypeFileContents, enumTypeContentsErr := core.LinesToString(allEnumTypeLines)
+	if enumTypeContentsErr != nil {
+		return nil, enumTypeContentsErr
+	}
+
+	if !w.EnableRollbacks {
+		return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, enumType.Name, enumTypeFileContents, order)
+	}
+
+	rollbackFileContents, rollbackContentsErr := core.LinesToString([]string{
+		fmt.Sprintf("-- Rollback of enum type definition for %s", enumType.Name),
+		"",
+		fmt.Sprintf("DROP TYPE IF EXISTS %s;", enumType.GetSyntax()),
+		"",
+	})
+	if rollbackContentsErr != nil {
+		return nil, rollbackContentsErr
+	}
+
+	return sqlfile.WriteSQLMigrationFilesWithOrder(w.TargetDirPath, enumType.Name, enumTypeFileContents, rollbackFileContents, order)
+}
+
+func (w *MorpheEnumTypeFileWriter) getAllEnumTypeLines(enumType *psqldef.PSQLTypeEnum) ([]string, error) {
+	if enumType == nil {
+		return nil, ErrNoEnumType
+	}
+	if len(enumType.Values) == 0 {
+		return nil, fmt.Errorf("enum type '%s' has no values", enumType.Name)
+	}
+
+	allEnumTypeLines := []string{}
+
+	// Add header comment
+	allEnumTypeLines = append(allEnumTypeLines, fmt.Sprintf("-- Enum type definition for %s", enumType.Name))
+	allEnumTypeLines = append(allEnumTypeLines, "")
+
+	// Create schema if specified
+	if enumType.Schema != "" {
+		allEnumTypeLines = append(allEnumTypeLines, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", enumType.Schema))
+		allEnumTypeLines = append(allEnumTypeLines, "")
+	}
+
+	allEnumTypeLines = append(allEnumTypeLines, w.getCreateEnumTypeLines(enumType)...)
+	allEnumTypeLines = append(allEnumTypeLines, "")
+
+	return allEnumTypeLines, nil
+}
+
+// getCreateEnumTypeLines wraps CREATE TYPE in a DO block, since PostgreSQL has no CREATE TYPE IF NOT EXISTS
+func (w *MorpheEnumTypeFileWriter) getCreateEnumTypeLines(enumType *psqldef.PSQLTypeEnum) []string {
+	enumValues := make([]string, len(enumType.Values))
+	for valueIdx, value := range enumType.Values {
+		enumValues[valueIdx] = fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+	}
+
+	return []string{
+		"DO $$ BEGIN",
+		fmt.Sprintf("\tCREATE TYPE %s AS ENUM (%s);", enumType.GetSyntax(), strings.Join(enumValues, ", ")),
+		"EXCEPTION",
+		"\tWHEN duplicate_object THEN NULL;",
+		"END $$;",
+	}
+}
@@ -0,0 +1,504 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/sqlfile"
+)
+
+type MorpheMigrationFileWriter struct {
+	TargetDirPath string
+
+	// EnableRollbacks writes each migration as an up/down pair (e.g., "003_alter_people.up.sql" and
+	// "003_alter_people.down.sql"), failing for migrations that cannot be rolled back.
+	EnableRollbacks bool
+}
+
+func (w *MorpheMigrationFileWriter) WriteMigration(tableDiff *psqldef.TableDiff) ([]byte, error) {
+	return w.WriteMigrationWithOrder(tableDiff, 0)
+}
+
+func (w *MorpheMigrationFileWriter) WriteMigrationWithOrder(tableDiff *psqldef.TableDiff, order int) ([]byte, error) {
+	allMigrationLines, allLinesErr := w.getAllMigrationLines(tableDiff)
+	if allLinesErr != nil {
+		return nil, allLinesErr
+	}
+
+	migrationFileContents, migrationContentsErr := core.LinesToString(allMigrationLines)
+	if migrationContentsErr != nil {
+		return nil, migrationContentsErr
+	}
+
+	migrationName := string(tableDiff.Change) + "_" + tableDiff.Name
+	if !w.EnableRollbacks {
+		return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, migrationName, migrationFileContents, order)
+	}
+
+	rollbackDiff, invertErr := InvertTableDiff(tableDiff)
+	if invertErr != nil {
+		return nil, invertErr
+	}
+	rollbackLines, rollbackLinesErr := w.getAllMigrationLines(rollbackDiff)
+	if rollbackLinesErr != nil {
+		return nil, rollbackLinesErr
+	}
+	rollbackFileContents, rollbackContentsErr := core.LinesToString(rollbackLines)
+	if rollbackContentsErr != nil {
+		return nil, rollbackContentsErr
+	}
+	return sqlfile.WriteSQLMigrationFilesWithOrder(w.TargetDirPath, migrationName, migrationFileContents, rollbackFileContents, order)
+}
+
+func (w *MorpheMigrationFileWriter) WriteViewMigration(viewDiff *psqldef.ViewDiff) ([]byte, error) {
+	return w.WriteViewMigrationWithOrder(viewDiff, 0)
+}
+
+func (w *MorpheMigrationFileWriter) WriteViewMigrationWithOrder(viewDiff *psqldef.ViewDiff, order int) ([]byte, error) {
+	allMigrationLines, allLinesErr := w.getAllViewMigrationLines(viewDiff)
+	if allLinesErr != nil {
+		return nil, allLinesErr
+	}
+
+	migrationFileContents, migrationContentsErr := core.LinesToString(allMigrationLines)
+	if migrationContentsErr != nil {
+		return nil, migrationContentsErr
+	}
+
+	migrationName := string(viewDiff.Change) + "_" + viewDiff.Name
+	if !w.EnableRollbacks {
+		return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, migrationName, migrationFileContents, order)
+	}
+
+	rollbackLines, rollbackLinesErr := w.getAllViewMigrationLines(InvertViewDiff(viewDiff))
+	if rollbackLinesErr != nil {
+		return nil, rollbackLinesErr
+	}
+	rollbackFileContents, rollbackContentsErr := core.LinesToString(rollbackLines)
+	if rollbackContentsErr != nil {
+		return nil, rollbackContentsErr
+	}
+	return sqlfile.WriteSQLMigrationFilesWithOrder(w.TargetDirPath, migrationName, migrationFileContents, rollbackFileContents, order)
+}
+
+// GetLastMigrationOrder returns the highest order prefix of the migrations in the target directory, so that new
+// migrations are ordered after the ones written by previous compilations
+func (w *MorpheMigrationFileWriter) GetLastMigrationOrder() (int, error) {
+	return sqlfile.GetLastOrder(w.TargetDirPath)
+}
+
+func (w *MorpheMigrationFileWriter) getAllMigrationLines(tableDiff *psqldef.TableDiff) ([]string, error) {
+	switch tableDiff.Change {
+	case psqldef.TableChangeCreate:
+		if tableDiff.Table == nil {
+			return nil, fmt.Errorf("migration for created table '%s' has no table definition", tableDiff.Name)
+		}
+		tableWriter := &MorpheTableFileWriter{}
+		tableLines, tableLinesErr := tableWriter.getAllTableLines(tableDiff.Table)
+		if tableLinesErr != nil {
+			return nil, tableLinesErr
+		}
+		// Only rolled back drops add triggers to created tables, restoring the ones on remaining tables
+		triggerLines := []string{}
+		for _, trigger := range tableDiff.AddedTriggers {
+			triggerLines = append(triggerLines, formatCreateTriggerLines(trigger)...)
+		}
+		return appendLineGroup(append(w.getEnumTypeLines(tableDiff), tableLines...), triggerLines), nil
+	case psqldef.TableChangeDrop:
+		return w.getDropTableLines(tableDiff), nil
+	case psqldef.TableChangeAlter:
+		return w.getAlterTableLines(tableDiff)
+	case psqldef.TableChangeTriggers:
+		return w.getCrossTableTriggerLines(tableDiff), nil
+	}
+	return nil, fmt.Errorf("unsupported table change '%s' for table '%s'", tableDiff.Change, tableDiff.Name)
+}
+
+func (w *MorpheMigrationFileWriter) getAllViewMigrationLines(viewDiff *psqldef.ViewDiff) ([]string, error) {
+	if viewDiff.View == nil {
+		return nil, fmt.Errorf("migration for view '%s' has no view definition", viewDiff.Name)
+	}
+	viewWriter := &MorpheViewFileWriter{}
+	switch viewDiff.Change {
+	case psqldef.ViewChangeCreate:
+		return viewWriter.getAllViewLines(viewDiff.View)
+	case psqldef.ViewChangeDrop:
+		return viewWriter.getAllRollbackLines(viewDiff.View), nil
+	}
+	return nil, fmt.Errorf("unsupported view change '%s' for view '%s'", viewDiff.Change, viewDiff.Name)
+}
+
+func (w *MorpheMigrationFileWriter) getDropTableLines(tableDiff *psqldef.TableDiff) []string {
+	migrationLines := []string{
+		fmt.Sprintf("-- Migration dropping %s", tableDiff.Name),
+		"",
+	}
+
+	triggerLines := []string{}
+	for _, trigger := range tableDiff.DroppedTriggers {
+		triggerLines = append(triggerLines, formatDropTriggerLine(trigger))
+	}
+	migrationLines = appendLineGroup(migrationLines, triggerLines)
+
+	migrationLines = append(migrationLines, fmt.Sprintf("DROP TABLE IF EXISTS %s;", getQualifiedTableDiffName(tableDiff)), "")
+
+	// Functions are dropped once the triggers executing them are gone
+	functionLines := []string{}
+	for _, function := range tableDiff.DroppedFunctions {
+		functionLines = append(functionLines, fmt.Sprintf("DROP FUNCTION IF EXISTS %s();", getQualifiedName(function.Schema, function.Name)))
+	}
+	functionLines = append(functionLines, w.getDropEnumTypeLines(tableDiff)...)
+	return appendLineGroup(migrationLines, functionLines)
+}
+
+// getCrossTableTriggerLines returns the statements creating the triggers a table creates on other tables
+func (w *MorpheMigrationFileWriter) getCrossTableTriggerLines(tableDiff *psqldef.TableDiff) []string {
+	migrationLines := []string{
+		fmt.Sprintf("-- Migration creating triggers of %s on other tables", tableDiff.Name),
+		"",
+	}
+	triggerLines := []string{}
+	for _, trigger := range tableDiff.AddedTriggers {
+		triggerLines = append(triggerLines, formatCreateTriggerLines(trigger)...)
+	}
+	return appendLineGroup(migrationLines, triggerLines)
+}
+
+// getEnumTypeLines returns the statements creating the native enum types and adding the enum values a table diff
+// depends on
+func (w *MorpheMigrationFileWriter) getEnumTypeLines(tableDiff *psqldef.TableDiff) []string {
+	enumTypeLines := []string{}
+	enumTypeWriter := &MorpheEnumTypeFileWriter{}
+	for _, enumType := range tableDiff.AddedEnumTypes {
+		enumTypeLines = append(enumTypeLines, enumTypeWriter.getCreateEnumTypeLines(&enumType)...)
+	}
+	for _, enumType := range tableDiff.AddedEnumValues {
+		for _, value := range enumType.Values {
+			enumTypeLines = append(enumTypeLines, fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s%s;",
+				enumType.GetSyntax(), formatEnumValue(value), getEnumValuePosition(tableDiff, enumType, value)))
+		}
+	}
+	return appendLineGroup([]string{}, enumTypeLines)
+}
+
+// getEnumValuePosition returns the clause adding an enum value at its sorted position among the values of the native
+// enum type used by the table, as fresh schemas create them, since ADD VALUE appends by default. Values are added in
+// sorted order, so the preceding value always exists, and the first value is added before the first existing one.
+func getEnumValuePosition(tableDiff *psqldef.TableDiff, addedValues psqldef.PSQLTypeEnum, value string) string {
+	if tableDiff.Table == nil {
+		return ""
+	}
+	for _, column := range tableDiff.Table.Columns {
+		enumType, isEnumType := column.Type.(psqldef.PSQLTypeEnum)
+		if !isEnumType || enumType.GetSyntax() != addedValues.GetSyntax() {
+			continue
+		}
+		valueIdx := slices.Index(enumType.Values, value)
+		if valueIdx > 0 {
+			return " AFTER " + formatEnumValue(enumType.Values[valueIdx-1])
+		}
+		for _, existingValue := range enumType.Values {
+			if !slices.Contains(addedValues.Values, existingValue) {
+				return " BEFORE " + formatEnumValue(existingValue)
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// getDropEnumTypeLines returns the statements dropping the native enum types no longer used after a table diff
+func (w *MorpheMigrationFileWriter) getDropEnumTypeLines(tableDiff *psqldef.TableDiff) []string {
+	dropLines := []string{}
+	for _, enumType := range tableDiff.DroppedEnumTypes {
+		dropLines = append(dropLines, fmt.Sprintf("DROP TYPE IF EXISTS %s;", enumType.GetSyntax()))
+	}
+	return dropLines
+}
+
+func formatEnumValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (w *MorpheMigrationFileWriter) getAlterTableLines(tableDiff *psqldef.TableDiff) ([]string, error) {
+	tableName := getQualifiedTableDiffName(tableDiff)
+	tableDefinition := &psqldef.Table{
+		Schema: tableDiff.Schema,
+		Name:   tableDiff.Name,
+	}
+	tableWriter := &MorpheTableFileWriter{}
+
+	migrationLines := []string{
+		fmt.Sprintf("-- Migration altering %s", tableDiff.Name),
+		"",
+	}
+	migrationLines = append(migrationLines, w.getEnumTypeLines(tableDiff)...)
+
+	// Seed rows are deleted while the columns they are matched by still exist
+	if len(tableDiff.DroppedSeedData) > 0 {
+		if tableDiff.PreviousTable == nil {
+			return nil, fmt.Errorf("migration deleting seed data from table '%s' has no previous table definition", tableDiff.Name)
+		}
+		seedDataTable := &psqldef.Table{
+			Schema:   tableDiff.Schema,
+			Name:     tableDiff.Name,
+			Columns:  tableDiff.PreviousTable.Columns,
+			SeedData: tableDiff.DroppedSeedData,
+		}
+		seedDataLines, seedDataErr := tableWriter.getSeedDataRollbackLines(seedDataTable)
+		if seedDataErr != nil {
+			return nil, seedDataErr
+		}
+		migrationLines = appendLineGroup(migrationLines, seedDataLines)
+	}
+
+	// Drop triggers, policies, constraints and indices first, so that dropped and altered columns are no longer referenced
+	dropLines := []string{}
+	for _, trigger := range tableDiff.DroppedTriggers {
+		dropLines = append(dropLines, formatDropTriggerLine(trigger))
+	}
+	for _, policy := range tableDiff.DroppedPolicies {
+		dropLines = append(dropLines, fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s;", policy.Name, tableName))
+	}
+	if tableDiff.DisableRowLevelSecurity {
+		dropLines = append(dropLines, fmt.Sprintf("ALTER TABLE %s DISABLE ROW LEVEL SECURITY;", tableName))
+	}
+	for _, foreignKey := range tableDiff.DroppedForeignKeys {
+		dropLines = append(dropLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;",
+			tableName, getForeignKeyIdentity(tableDiff.Name, foreignKey)))
+	}
+	for _, uniqueConstraint := range tableDiff.DroppedUniqueConstraints {
+		dropLines = append(dropLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;",
+			tableName, getUniqueConstraintDBName(tableDiff.Name, uniqueConstraint)))
+	}
+	for _, checkConstraint := range tableDiff.DroppedCheckConstraints {
+		dropLines = append(dropLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;",
+			tableName, checkConstraint.Name))
+	}
+	for _, index := range tableDiff.DroppedIndices {
+		indexName := getIndexDefinitionName(tableDefinition, index)
+		if tableDiff.Schema != "" {
+			indexName = tableDiff.Schema + "." + indexName
+		}
+		dropLines = append(dropLines, fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName))
+	}
+	for _, partition := range tableDiff.DroppedPartitions {
+		dropLines = append(dropLines, fmt.Sprintf("DROP TABLE IF EXISTS %s;", getQualifiedName(tableDiff.Schema, partition.Name)))
+	}
+	for _, column := range tableDiff.DroppedColumns {
+		dropLines = append(dropLines, fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s;", tableName, column.Name))
+	}
+	for _, function := range tableDiff.DroppedFunctions {
+		dropLines = append(dropLines, fmt.Sprintf("DROP FUNCTION IF EXISTS %s();", getQualifiedName(function.Schema, function.Name)))
+	}
+	migrationLines = appendLineGroup(migrationLines, dropLines)
+
+	columnLines := []string{}
+	for _, column := range tableDiff.AddedColumns {
+		columnLines = append(columnLines, fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s;",
+			tableName, tableWriter.formatColumnDefinition(column)))
+	}
+	for _, columnDiff := range tableDiff.AlteredColumns {
+		columnLines = append(columnLines, w.getAlterColumnLines(tableDiff, columnDiff)...)
+	}
+	// Enum types are dropped once no column uses them
+	columnLines = append(columnLines, w.getDropEnumTypeLines(tableDiff)...)
+	migrationLines = appendLineGroup(migrationLines, columnLines)
+
+	addLines := []string{}
+	for _, partition := range tableDiff.AddedPartitions {
+		addLines = append(addLines, formatCreatePartitionLine(tableDiff.Schema, tableName, partition))
+	}
+	for _, uniqueConstraint := range tableDiff.AddedUniqueConstraints {
+		addLines = append(addLines, fmt.Sprintf("ALTER TABLE %s ADD UNIQUE (%s);",
+			tableName, strings.Join(uniqueConstraint.ColumnNames, ", ")))
+	}
+	for _, checkConstraint := range tableDiff.AddedCheckConstraints {
+		addLines = append(addLines, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);",
+			tableName, checkConstraint.Name, checkConstraint.Expression))
+	}
+	for _, foreignKey := range tableDiff.AddedForeignKeys {
+		addLines = append(addLines, w.formatAddForeignKeyLine(tableName, tableDiff.Name, foreignKey))
+	}
+	for _, index := range tableDiff.AddedIndices {
+		addLines = append(addLines, tableWriter.formatCreateIndexLine(tableDefinition, index))
+	}
+	migrationLines = appendLineGroup(migrationLines, addLines)
+
+	// Functions are (re)created before the triggers executing them
+	for _, function := range tableDiff.AddedFunctions {
+		migrationLines = append(migrationLines, formatCreateFunctionLines(function)...)
+	}
+
+	triggerLines := []string{}
+	for _, trigger := range tableDiff.AddedTriggers {
+		triggerLines = append(triggerLines, formatCreateTriggerLines(trigger)...)
+	}
+	migrationLines = appendLineGroup(migrationLines, triggerLines)
+
+	securityLines := []string{}
+	if tableDiff.EnableRowLevelSecurity {
+		securityLines = append(securityLines, fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY;", tableName))
+	}
+	for _, policy := range tableDiff.AddedPolicies {
+		securityLines = append(securityLines, formatCreatePolicyLines(tableName, policy)...)
+	}
+	migrationLines = appendLineGroup(migrationLines, securityLines)
+
+	if len(tableDiff.AddedSeedData) > 0 {
+		if tableDiff.Table == nil {
+			return nil, fmt.Errorf("migration adding seed data to table '%s' has no table definition", tableDiff.Name)
+		}
+		seedDataTable := &psqldef.Table{
+			Schema:   tableDiff.Schema,
+			Name:     tableDiff.Name,
+			Columns:  tableDiff.Table.Columns,
+			SeedData: tableDiff.AddedSeedData,
+		}
+		seedDataLines, seedDataErr := tableWriter.getSeedDataLines(seedDataTable)
+		if seedDataErr != nil {
+			return nil, seedDataErr
+		}
+		migrationLines = appendLineGroup(migrationLines, seedDataLines)
+	}
+
+	return migrationLines, nil
+}
+
+func (w *MorpheMigrationFileWriter) getAlterColumnLines(tableDiff *psqldef.TableDiff, columnDiff psqldef.ColumnDiff) []string {
+	tableName := getQualifiedTableDiffName(tableDiff)
+	alterPrefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", tableName, columnDiff.Name)
+	alterLines := []string{}
+
+	if columnDiff.TypeChanged() {
+		alterLines = append(alterLines, w.getAlterColumnTypeLines(tableDiff, columnDiff)...)
+	}
+
+	if columnDiff.NotNullChanged() && !columnDiff.Current.PrimaryKey {
+		if columnDiff.Current.NotNull {
+			alterLines = append(alterLines, alterPrefix+" SET NOT NULL;")
+		} else {
+			alterLines = append(alterLines, alterPrefix+" DROP NOT NULL;")
+		}
+	}
+
+	if columnDiff.DefaultChanged() {
+		if columnDiff.Current.Default != "" {
+			alterLines = append(alterLines, fmt.Sprintf("%s SET DEFAULT %s;", alterPrefix, columnDiff.Current.Default))
+		} else {
+			alterLines = append(alterLines, alterPrefix+" DROP DEFAULT;")
+		}
+	}
+
+	return alterLines
+}
+
+// getAlterColumnTypeLines returns the statements changing a column type. SERIAL and BIGSERIAL are no real types, so
+// a column becomes serial through an owned sequence providing its default.
+func (w *MorpheMigrationFileWriter) getAlterColumnTypeLines(tableDiff *psqldef.TableDiff, columnDiff psqldef.ColumnDiff) []string {
+	tableName := getQualifiedTableDiffName(tableDiff)
+	alterPrefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", tableName, columnDiff.Name)
+	sequenceName := getQualifiedName(tableDiff.Schema, getSerialSequenceName(tableDiff.Name, columnDiff.Name))
+
+	previousTypeSyntax := getSerialIntegerType(columnDiff.Previous.Type).GetSyntax()
+	currentTypeSyntax := getSerialIntegerType(columnDiff.Current.Type).GetSyntax()
+
+	alterLines := []string{}
+	if isSerialColumnType(columnDiff.Current.Type) {
+		alterLines = append(alterLines, fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s AS %s OWNED BY %s.%s;",
+			sequenceName, currentTypeSyntax, tableName, columnDiff.Name))
+	}
+	if previousTypeSyntax != currentTypeSyntax {
+		alterLines = append(alterLines, fmt.Sprintf("%s TYPE %s USING %s::%s;", alterPrefix, currentTypeSyntax, columnDiff.Name, currentTypeSyntax))
+		if isSerialColumnType(columnDiff.Previous.Type) && isSerialColumnType(columnDiff.Current.Type) {
+			alterLines = append(alterLines, fmt.Sprintf("ALTER SEQUENCE %s AS %s;", sequenceName, currentTypeSyntax))
+		}
+	}
+
+	if isSerialColumnType(columnDiff.Current.Type) && !isSerialColumnType(columnDiff.Previous.Type) {
+		alterLines = append(alterLines,
+			fmt.Sprintf("SELECT setval('%s', COALESCE(MAX(%s), 0) + 1, false) FROM %s;", sequenceName, columnDiff.Name, tableName),
+			fmt.Sprintf("%s SET DEFAULT nextval('%s');", alterPrefix, sequenceName),
+		)
+	}
+	if isSerialColumnType(columnDiff.Previous.Type) && !isSerialColumnType(columnDiff.Current.Type) {
+		if columnDiff.Current.Default == "" {
+			alterLines = append(alterLines, alterPrefix+" DROP DEFAULT;")
+		}
+		alterLines = append(alterLines, fmt.Sprintf("DROP SEQUENCE IF EXISTS %s;", sequenceName))
+	}
+	return alterLines
+}
+
+func (w *MorpheMigrationFileWriter) formatAddForeignKeyLine(tableName string, localTableName string, foreignKey psqldef.ForeignKey) string {
+	foreignKeyLine := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s.%s (%s)",
+		tableName,
+		getForeignKeyIdentity(localTableName, foreignKey),
+		strings.Join(foreignKey.ColumnNames, ", "),
+		foreignKey.RefSchema,
+		foreignKey.RefTableName,
+		strings.Join(foreignKey.RefColumnNames, ", "))
+
+	if foreignKey.OnDelete != "" {
+		foreignKeyLine += " ON DELETE " + getForeignKeyOnDeleteAction(foreignKey)
+	}
+	if foreignKey.OnUpdate != "" {
+		foreignKeyLine += " ON UPDATE " + foreignKey.OnUpdate
+	}
+
+	return foreignKeyLine + ";"
+}
+
+func getQualifiedTableDiffName(tableDiff *psqldef.TableDiff) string {
+	if tableDiff.Schema == "" {
+		return tableDiff.Name
+	}
+	return tableDiff.Schema + "." + tableDiff.Name
+}
+
+// getUniqueConstraintDBName returns the name PostgreSQL assigns to an unnamed unique constraint
+func getUniqueConstraintDBName(tableName string, uniqueConstraint psqldef.UniqueConstraint) string {
+	constraintName := fmt.Sprintf("%s_%s_key", tableName, strings.Join(uniqueConstraint.ColumnNames, "_"))
+	constraintName = strings.ReplaceAll(constraintName, "\"", "")
+	if len(constraintName) > maxIdentifierLength {
+		return constraintName[:maxIdentifierLength]
+	}
+	return constraintName
+}
+
+// isSerialColumnType returns true for the SERIAL and BIGSERIAL shorthands, which default to the next value of a sequence
+func isSerialColumnType(columnType psqldef.PSQLType) bool {
+	return columnType.GetSyntax() == psqldef.PSQLTypeSerial.GetSyntax() || columnType.GetSyntax() == psqldef.PSQLTypeBigSerial.GetSyntax()
+}
+
+// getSerialIntegerType returns the integer type underlying a serial column type, or the column type itself
+func getSerialIntegerType(columnType psqldef.PSQLType) psqldef.PSQLType {
+	switch columnType.GetSyntax() {
+	case psqldef.PSQLTypeSerial.GetSyntax():
+		return psqldef.PSQLTypeInteger
+	case psqldef.PSQLTypeBigSerial.GetSyntax():
+		return psqldef.PSQLTypeBigInt
+	}
+	return columnType
+}
+
+// getSerialSequenceName returns the name PostgreSQL assigns to the sequence of a serial column
+func getSerialSequenceName(tableName string, columnName string) string {
+	sequenceName := strings.ReplaceAll(fmt.Sprintf("%s_%s_seq", tableName, columnName), "\"", "")
+	if len(sequenceName) > maxIdentifierLength {
+		return sequenceName[:maxIdentifierLength]
+	}
+	return sequenceName
+}
+
+// appendLineGroup appends a group of lines followed by a blank line, skipping empty groups
+func appendLineGroup(allLines []string, lineGroup []string) []string {
+	if len(lineGroup) == 0 {
+		return allLines
+	}
+	allLines = append(allLines, lineGroup...)
+	return append(allLines, "")
+}
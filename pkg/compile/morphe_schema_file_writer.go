@@ -0,0 +1,120 @@
+package compile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// SchemaFileName is the default file name of the consolidated schema in the output directory
+const SchemaFileName = "schema.sql"
+
+type MorpheSchemaFileWriter struct {
+	TargetFilePath string
+}
+
+func (w *MorpheSchemaFileWriter) WriteSchema(schema *psqldef.Schema) ([]byte, error) {
+	allSchemaLines, allLinesErr := w.getAllSchemaLines(schema)
+	if allLinesErr != nil {
+		return nil, allLinesErr
+	}
+
+	schemaFileContents, schemaContentsErr := core.LinesToString(allSchemaLines)
+	if schemaContentsErr != nil {
+		return nil, schemaContentsErr
+	}
+
+	targetDirPath := filepath.Dir(w.TargetFilePath)
+	if _, readErr := os.ReadDir(targetDirPath); readErr != nil && os.IsNotExist(readErr) {
+		mkDirErr := os.MkdirAll(targetDirPath, 0644)
+		if mkDirErr != nil {
+			return nil, mkDirErr
+		}
+	}
+	return []byte(schemaFileContents), os.WriteFile(w.TargetFilePath, []byte(schemaFileContents), 0644)
+}
+
+func (w *MorpheSchemaFileWriter) getAllSchemaLines(schema *psqldef.Schema) ([]string, error) {
+	if schema == nil {
+		return nil, ErrNoSchema
+	}
+
+	allSchemaLines := []string{
+		"-- Consolidated schema definition",
+		"",
+	}
+
+	// Create all schemas once up front
+	schemaNames := w.getSchemaNames(schema)
+	for _, schemaName := range schemaNames {
+		allSchemaLines = append(allSchemaLines, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", schemaName))
+	}
+	if len(schemaNames) > 0 {
+		allSchemaLines = append(allSchemaLines, "")
+	}
+
+	enumTypeWriter := &MorpheEnumTypeFileWriter{}
+	for enumTypeIdx := range schema.EnumTypes {
+		enumType := &schema.EnumTypes[enumTypeIdx]
+		allSchemaLines = append(allSchemaLines, fmt.Sprintf("-- Enum type definition for %s", enumType.Name))
+		allSchemaLines = append(allSchemaLines, "")
+		allSchemaLines = append(allSchemaLines, enumTypeWriter.getCreateEnumTypeLines(enumType)...)
+		allSchemaLines = append(allSchemaLines, "")
+	}
+
+	tableWriter := &MorpheTableFileWriter{}
+	viewWriter := &MorpheViewFileWriter{}
+	for _, definition := range schema.Definitions {
+		if definition.Table != nil {
+			allSchemaLines = append(allSchemaLines, fmt.Sprintf("-- Table definition for %s", definition.Table.Name))
+			allSchemaLines = append(allSchemaLines, "")
+			tableLines, tableErr := tableWriter.getTableDefinitionLines(definition.Table)
+			if tableErr != nil {
+				return nil, tableErr
+			}
+			allSchemaLines = append(allSchemaLines, tableLines...)
+			continue
+		}
+		if definition.View != nil {
+			allSchemaLines = append(allSchemaLines, fmt.Sprintf("-- View definition for %s", definition.View.Name))
+			allSchemaLines = append(allSchemaLines, "")
+			viewLines, viewErr := viewWriter.getViewDefinitionLines(definition.View)
+			if viewErr != nil {
+				return nil, viewErr
+			}
+			allSchemaLines = append(allSchemaLines, viewLines...)
+		}
+	}
+
+	// Triggers on other tables follow all tables, so that they add no dependencies between tables
+	schemaTables := []*psqldef.Table{}
+	for _, definition := range schema.Definitions {
+		if definition.Table != nil {
+			schemaTables = append(schemaTables, definition.Table)
+		}
+	}
+	allSchemaLines = append(allSchemaLines, getCrossTableTriggerLines(schemaTables)...)
+
+	return allSchemaLines, nil
+}
+
+func (w *MorpheSchemaFileWriter) getSchemaNames(schema *psqldef.Schema) []string {
+	schemaNames := []string{}
+	addSchemaName := func(schemaName string) {
+		if schemaName != "" && !slices.Contains(schemaNames, schemaName) {
+			schemaNames = append(schemaNames, schemaName)
+		}
+	}
+
+	for _, enumType := range schema.EnumTypes {
+		addSchemaName(enumType.Schema)
+	}
+	for _, definition := range schema.Definitions {
+		addSchemaName(definition.GetSchema())
+	}
+	return schemaNames
+}
@@ -0,0 +1,52 @@
+package compile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// SchemaSnapshotFileName is the default file name of the schema snapshot in the output directory
+const SchemaSnapshotFileName = "schema_snapshot.json"
+
+type MorpheSnapshotFileWriter struct {
+	TargetFilePath string
+}
+
+func (w *MorpheSnapshotFileWriter) WriteSnapshot(snapshot *psqldef.SchemaSnapshot) ([]byte, error) {
+	if snapshot == nil {
+		return nil, ErrNoSchemaSnapshot
+	}
+
+	snapshotContents, marshalErr := json.MarshalIndent(snapshot, "", "\t")
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	snapshotContents = append(snapshotContents, '\n')
+
+	targetDirPath := filepath.Dir(w.TargetFilePath)
+	if _, readErr := os.ReadDir(targetDirPath); readErr != nil && os.IsNotExist(readErr) {
+		mkDirErr := os.MkdirAll(targetDirPath, 0644)
+		if mkDirErr != nil {
+			return nil, mkDirErr
+		}
+	}
+	return snapshotContents, os.WriteFile(w.TargetFilePath, snapshotContents, 0644)
+}
+
+// ReadSchemaSnapshotFile reads a schema snapshot previously written by a MorpheSnapshotFileWriter.
+func ReadSchemaSnapshotFile(filePath string) (*psqldef.SchemaSnapshot, error) {
+	snapshotContents, readErr := os.ReadFile(filePath)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	snapshot := &psqldef.SchemaSnapshot{}
+	unmarshalErr := json.Unmarshal(snapshotContents, snapshot)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,85 @@
+package compile_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/stretchr/testify/suite"
+)
+
+type MorpheSnapshotFileWriterTestSuite struct {
+	suite.Suite
+}
+
+func TestMorpheSnapshotFileWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(MorpheSnapshotFileWriterTestSuite))
+}
+
+func (suite *MorpheSnapshotFileWriterTestSuite) TestWriteSnapshot_RoundTrip() {
+	snapshotFilePath := filepath.Join(suite.T().TempDir(), compile.SchemaSnapshotFileName)
+	writer := &compile.MorpheSnapshotFileWriter{
+		TargetFilePath: snapshotFilePath,
+	}
+
+	emailDomain := psqldef.PSQLTypeDomain{
+		ValueType: psqldef.PSQLTypeText,
+		Schema:    "public",
+		Name:      "email_address",
+	}
+	snapshot := &psqldef.SchemaSnapshot{
+		Tables: []psqldef.Table{
+			{
+				Schema: "public",
+				Name:   "people",
+				Columns: []psqldef.TableColumn{
+					{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+					{Name: "email", Type: emailDomain, NotNull: true},
+					{Name: "tags", Type: psqldef.PSQLTypeArray{ValueType: psqldef.PSQLTypeText}, Default: "'{}'"},
+					{Name: "aliases", Type: psqldef.PSQLTypeArray{ValueType: emailDomain}},
+				},
+				ForeignKeys: []psqldef.ForeignKey{},
+				Indices: []psqldef.Index{
+					{Name: "idx_people_email", TableName: "people", Columns: []string{"email"}, IsUnique: true},
+				},
+				UniqueConstraints: []psqldef.UniqueConstraint{},
+			},
+		},
+		Views: []psqldef.View{
+			{
+				Schema: "public",
+				Name:   "person_entities",
+				Columns: []psqldef.ViewColumn{
+					{Name: "id", SourceRef: "people.id"},
+					{Name: "email", SourceRef: "people.email"},
+				},
+				FromSchema: "public",
+				FromTable:  "people",
+			},
+		},
+	}
+
+	snapshotContents, writeErr := writer.WriteSnapshot(snapshot)
+
+	suite.Nil(writeErr)
+	suite.Contains(string(snapshotContents), `"Kind": "domain"`)
+	suite.Contains(string(snapshotContents), `"Kind": "array"`)
+
+	readSnapshot, readErr := compile.ReadSchemaSnapshotFile(snapshotFilePath)
+
+	suite.Nil(readErr)
+	suite.Equal(snapshot, readSnapshot)
+	suite.Equal("public.email_address[]", readSnapshot.Tables[0].Columns[3].Type.GetSyntax())
+}
+
+func (suite *MorpheSnapshotFileWriterTestSuite) TestWriteSnapshot_NoSnapshot() {
+	writer := &compile.MorpheSnapshotFileWriter{
+		TargetFilePath: filepath.Join(suite.T().TempDir(), compile.SchemaSnapshotFileName),
+	}
+
+	snapshotContents, writeErr := writer.WriteSnapshot(nil)
+
+	suite.ErrorIs(writeErr, compile.ErrNoSchemaSnapshot)
+	suite.Nil(snapshotContents)
+}
@@ -2,16 +2,25 @@ package compile
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/sqlfile"
 )
 
+// CrossTableTriggersFileName is the definition name of the file holding the triggers tables create on other tables
+const CrossTableTriggersFileName = "cross_table_triggers"
+
 type MorpheTableFileWriter struct {
 	Type          MorpheTableType
 	TargetDirPath string
+
+	// EnableRollbacks writes each table as an up/down migration pair (e.g., "003_people.up.sql" and
+	// "003_people.down.sql") instead of a single definition file.
+	EnableRollbacks bool
 }
 
 func (w *MorpheTableFileWriter) WriteTable(tableDefinition *psqldef.Table) ([]byte, error) {
@@ -29,7 +38,21 @@ func (w *MorpheTableFileWriter) WriteTableWithOrder(tableDefinition *psqldef.Tab
 		return nil, tableContentsErr
 	}
 
-	return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, tableDefinition.Name, tableFileContents, order)
+	if !w.EnableRollbacks {
+		return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, tableDefinition.Name, tableFileContents, order)
+	}
+
+	allRollbackLines, allRollbackLinesErr := w.getAllRollbackLines(tableDefinition)
+	if allRollbackLinesErr != nil {
+		return nil, allRollbackLinesErr
+	}
+
+	rollbackFileContents, rollbackContentsErr := core.LinesToString(allRollbackLines)
+	if rollbackContentsErr != nil {
+		return nil, rollbackContentsErr
+	}
+
+	return sqlfile.WriteSQLMigrationFilesWithOrder(w.TargetDirPath, tableDefinition.Name, tableFileContents, rollbackFileContents, order)
 }
 
 func (w *MorpheTableFileWriter) getAllTableLines(tableDefinition *psqldef.Table) ([]string, error) {
@@ -45,6 +68,19 @@ func (w *MorpheTableFileWriter) getAllTableLines(tableDefinition *psqldef.Table)
 		allTableLines = append(allTableLines, "")
 	}
 
+	tableDefinitionLines, tableDefinitionErr := w.getTableDefinitionLines(tableDefinition)
+	if tableDefinitionErr != nil {
+		return nil, tableDefinitionErr
+	}
+	allTableLines = append(allTableLines, tableDefinitionLines...)
+
+	return allTableLines, nil
+}
+
+// getTableDefinitionLines returns the table, index and seed data lines without the header and schema creation
+func (w *MorpheTableFileWriter) getTableDefinitionLines(tableDefinition *psqldef.Table) ([]string, error) {
+	allTableLines := []string{}
+
 	// Create table
 	tableLines, tableErr := w.getCreateTableLines(tableDefinition)
 	if tableErr != nil {
@@ -53,6 +89,12 @@ func (w *MorpheTableFileWriter) getAllTableLines(tableDefinition *psqldef.Table)
 	allTableLines = append(allTableLines, tableLines...)
 	allTableLines = append(allTableLines, "")
 
+	// Add partitions
+	if len(tableDefinition.Partitions) > 0 {
+		allTableLines = append(allTableLines, w.getPartitionLines(tableDefinition)...)
+		allTableLines = append(allTableLines, "")
+	}
+
 	// Add indices
 	if len(tableDefinition.Indices) > 0 {
 		indexLines, indexErr := w.getIndexLines(tableDefinition)
@@ -63,6 +105,22 @@ func (w *MorpheTableFileWriter) getAllTableLines(tableDefinition *psqldef.Table)
 		allTableLines = append(allTableLines, "")
 	}
 
+	// Add functions
+	if len(tableDefinition.Functions) > 0 {
+		allTableLines = append(allTableLines, w.getFunctionLines(tableDefinition)...)
+	}
+
+	// Add triggers
+	if len(getOwnTriggers(tableDefinition)) > 0 {
+		allTableLines = append(allTableLines, w.getTriggerLines(tableDefinition)...)
+		allTableLines = append(allTableLines, "")
+	}
+
+	// Add row-level security
+	if tableDefinition.RowLevelSecurity {
+		allTableLines = append(allTableLines, w.getRowLevelSecurityLines(tableDefinition)...)
+	}
+
 	// Add seed data
 	if len(tableDefinition.SeedData) > 0 {
 		seedDataLines, seedErr := w.getSeedDataLines(tableDefinition)
@@ -86,41 +144,45 @@ func (w *MorpheTableFileWriter) getCreateTableLines(tableDefinition *psqldef.Tab
 		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (", tableName),
 	}
 
+	// Columns and table constraints are collected first, so that all but the last can be followed by a comma
+	definitionEntries := []string{}
+
+	// A composite primary key is written as a table constraint instead of per column
+	primaryKeyColumnNames := getPrimaryKeyColumnNames(tableDefinition)
+	isCompositePrimaryKey := len(primaryKeyColumnNames) > 1
+
 	// Add columns
-	for colIdx, column := range tableDefinition.Columns {
-		columnDef := w.formatColumnDefinition(column)
-
-		// Add comma if not the last column or if we have constraints to add
-		if colIdx < len(tableDefinition.Columns)-1 ||
-			len(tableDefinition.ForeignKeys) > 0 ||
-			len(tableDefinition.UniqueConstraints) > 0 {
-			columnDef += ","
+	for _, column := range tableDefinition.Columns {
+		if isCompositePrimaryKey && column.PrimaryKey {
+			column.PrimaryKey = false
+			column.NotNull = true
 		}
+		definitionEntries = append(definitionEntries, "\t"+w.formatColumnDefinition(column))
+	}
 
-		tableLines = append(tableLines, "\t"+columnDef)
+	// Add composite primary key
+	if isCompositePrimaryKey {
+		definitionEntries = append(definitionEntries, fmt.Sprintf("\tPRIMARY KEY (%s)", strings.Join(primaryKeyColumnNames, ", ")))
 	}
 
 	// Add unique constraints
-	for uqIdx, uniqueConstraint := range tableDefinition.UniqueConstraints {
-		constraintLine := fmt.Sprintf("\tUNIQUE (%s)", strings.Join(uniqueConstraint.ColumnNames, ", "))
-
-		// Add comma if not the last constraint or if we have foreign keys to add
-		if uqIdx < len(tableDefinition.UniqueConstraints)-1 || len(tableDefinition.ForeignKeys) > 0 {
-			constraintLine += ","
-		}
+	for _, uniqueConstraint := range tableDefinition.UniqueConstraints {
+		definitionEntries = append(definitionEntries, fmt.Sprintf("\tUNIQUE (%s)", strings.Join(uniqueConstraint.ColumnNames, ", ")))
+	}
 
-		tableLines = append(tableLines, constraintLine)
+	// Add check constraints
+	for _, checkConstraint := range tableDefinition.CheckConstraints {
+		definitionEntries = append(definitionEntries, fmt.Sprintf("\tCONSTRAINT %s CHECK (%s)", checkConstraint.Name, checkConstraint.Expression))
 	}
 
 	// Add foreign key constraints with proper formatting
-	for fkIdx, foreignKey := range tableDefinition.ForeignKeys {
+	for _, foreignKey := range tableDefinition.ForeignKeys {
 		// Format according to the spec with named constraints
 		if foreignKey.Name != "" {
 			// Format with CONSTRAINT and multiline for readability
 			fkLine := fmt.Sprintf("\tCONSTRAINT %s FOREIGN KEY (%s)",
 				foreignKey.Name,
 				strings.Join(foreignKey.ColumnNames, ", "))
-			tableLines = append(tableLines, fkLine)
 
 			refLine := fmt.Sprintf("\t\tREFERENCES %s.%s (%s)",
 				foreignKey.RefSchema,
@@ -128,15 +190,13 @@ func (w *MorpheTableFileWriter) getCreateTableLines(tableDefinition *psqldef.Tab
 				strings.Join(foreignKey.RefColumnNames, ", "))
 
 			if foreignKey.OnDelete != "" {
-				refLine += fmt.Sprintf("\n\t\tON DELETE %s", foreignKey.OnDelete)
+				refLine += fmt.Sprintf("\n\t\tON DELETE %s", getForeignKeyOnDeleteAction(foreignKey))
 			}
-
-			// Only add comma if not the last foreign key
-			if fkIdx < len(tableDefinition.ForeignKeys)-1 {
-				refLine += ","
+			if foreignKey.OnUpdate != "" {
+				refLine += fmt.Sprintf("\n\t\tON UPDATE %s", foreignKey.OnUpdate)
 			}
 
-			tableLines = append(tableLines, refLine)
+			definitionEntries = append(definitionEntries, fkLine+"\n"+refLine)
 		} else {
 			// Fallback to simple single-line format for unnamed constraints
 			fkLine := fmt.Sprintf("\tFOREIGN KEY (%s) REFERENCES %s.%s (%s)",
@@ -145,19 +205,57 @@ func (w *MorpheTableFileWriter) getCreateTableLines(tableDefinition *psqldef.Tab
 				foreignKey.RefTableName,
 				strings.Join(foreignKey.RefColumnNames, ", "))
 
-			// Only add comma if not the last foreign key
-			if fkIdx < len(tableDefinition.ForeignKeys)-1 {
-				fkLine += ","
-			}
+			definitionEntries = append(definitionEntries, fkLine)
+		}
+	}
 
-			tableLines = append(tableLines, fkLine)
+	for entryIdx, entry := range definitionEntries {
+		if entryIdx < len(definitionEntries)-1 {
+			entry += ","
 		}
+		tableLines = append(tableLines, entry)
 	}
 
-	tableLines = append(tableLines, ");")
+	if tableDefinition.PartitionStrategy == "" {
+		tableLines = append(tableLines, ");")
+		return tableLines, nil
+	}
+	tableLines = append(tableLines, fmt.Sprintf(") PARTITION BY %s (%s);",
+		tableDefinition.PartitionStrategy, strings.Join(tableDefinition.PartitionColumns, ", ")))
 	return tableLines, nil
 }
 
+// getPartitionLines returns the statements creating the partitions of a partitioned table
+func (w *MorpheTableFileWriter) getPartitionLines(tableDefinition *psqldef.Table) []string {
+	partitionLines := []string{
+		"-- Partitions",
+	}
+	for _, partition := range tableDefinition.Partitions {
+		partitionLines = append(partitionLines, formatCreatePartitionLine(tableDefinition.Schema, getQualifiedTableName(tableDefinition), partition))
+	}
+	return partitionLines
+}
+
+// formatCreatePartitionLine returns the statement creating a partition of a partitioned table
+func formatCreatePartitionLine(schema string, tableName string, partition psqldef.TablePartition) string {
+	bound := "DEFAULT"
+	if partition.Bound != "" {
+		bound = "FOR VALUES " + partition.Bound
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s %s;",
+		getQualifiedName(schema, partition.Name), tableName, bound)
+}
+
+func getPrimaryKeyColumnNames(tableDefinition *psqldef.Table) []string {
+	primaryKeyColumnNames := []string{}
+	for _, column := range tableDefinition.Columns {
+		if column.PrimaryKey {
+			primaryKeyColumnNames = append(primaryKeyColumnNames, column.Name)
+		}
+	}
+	return primaryKeyColumnNames
+}
+
 func (w *MorpheTableFileWriter) formatColumnDefinition(column psqldef.TableColumn) string {
 	parts := []string{column.Name, column.Type.GetSyntax()}
 
@@ -179,34 +277,199 @@ func (w *MorpheTableFileWriter) getIndexLines(tableDefinition *psqldef.Table) ([
 		"-- Indices",
 	}
 
-	tableName := tableDefinition.Name
-	if tableDefinition.Schema != "" {
-		tableName = tableDefinition.Schema + "." + tableName
+	for _, index := range tableDefinition.Indices {
+		indexLines = append(indexLines, w.formatCreateIndexLine(tableDefinition, index))
 	}
 
-	for _, index := range tableDefinition.Indices {
-		indexName := index.Name
-		if indexName == "" {
-			indexName = fmt.Sprintf("idx_%s_%s", tableDefinition.Name, strings.Join(index.Columns, "_"))
-		}
+	return indexLines, nil
+}
+
+func (w *MorpheTableFileWriter) formatCreateIndexLine(tableDefinition *psqldef.Table, index psqldef.Index) string {
+	indexType := ""
+	if index.Using != "" {
+		indexType = "USING " + index.Using + " "
+	}
+
+	unique := ""
+	if index.IsUnique {
+		unique = "UNIQUE "
+	}
+
+	return fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s %s(%s)%s;",
+		unique, getIndexDefinitionName(tableDefinition, index), getQualifiedTableName(tableDefinition), indexType, strings.Join(index.Columns, ", "),
+		formatIndexWhereClause(index))
+}
+
+// formatIndexWhereClause returns the WHERE clause of a partial index, or an empty string
+func formatIndexWhereClause(index psqldef.Index) string {
+	if index.Where == "" {
+		return ""
+	}
+	return " WHERE " + index.Where
+}
 
-		indexType := ""
-		if index.Using != "" {
-			indexType = "USING " + index.Using + " "
+// getFunctionLines returns the CREATE OR REPLACE FUNCTION statements for the functions of a table
+func (w *MorpheTableFileWriter) getFunctionLines(tableDefinition *psqldef.Table) []string {
+	functionLines := []string{
+		"-- Functions",
+	}
+	for _, function := range tableDefinition.Functions {
+		functionLines = append(functionLines, formatCreateFunctionLines(function)...)
+	}
+	return functionLines
+}
+
+// formatCreateFunctionLines returns the CREATE OR REPLACE FUNCTION statement for a function, followed by a blank line
+func formatCreateFunctionLines(function psqldef.Function) []string {
+	functionLines := []string{
+		fmt.Sprintf("CREATE OR REPLACE FUNCTION %s()", getQualifiedName(function.Schema, function.Name)),
+		fmt.Sprintf("RETURNS %s", function.Returns),
+		fmt.Sprintf("LANGUAGE %s", function.Language),
+		"AS $$",
+	}
+	functionLines = append(functionLines, function.Body...)
+	return append(functionLines, "$$;", "")
+}
+
+// getRowLevelSecurityLines enables row-level security on the table and (re)creates its policies
+func (w *MorpheTableFileWriter) getRowLevelSecurityLines(tableDefinition *psqldef.Table) []string {
+	tableName := getQualifiedTableName(tableDefinition)
+	securityLines := []string{
+		"-- Row-level security",
+		fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY;", tableName),
+	}
+	for _, policy := range tableDefinition.Policies {
+		securityLines = append(securityLines, formatCreatePolicyLines(tableName, policy)...)
+	}
+	return append(securityLines, "")
+}
+
+// formatCreatePolicyLines returns the statements (re)creating a row-level security policy
+func formatCreatePolicyLines(tableName string, policy psqldef.Policy) []string {
+	policyLines := []string{
+		fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s;", policy.Name, tableName),
+		fmt.Sprintf("CREATE POLICY %s ON %s", policy.Name, tableName),
+		fmt.Sprintf("\tFOR %s", policy.Command),
+	}
+	if policy.Using != "" {
+		policyLines = append(policyLines, fmt.Sprintf("\tUSING (%s)", policy.Using))
+	}
+	if policy.WithCheck != "" {
+		policyLines = append(policyLines, fmt.Sprintf("\tWITH CHECK (%s)", policy.WithCheck))
+	}
+	policyLines[len(policyLines)-1] += ";"
+	return policyLines
+}
+
+func (w *MorpheTableFileWriter) getTriggerLines(tableDefinition *psqldef.Table) []string {
+	triggerLines := []string{
+		"-- Triggers",
+	}
+	for _, trigger := range getOwnTriggers(tableDefinition) {
+		triggerLines = append(triggerLines, formatCreateTriggerLines(trigger)...)
+	}
+	return triggerLines
+}
+
+// getOwnTriggers returns the triggers a table definition creates on the table itself
+func getOwnTriggers(tableDefinition *psqldef.Table) []psqldef.Trigger {
+	return slices.DeleteFunc(slices.Clone(tableDefinition.Triggers), func(trigger psqldef.Trigger) bool {
+		return trigger.TableName != tableDefinition.Name
+	})
+}
+
+// getCrossTableTriggers returns the triggers a table definition creates on other tables, e.g. polymorphic cleanup
+// triggers. They are written once all tables exist, so they add no dependencies between tables.
+func getCrossTableTriggers(tableDefinition *psqldef.Table) []psqldef.Trigger {
+	return slices.DeleteFunc(slices.Clone(tableDefinition.Triggers), func(trigger psqldef.Trigger) bool {
+		return trigger.TableName == tableDefinition.Name
+	})
+}
+
+// WriteCrossTableTriggersWithOrder writes the triggers the table definitions create on other tables to a single
+// file, ordered after the table files
+func (w *MorpheTableFileWriter) WriteCrossTableTriggersWithOrder(tableDefinitions []*psqldef.Table, order int) ([]byte, error) {
+	triggerFileContents, triggerContentsErr := core.LinesToString(getCrossTableTriggerLines(tableDefinitions))
+	if triggerContentsErr != nil {
+		return nil, triggerContentsErr
+	}
+
+	if !w.EnableRollbacks {
+		return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, CrossTableTriggersFileName, triggerFileContents, order)
+	}
+
+	rollbackFileContents, rollbackContentsErr := core.LinesToString(getCrossTableTriggerRollbackLines(tableDefinitions))
+	if rollbackContentsErr != nil {
+		return nil, rollbackContentsErr
+	}
+	return sqlfile.WriteSQLMigrationFilesWithOrder(w.TargetDirPath, CrossTableTriggersFileName, triggerFileContents, rollbackFileContents, order)
+}
+
+// getCrossTableTriggerLines returns the statements creating the triggers the table definitions create on other tables
+func getCrossTableTriggerLines(tableDefinitions []*psqldef.Table) []string {
+	triggerLines := []string{}
+	for _, tableDefinition := range tableDefinitions {
+		for _, trigger := range getCrossTableTriggers(tableDefinition) {
+			triggerLines = append(triggerLines, formatCreateTriggerLines(trigger)...)
 		}
+	}
+	if len(triggerLines) == 0 {
+		return []string{}
+	}
+	return append(append([]string{"-- Triggers created on other tables", ""}, triggerLines...), "")
+}
 
-		unique := ""
-		if index.IsUnique {
-			unique = "UNIQUE "
+// getCrossTableTriggerRollbackLines returns the statements dropping the triggers the table definitions create on
+// other tables, in reverse creation order
+func getCrossTableTriggerRollbackLines(tableDefinitions []*psqldef.Table) []string {
+	rollbackLines := []string{}
+	for tableIdx := len(tableDefinitions) - 1; tableIdx >= 0; tableIdx-- {
+		triggers := getCrossTableTriggers(tableDefinitions[tableIdx])
+		for triggerIdx := len(triggers) - 1; triggerIdx >= 0; triggerIdx-- {
+			rollbackLines = append(rollbackLines, formatDropTriggerLine(triggers[triggerIdx]))
 		}
+	}
+	if len(rollbackLines) == 0 {
+		return []string{}
+	}
+	return append(append([]string{"-- Rollback of triggers created on other tables", ""}, rollbackLines...), "")
+}
 
-		indexLine := fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s %s(%s);",
-			unique, indexName, tableName, indexType, strings.Join(index.Columns, ", "))
+// formatDropTriggerLine returns the statement dropping a trigger
+func formatDropTriggerLine(trigger psqldef.Trigger) string {
+	return fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;", trigger.Name, getQualifiedName(trigger.Schema, trigger.TableName))
+}
+
+// formatCreateTriggerLines returns the statements (re)creating a row-level trigger
+func formatCreateTriggerLines(trigger psqldef.Trigger) []string {
+	triggerTableName := getQualifiedName(trigger.Schema, trigger.TableName)
 
-		indexLines = append(indexLines, indexLine)
+	arguments := make([]string, len(trigger.Arguments))
+	for argIdx, argument := range trigger.Arguments {
+		arguments[argIdx] = "'" + strings.ReplaceAll(argument, "'", "''") + "'"
 	}
 
-	return indexLines, nil
+	// Triggers have no IF NOT EXISTS, so they are dropped and recreated to keep the definition idempotent
+	return []string{
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;", trigger.Name, triggerTableName),
+		fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s", trigger.Name, trigger.Timing, strings.Join(trigger.Events, " OR "), triggerTableName),
+		fmt.Sprintf("\tFOR EACH ROW EXECUTE FUNCTION %s(%s);", getQualifiedName(trigger.FunctionSchema, trigger.FunctionName), strings.Join(arguments, ", ")),
+	}
+}
+
+func getQualifiedName(schema string, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+// getIndexDefinitionName returns the index name, falling back to a name derived from the indexed columns
+func getIndexDefinitionName(tableDefinition *psqldef.Table, index psqldef.Index) string {
+	if index.Name != "" {
+		return index.Name
+	}
+	return fmt.Sprintf("idx_%s_%s", tableDefinition.Name, strings.Join(index.Columns, "_"))
 }
 
 func (w *MorpheTableFileWriter) getSeedDataLines(tableDefinition *psqldef.Table) ([]string, error) {
@@ -270,6 +533,115 @@ func (w *MorpheTableFileWriter) getSeedDataLines(tableDefinition *psqldef.Table)
 	return seedDataLines, nil
 }
 
+// getAllRollbackLines returns the lines undoing getAllTableLines: seed data, triggers, functions, indices and the table are removed
+// in reverse creation order
+func (w *MorpheTableFileWriter) getAllRollbackLines(tableDefinition *psqldef.Table) ([]string, error) {
+	allRollbackLines := []string{}
+
+	// Add header comment
+	allRollbackLines = append(allRollbackLines, fmt.Sprintf("-- Rollback of table definition for %s", tableDefinition.Name))
+	allRollbackLines = append(allRollbackLines, "")
+
+	// Remove seed data
+	if len(tableDefinition.SeedData) > 0 {
+		seedDataLines, seedErr := w.getSeedDataRollbackLines(tableDefinition)
+		if seedErr != nil {
+			return nil, seedErr
+		}
+		allRollbackLines = append(allRollbackLines, seedDataLines...)
+		allRollbackLines = append(allRollbackLines, "")
+	}
+
+	// Drop triggers and their functions
+	ownTriggers := getOwnTriggers(tableDefinition)
+	if len(ownTriggers) > 0 {
+		allRollbackLines = append(allRollbackLines, "-- Triggers")
+		for triggerIdx := len(ownTriggers) - 1; triggerIdx >= 0; triggerIdx-- {
+			allRollbackLines = append(allRollbackLines, formatDropTriggerLine(ownTriggers[triggerIdx]))
+		}
+		allRollbackLines = append(allRollbackLines, "")
+	}
+	// Shared functions may still be used by the triggers of other tables
+	ownFunctions := slices.DeleteFunc(slices.Clone(tableDefinition.Functions), func(function psqldef.Function) bool {
+		return function.Shared
+	})
+	if len(ownFunctions) > 0 {
+		allRollbackLines = append(allRollbackLines, "-- Functions")
+		for functionIdx := len(ownFunctions) - 1; functionIdx >= 0; functionIdx-- {
+			function := ownFunctions[functionIdx]
+			allRollbackLines = append(allRollbackLines, fmt.Sprintf("DROP FUNCTION IF EXISTS %s();",
+				getQualifiedName(function.Schema, function.Name)))
+		}
+		allRollbackLines = append(allRollbackLines, "")
+	}
+
+	// Drop indices
+	if len(tableDefinition.Indices) > 0 {
+		allRollbackLines = append(allRollbackLines, "-- Indices")
+		for indexIdx := len(tableDefinition.Indices) - 1; indexIdx >= 0; indexIdx-- {
+			indexName := getIndexDefinitionName(tableDefinition, tableDefinition.Indices[indexIdx])
+			if tableDefinition.Schema != "" {
+				indexName = tableDefinition.Schema + "." + indexName
+			}
+			allRollbackLines = append(allRollbackLines, fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName))
+		}
+		allRollbackLines = append(allRollbackLines, "")
+	}
+
+	// Drop table
+	allRollbackLines = append(allRollbackLines, fmt.Sprintf("DROP TABLE IF EXISTS %s;", getQualifiedTableName(tableDefinition)))
+	allRollbackLines = append(allRollbackLines, "")
+
+	return allRollbackLines, nil
+}
+
+func (w *MorpheTableFileWriter) getSeedDataRollbackLines(tableDefinition *psqldef.Table) ([]string, error) {
+	seedDataLines := []string{
+		"-- Seed Data",
+	}
+
+	columnMap := make(map[string]psqldef.TableColumn)
+	for _, col := range tableDefinition.Columns {
+		columnMap[col.Name] = col
+	}
+
+	for stmtIdx := len(tableDefinition.SeedData) - 1; stmtIdx >= 0; stmtIdx-- {
+		insertStmt := tableDefinition.SeedData[stmtIdx]
+		tableName := insertStmt.TableName
+		if insertStmt.Schema != "" {
+			tableName = insertStmt.Schema + "." + tableName
+		}
+
+		for rowIdx := len(insertStmt.Values) - 1; rowIdx >= 0; rowIdx-- {
+			valueRow := insertStmt.Values[rowIdx]
+			if len(valueRow) != len(insertStmt.Columns) {
+				return nil, fmt.Errorf("row %d has %d values but expected %d columns",
+					rowIdx, len(valueRow), len(insertStmt.Columns))
+			}
+
+			conditions := make([]string, len(valueRow))
+			for valueIdx, val := range valueRow {
+				colName := insertStmt.Columns[valueIdx]
+				col, exists := columnMap[colName]
+				if !exists {
+					return nil, fmt.Errorf("column '%s' in seed data not found in table definition", colName)
+				}
+
+				if val == nil {
+					conditions[valueIdx] = colName + " IS NULL"
+					continue
+				}
+				conditions[valueIdx] = fmt.Sprintf("%s = %s", colName, w.formatSQLValue(val, col.Type))
+			}
+
+			seedDataLines = append(seedDataLines, fmt.Sprintf("DELETE FROM %s WHERE %s;",
+				tableName, strings.Join(conditions, " AND ")))
+		}
+	}
+
+	return seedDataLines, nil
+}
+
 // validateValueType checks if a value is compatible with the column type
 func (w *MorpheTableFileWriter) validateValueType(value any, column psqldef.TableColumn) error {
 	if value == nil {
@@ -352,3 +724,12 @@ func (w *MorpheTableFileWriter) formatSQLValue(value any, columnType psqldef.PSQ
 		return fmt.Sprintf("'%v'", v)
 	}
 }
+
+// getForeignKeyOnDeleteAction returns the ON DELETE action of a foreign key, listing the columns SET NULL clears when
+// it is limited to some of them
+func getForeignKeyOnDeleteAction(foreignKey psqldef.ForeignKey) string {
+	if foreignKey.OnDelete == cfg.ReferentialActionSetNull && len(foreignKey.SetNullColumnNames) > 0 {
+		return fmt.Sprintf("%s (%s)", foreignKey.OnDelete, strings.Join(foreignKey.SetNullColumnNames, ", "))
+	}
+	return foreignKey.OnDelete
+}
@@ -0,0 +1,394 @@
+package compile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/stretchr/testify/suite"
+)
+
+type MorpheTableFileWriterTestSuite struct {
+	suite.Suite
+}
+
+func TestMorpheTableFileWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(MorpheTableFileWriterTestSuite))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_CompositePrimaryKey() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "accounts",
+		Columns: []psqldef.TableColumn{
+			{Name: "tenant_id", Type: psqldef.PSQLTypeInteger, NotNull: true, PrimaryKey: true},
+			{Name: "code", Type: psqldef.PSQLTypeText, NotNull: true, PrimaryKey: true},
+			{Name: "name", Type: psqldef.PSQLTypeText, NotNull: true},
+		},
+		ForeignKeys:       []psqldef.ForeignKey{},
+		Indices:           []psqldef.Index{},
+		UniqueConstraints: []psqldef.UniqueConstraint{},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "accounts.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Table definition for accounts
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.accounts (
+	tenant_id INTEGER NOT NULL,
+	code TEXT NOT NULL,
+	name TEXT NOT NULL,
+	PRIMARY KEY (tenant_id, code)
+);
+
+`, string(tableContents))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_CheckConstraints() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "age", Type: psqldef.PSQLTypeInteger, NotNull: true, Default: "0"},
+		},
+		ForeignKeys: []psqldef.ForeignKey{},
+		Indices:     []psqldef.Index{},
+		UniqueConstraints: []psqldef.UniqueConstraint{
+			{TableName: "people", ColumnNames: []string{"age"}},
+		},
+		CheckConstraints: []psqldef.CheckConstraint{
+			{Schema: "public", Name: "ck_people_age_min", TableName: "people", Expression: "age >= 0"},
+		},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "people.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Table definition for people
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.people (
+	id SERIAL PRIMARY KEY,
+	age INTEGER NOT NULL DEFAULT 0,
+	UNIQUE (age),
+	CONSTRAINT ck_people_age_min CHECK (age >= 0)
+);
+
+`, string(tableContents))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_ForeignKeyActions() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "company_id", Type: psqldef.PSQLTypeInteger},
+		},
+		ForeignKeys: []psqldef.ForeignKey{
+			{
+				Schema:         "public",
+				Name:           "fk_people_company_id",
+				TableName:      "people",
+				ColumnNames:    []string{"company_id"},
+				RefSchema:      "public",
+				RefTableName:   "companies",
+				RefColumnNames: []string{"id"},
+				OnDelete:       "SET NULL",
+				OnUpdate:       "CASCADE",
+			},
+		},
+		Indices:           []psqldef.Index{},
+		UniqueConstraints: []psqldef.UniqueConstraint{},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "people.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Table definition for people
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.people (
+	id SERIAL PRIMARY KEY,
+	company_id INTEGER,
+	CONSTRAINT fk_people_company_id FOREIGN KEY (company_id)
+		REFERENCES public.companies (id)
+		ON DELETE SET NULL
+		ON UPDATE CASCADE
+);
+
+`, string(tableContents))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_SharedFunction_Rollbacks() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:            compile.MorpheTableTypeModels,
+		TargetDirPath:   targetDirPath,
+		EnableRollbacks: true,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "updated_at", Type: psqldef.PSQLTypeTimestampTZ, NotNull: true, Default: "NOW()"},
+		},
+		Functions: []psqldef.Function{
+			{
+				Schema:   "public",
+				Name:     "set_updated_at",
+				Returns:  "trigger",
+				Language: "plpgsql",
+				Body: []string{
+					"BEGIN",
+					"\tNEW.updated_at = NOW();",
+					"\tRETURN NEW;",
+					"END;",
+				},
+				Shared: true,
+			},
+		},
+		Triggers: []psqldef.Trigger{
+			{
+				Schema:         "public",
+				Name:           "trg_set_updated_at",
+				TableName:      "people",
+				Timing:         "BEFORE",
+				Events:         []string{"UPDATE"},
+				FunctionSchema: "public",
+				FunctionName:   "set_updated_at",
+			},
+		},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	rollbackContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "people.down.sql"))
+	suite.Nil(readErr)
+	// The shared function may still be used by other tables
+	suite.Equal(`-- Rollback of table definition for people
+
+-- Triggers
+DROP TRIGGER IF EXISTS trg_set_updated_at ON public.people;
+
+DROP TABLE IF EXISTS public.people;
+
+`, string(rollbackContents))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_PartialIndex() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "email", Type: psqldef.PSQLTypeText, NotNull: true},
+			{Name: "deleted_at", Type: psqldef.PSQLTypeTimestampTZ},
+		},
+		Indices: []psqldef.Index{
+			{Name: "idx_people_email", TableName: "people", Columns: []string{"email"}, IsUnique: true, Where: "deleted_at IS NULL"},
+		},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "people.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Table definition for people
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.people (
+	id SERIAL PRIMARY KEY,
+	email TEXT NOT NULL,
+	deleted_at TIMESTAMPTZ
+);
+
+-- Indices
+CREATE UNIQUE INDEX IF NOT EXISTS idx_people_email ON public.people (email) WHERE deleted_at IS NULL;
+
+`, string(tableContents))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_SetNullColumns() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "tenant_id", Type: psqldef.PSQLTypeUUID, NotNull: true},
+			{Name: "manager_id", Type: psqldef.PSQLTypeInteger},
+		},
+		ForeignKeys: []psqldef.ForeignKey{
+			{
+				Schema:             "public",
+				Name:               "fk_people_tenant_id_manager_id",
+				TableName:          "people",
+				ColumnNames:        []string{"tenant_id", "manager_id"},
+				RefSchema:          "public",
+				RefTableName:       "managers",
+				RefColumnNames:     []string{"tenant_id", "id"},
+				OnDelete:           "SET NULL",
+				SetNullColumnNames: []string{"manager_id"},
+			},
+		},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "people.sql"))
+	suite.Nil(readErr)
+	suite.Contains(string(tableContents), `	CONSTRAINT fk_people_tenant_id_manager_id FOREIGN KEY (tenant_id, manager_id)
+		REFERENCES public.managers (tenant_id, id)
+		ON DELETE SET NULL (manager_id)
+);`)
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_RowLevelSecurity() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	tenantCondition := "tenant_id = current_setting('app.tenant_id')::UUID"
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "people",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "tenant_id", Type: psqldef.PSQLTypeUUID, NotNull: true, Default: "current_setting('app.tenant_id')::UUID"},
+		},
+		UniqueConstraints: []psqldef.UniqueConstraint{
+			{Schema: "public", Name: "uk_people_tenant_id_id", TableName: "people", ColumnNames: []string{"tenant_id", "id"}},
+		},
+		RowLevelSecurity: true,
+		Policies: []psqldef.Policy{
+			{
+				Name:      "people_tenant_isolation",
+				TableName: "people",
+				Command:   "ALL",
+				Using:     tenantCondition,
+				WithCheck: tenantCondition,
+			},
+		},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "people.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Table definition for people
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.people (
+	id SERIAL PRIMARY KEY,
+	tenant_id UUID NOT NULL DEFAULT current_setting('app.tenant_id')::UUID,
+	UNIQUE (tenant_id, id)
+);
+
+-- Row-level security
+ALTER TABLE public.people ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS people_tenant_isolation ON public.people;
+CREATE POLICY people_tenant_isolation ON public.people
+	FOR ALL
+	USING (tenant_id = current_setting('app.tenant_id')::UUID)
+	WITH CHECK (tenant_id = current_setting('app.tenant_id')::UUID);
+
+`, string(tableContents))
+}
+
+func (suite *MorpheTableFileWriterTestSuite) TestWriteTable_Partitioned() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheTableFileWriter{
+		Type:          compile.MorpheTableTypeModels,
+		TargetDirPath: targetDirPath,
+	}
+
+	table := &psqldef.Table{
+		Schema: "public",
+		Name:   "events",
+		Columns: []psqldef.TableColumn{
+			{Name: "id", Type: psqldef.PSQLTypeSerial, PrimaryKey: true},
+			{Name: "occurred_at", Type: psqldef.PSQLTypeTimestampTZ, PrimaryKey: true},
+		},
+		PartitionStrategy: "RANGE",
+		PartitionColumns:  []string{"occurred_at"},
+		Partitions: []psqldef.TablePartition{
+			{Name: "events_2024_12", Bound: "FROM ('2024-12-01') TO ('2025-01-01')"},
+			{Name: "events_default"},
+		},
+	}
+
+	_, writeErr := writer.WriteTable(table)
+	suite.Nil(writeErr)
+
+	tableContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "events.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Table definition for events
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.events (
+	id SERIAL NOT NULL,
+	occurred_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (id, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+-- Partitions
+CREATE TABLE IF NOT EXISTS public.events_2024_12 PARTITION OF public.events FOR VALUES FROM ('2024-12-01') TO ('2025-01-01');
+CREATE TABLE IF NOT EXISTS public.events_default PARTITION OF public.events DEFAULT;
+
+`, string(tableContents))
+}
@@ -11,9 +11,17 @@ import (
 
 type MorpheViewFileWriter struct {
 	TargetDirPath string
+
+	// EnableRollbacks writes each view as an up/down migration pair (e.g., "person_entities.up.sql" and
+	// "person_entities.down.sql") instead of a single definition file.
+	EnableRollbacks bool
 }
 
 func (w *MorpheViewFileWriter) WriteView(viewDefinition *psqldef.View) ([]byte, error) {
+	return w.WriteViewWithOrder(viewDefinition, 0)
+}
+
+func (w *MorpheViewFileWriter) WriteViewWithOrder(viewDefinition *psqldef.View, order int) ([]byte, error) {
 	allViewLines, allLinesErr := w.getAllViewLines(viewDefinition)
 	if allLinesErr != nil {
 		return nil, allLinesErr
@@ -24,7 +32,54 @@ func (w *MorpheViewFileWriter) WriteView(viewDefinition *psqldef.View) ([]byte,
 		return nil, viewContentsErr
 	}
 
-	return sqlfile.WriteSQLDefinitionFile(w.TargetDirPath, viewDefinition.Name, viewFileContents)
+	if !w.EnableRollbacks {
+		return sqlfile.WriteSQLDefinitionFileWithOrder(w.TargetDirPath, viewDefinition.Name, viewFileContents, order)
+	}
+
+	rollbackFileContents, rollbackContentsErr := core.LinesToString(w.getAllRollbackLines(viewDefinition))
+	if rollbackContentsErr != nil {
+		return nil, rollbackContentsErr
+	}
+
+	return sqlfile.WriteSQLMigrationFilesWithOrder(w.TargetDirPath, viewDefinition.Name, viewFileContents, rollbackFileContents, order)
+}
+
+func (w *MorpheViewFileWriter) getAllRollbackLines(viewDefinition *psqldef.View) []string {
+	viewName := viewDefinition.Name
+	if viewDefinition.Schema != "" {
+		viewName = viewDefinition.Schema + "." + viewName
+	}
+
+	rollbackLines := []string{
+		fmt.Sprintf("-- Rollback of view definition for %s", viewDefinition.Name),
+		"",
+	}
+
+	// Drop triggers and functions, indices are dropped with the materialized view
+	if len(viewDefinition.Triggers) > 0 {
+		rollbackLines = append(rollbackLines, "-- Triggers")
+		for triggerIdx := len(viewDefinition.Triggers) - 1; triggerIdx >= 0; triggerIdx-- {
+			trigger := viewDefinition.Triggers[triggerIdx]
+			rollbackLines = append(rollbackLines, fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;",
+				trigger.Name, getQualifiedName(trigger.Schema, trigger.TableName)))
+		}
+		rollbackLines = append(rollbackLines, "")
+	}
+	if len(viewDefinition.Functions) > 0 {
+		rollbackLines = append(rollbackLines, "-- Functions")
+		for functionIdx := len(viewDefinition.Functions) - 1; functionIdx >= 0; functionIdx-- {
+			function := viewDefinition.Functions[functionIdx]
+			rollbackLines = append(rollbackLines, fmt.Sprintf("DROP FUNCTION IF EXISTS %s();",
+				getQualifiedName(function.Schema, function.Name)))
+		}
+		rollbackLines = append(rollbackLines, "")
+	}
+
+	dropStatement := "DROP VIEW IF EXISTS %s;"
+	if viewDefinition.Materialized {
+		dropStatement = "DROP MATERIALIZED VIEW IF EXISTS %s;"
+	}
+	return append(rollbackLines, fmt.Sprintf(dropStatement, viewName), "")
 }
 
 func (w *MorpheViewFileWriter) getAllViewLines(viewDefinition *psqldef.View) ([]string, error) {
@@ -40,15 +95,60 @@ func (w *MorpheViewFileWriter) getAllViewLines(viewDefinition *psqldef.View) ([]
 		allViewLines = append(allViewLines, "")
 	}
 
-	// Create view
+	viewDefinitionLines, viewDefinitionErr := w.getViewDefinitionLines(viewDefinition)
+	if viewDefinitionErr != nil {
+		return nil, viewDefinitionErr
+	}
+	allViewLines = append(allViewLines, viewDefinitionLines...)
+
+	return allViewLines, nil
+}
+
+// getViewDefinitionLines returns the view lines without the header and schema creation
+func (w *MorpheViewFileWriter) getViewDefinitionLines(viewDefinition *psqldef.View) ([]string, error) {
 	viewLines, viewErr := w.getCreateViewLines(viewDefinition)
 	if viewErr != nil {
 		return nil, viewErr
 	}
-	allViewLines = append(allViewLines, viewLines...)
-	allViewLines = append(allViewLines, "")
+	viewLines = append(viewLines, "")
 
-	return allViewLines, nil
+	// Add indices
+	if len(viewDefinition.Indices) > 0 {
+		viewLines = append(viewLines, "-- Indices")
+		for _, index := range viewDefinition.Indices {
+			viewLines = append(viewLines, w.formatCreateIndexLine(viewDefinition, index))
+		}
+		viewLines = append(viewLines, "")
+	}
+
+	// Add functions
+	if len(viewDefinition.Functions) > 0 {
+		viewLines = append(viewLines, "-- Functions")
+		for _, function := range viewDefinition.Functions {
+			viewLines = append(viewLines, formatCreateFunctionLines(function)...)
+		}
+	}
+
+	// Add triggers
+	if len(viewDefinition.Triggers) > 0 {
+		viewLines = append(viewLines, "-- Triggers")
+		for _, trigger := range viewDefinition.Triggers {
+			viewLines = append(viewLines, formatCreateTriggerLines(trigger)...)
+		}
+		viewLines = append(viewLines, "")
+	}
+
+	return viewLines, nil
+}
+
+func (w *MorpheViewFileWriter) formatCreateIndexLine(viewDefinition *psqldef.View, index psqldef.Index) string {
+	unique := ""
+	if index.IsUnique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s (%s)%s;",
+		unique, index.Name, getQualifiedName(viewDefinition.Schema, viewDefinition.Name), strings.Join(index.Columns, ", "),
+		formatIndexWhereClause(index))
 }
 
 func (w *MorpheViewFileWriter) getCreateViewLines(viewDefinition *psqldef.View) ([]string, error) {
@@ -65,6 +165,14 @@ func (w *MorpheViewFileWriter) getCreateViewLines(viewDefinition *psqldef.View)
 		fmt.Sprintf("CREATE OR REPLACE VIEW %s AS", viewName),
 		"SELECT",
 	}
+	if viewDefinition.Materialized {
+		// Materialized views cannot be replaced, so an existing one is dropped and recreated with the current query
+		viewLines = []string{
+			fmt.Sprintf("DROP MATERIALIZED VIEW IF EXISTS %s;", viewName),
+			fmt.Sprintf("CREATE MATERIALIZED VIEW %s AS", viewName),
+			"SELECT",
+		}
+	}
 
 	columnRefs := []string{}
 	for _, column := range viewDefinition.Columns {
@@ -82,6 +190,23 @@ func (w *MorpheViewFileWriter) getCreateViewLines(viewDefinition *psqldef.View)
 
 	viewLines = append(viewLines, strings.Join(columnRefs, ",\n"))
 
+	sourceLines, sourceErr := formatViewSourceLines(viewDefinition)
+	if sourceErr != nil {
+		return nil, sourceErr
+	}
+	viewLines = append(viewLines, sourceLines...)
+
+	if viewDefinition.WhereClause != "" {
+		viewLines = append(viewLines, fmt.Sprintf("WHERE %s", viewDefinition.WhereClause))
+	}
+
+	viewLines[len(viewLines)-1] += ";"
+
+	return viewLines, nil
+}
+
+// formatViewSourceLines returns the FROM and JOIN lines a view selects its rows from
+func formatViewSourceLines(viewDefinition *psqldef.View) ([]string, error) {
 	if viewDefinition.FromTable == "" {
 		return nil, fmt.Errorf("view has no source table")
 	}
@@ -93,7 +218,7 @@ func (w *MorpheViewFileWriter) getCreateViewLines(viewDefinition *psqldef.View)
 
 	fromTable := viewDefinition.FromTable
 
-	viewLines = append(viewLines, fmt.Sprintf("FROM %s.%s", fromSchema, fromTable))
+	viewLines := []string{fmt.Sprintf("FROM %s.%s", fromSchema, fromTable)}
 
 	for _, join := range viewDefinition.Joins {
 		joinTable := join.Table
@@ -117,11 +242,5 @@ func (w *MorpheViewFileWriter) getCreateViewLines(viewDefinition *psqldef.View)
 		}
 	}
 
-	if viewDefinition.WhereClause != "" {
-		viewLines = append(viewLines, fmt.Sprintf("WHERE %s", viewDefinition.WhereClause))
-	}
-
-	viewLines[len(viewLines)-1] += ";"
-
 	return viewLines, nil
 }
@@ -0,0 +1,196 @@
+package compile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+	"github.com/stretchr/testify/suite"
+)
+
+type MorpheViewFileWriterTestSuite struct {
+	suite.Suite
+}
+
+func TestMorpheViewFileWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(MorpheViewFileWriterTestSuite))
+}
+
+func (suite *MorpheViewFileWriterTestSuite) getMaterializedView() *psqldef.View {
+	return &psqldef.View{
+		Schema:     "public",
+		Name:       "person_entities",
+		FromSchema: "public",
+		FromTable:  "people",
+		Columns: []psqldef.ViewColumn{
+			{Name: "id", SourceRef: "people.id"},
+		},
+		Joins:        []psqldef.JoinClause{},
+		Materialized: true,
+		Indices: []psqldef.Index{
+			{Name: "idx_person_entities_id", TableName: "person_entities", Columns: []string{"id"}, IsUnique: true},
+		},
+		Functions: []psqldef.Function{
+			{
+				Schema:   "public",
+				Name:     "refresh_person_entities",
+				Returns:  "void",
+				Language: "plpgsql",
+				Body: []string{
+					"BEGIN",
+					"\tREFRESH MATERIALIZED VIEW CONCURRENTLY public.person_entities;",
+					"END;",
+				},
+			},
+		},
+	}
+}
+
+func (suite *MorpheViewFileWriterTestSuite) TestWriteView_Materialized() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheViewFileWriter{
+		TargetDirPath: targetDirPath,
+	}
+
+	_, writeErr := writer.WriteView(suite.getMaterializedView())
+	suite.Nil(writeErr)
+
+	viewContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "person_entities.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- View definition for person_entities
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+DROP MATERIALIZED VIEW IF EXISTS public.person_entities;
+CREATE MATERIALIZED VIEW public.person_entities AS
+SELECT
+	people.id
+FROM public.people;
+
+-- Indices
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_entities_id ON public.person_entities (id);
+
+-- Functions
+CREATE OR REPLACE FUNCTION public.refresh_person_entities()
+RETURNS void
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	REFRESH MATERIALIZED VIEW CONCURRENTLY public.person_entities;
+END;
+$$;
+
+`, string(viewContents))
+}
+
+func (suite *MorpheViewFileWriterTestSuite) TestWriteView_Materialized_Rollbacks() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheViewFileWriter{
+		TargetDirPath:   targetDirPath,
+		EnableRollbacks: true,
+	}
+
+	_, writeErr := writer.WriteView(suite.getMaterializedView())
+	suite.Nil(writeErr)
+
+	rollbackContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "person_entities.down.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- Rollback of view definition for person_entities
+
+-- Functions
+DROP FUNCTION IF EXISTS public.refresh_person_entities();
+
+DROP MATERIALIZED VIEW IF EXISTS public.person_entities;
+
+`, string(rollbackContents))
+}
+
+func (suite *MorpheViewFileWriterTestSuite) TestWriteView_Triggers() {
+	targetDirPath := suite.T().TempDir()
+	writer := &compile.MorpheViewFileWriter{
+		TargetDirPath:   targetDirPath,
+		EnableRollbacks: true,
+	}
+
+	view := &psqldef.View{
+		Schema:     "public",
+		Name:       "person_entities",
+		FromSchema: "public",
+		FromTable:  "people",
+		Columns: []psqldef.ViewColumn{
+			{Name: "id", SourceRef: "people.id"},
+		},
+		Joins: []psqldef.JoinClause{},
+		Functions: []psqldef.Function{
+			{
+				Schema:   "public",
+				Name:     "write_person_entities",
+				Returns:  "trigger",
+				Language: "plpgsql",
+				Body: []string{
+					"BEGIN",
+					"\tRETURN NEW;",
+					"END;",
+				},
+			},
+		},
+		Triggers: []psqldef.Trigger{
+			{
+				Schema:         "public",
+				Name:           "trg_write_person_entities",
+				TableName:      "person_entities",
+				Timing:         "INSTEAD OF",
+				Events:         []string{"INSERT", "UPDATE", "DELETE"},
+				FunctionSchema: "public",
+				FunctionName:   "write_person_entities",
+			},
+		},
+	}
+
+	_, writeErr := writer.WriteView(view)
+	suite.Nil(writeErr)
+
+	viewContents, readErr := os.ReadFile(filepath.Join(targetDirPath, "person_entities.up.sql"))
+	suite.Nil(readErr)
+	suite.Equal(`-- View definition for person_entities
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE OR REPLACE VIEW public.person_entities AS
+SELECT
+	people.id
+FROM public.people;
+
+-- Functions
+CREATE OR REPLACE FUNCTION public.write_person_entities()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	RETURN NEW;
+END;
+$$;
+
+-- Triggers
+DROP TRIGGER IF EXISTS trg_write_person_entities ON public.person_entities;
+CREATE TRIGGER trg_write_person_entities INSTEAD OF INSERT OR UPDATE OR DELETE ON public.person_entities
+	FOR EACH ROW EXECUTE FUNCTION public.write_person_entities();
+
+`, string(viewContents))
+
+	rollbackContents, rollbackReadErr := os.ReadFile(filepath.Join(targetDirPath, "person_entities.down.sql"))
+	suite.Nil(rollbackReadErr)
+	suite.Equal(`-- Rollback of view definition for person_entities
+
+-- Triggers
+DROP TRIGGER IF EXISTS trg_write_person_entities ON public.person_entities;
+
+-- Functions
+DROP FUNCTION IF EXISTS public.write_person_entities();
+
+DROP VIEW IF EXISTS public.person_entities;
+
+`, string(rollbackContents))
+}
@@ -26,10 +26,10 @@ func AbbreviateIdentifier(identifier string, useHash bool) string {
 	parts := strings.Split(identifier, "_")
 	abbreviated := make([]string, len(parts))
 
-	// For non-prefixes (like "fk", "uk", "idx"), keep them as is
+	// For non-prefixes (like "fk", "uk", "ck", "idx"), keep them as is
 	// These are typically important for identifying the type of object
 	prefixParts := 0
-	if len(parts) > 0 && (parts[0] == "fk" || parts[0] == "uk" || parts[0] == "idx") {
+	if len(parts) > 0 && (parts[0] == "fk" || parts[0] == "uk" || parts[0] == "ck" || parts[0] == "idx") {
 		abbreviated[0] = parts[0]
 		prefixParts = 1
 	}
@@ -70,6 +70,17 @@ func GetTableNameFromModel(modelName string) string {
 	return AbbreviateIdentifier(tableName, false)
 }
 
+// GetEnumTableName returns the snake_case, pluralized name of the lookup table for an enum
+func GetEnumTableName(enumName string) string {
+	return Pluralize(strcase.ToSnakeCaseLower(enumName))
+}
+
+// GetEnumTypeName returns the snake_case name of the native PostgreSQL ENUM type for an enum
+func GetEnumTypeName(enumName string) string {
+	typeName := strcase.ToSnakeCaseLower(enumName)
+	return AbbreviateIdentifier(typeName, false)
+}
+
 // GetColumnNameFromField returns the snake_case column name for a field
 func GetColumnNameFromField(fieldName string) string {
 	columnName := strcase.ToSnakeCaseLower(fieldName)
@@ -101,6 +112,52 @@ func GetJunctionTableForeignKeyConstraintName(junctionTableName, modelName, idFi
 	return AbbreviateIdentifier(constraintName, true)
 }
 
+// GetPolymorphicCheckFunctionName generates a name for the trigger function validating a polymorphic relation
+func GetPolymorphicCheckFunctionName(tableName, relationName string) string {
+	functionName := fmt.Sprintf("check_%s_%s",
+		tableName,
+		strcase.ToSnakeCaseLower(relationName))
+	return AbbreviateIdentifier(functionName, true)
+}
+
+// GetPolymorphicCleanupFunctionName generates a name for the trigger function removing rows referencing a deleted polymorphic target
+func GetPolymorphicCleanupFunctionName(tableName, relationName string) string {
+	functionName := fmt.Sprintf("cleanup_%s_%s",
+		tableName,
+		strcase.ToSnakeCaseLower(relationName))
+	return AbbreviateIdentifier(functionName, true)
+}
+
+// GetRefreshFunctionName generates a name for the function refreshing a materialized view
+func GetRefreshFunctionName(viewName string) string {
+	functionName := fmt.Sprintf("refresh_%s", viewName)
+	return AbbreviateIdentifier(functionName, true)
+}
+
+// GetViewWriteFunctionName generates a name for the INSTEAD OF trigger function writing through a view
+func GetViewWriteFunctionName(viewName string) string {
+	functionName := fmt.Sprintf("write_%s", viewName)
+	return AbbreviateIdentifier(functionName, true)
+}
+
+// GetTriggerName generates a name for a trigger executing a function
+func GetTriggerName(functionName string) string {
+	triggerName := fmt.Sprintf("trg_%s", functionName)
+	return AbbreviateIdentifier(triggerName, true)
+}
+
+// GetTenantPolicyName generates a name for the row-level security policy isolating the tenants of a table
+func GetTenantPolicyName(tableName string) string {
+	policyName := fmt.Sprintf("%s_tenant_isolation", tableName)
+	return AbbreviateIdentifier(policyName, true)
+}
+
+// GetPartitionTableName generates a name for a partition of a partitioned table, e.g. "events_2024_01"
+func GetPartitionTableName(tableName, partitionName string) string {
+	partitionTableName := fmt.Sprintf("%s_%s", tableName, partitionName)
+	return AbbreviateIdentifier(partitionTableName, true)
+}
+
 // GetIndexName generates a name for an index
 func GetIndexName(tableName, columnName string) string {
 	indexName := fmt.Sprintf("idx_%s_%s", tableName, columnName)
@@ -115,6 +172,15 @@ func GetUniqueConstraintName(tableName string, columnNames ...string) string {
 	return AbbreviateIdentifier(constraintName, true)
 }
 
+// GetCheckConstraintName generates a name for a check constraint on a column, e.g. "ck_people_age_min"
+func GetCheckConstraintName(tableName, columnName, checkName string) string {
+	constraintName := fmt.Sprintf("ck_%s_%s_%s",
+		tableName,
+		columnName,
+		strcase.ToSnakeCaseLower(checkName))
+	return AbbreviateIdentifier(constraintName, true)
+}
+
 // GetJunctionTableName generates a name for a junction table
 func GetJunctionTableName(sourceModelName, targetModelName string) string {
 	// Generate the singular form of the junction table name
@@ -127,6 +193,28 @@ func GetJunctionTableName(sourceModelName, targetModelName string) string {
 	return AbbreviateIdentifier(tableName, false)
 }
 
+// GetJoinAlias generates a table alias for a join reached through a relation path, e.g. "work_contact_addresses"
+// for the path WorkContact.Address
+func GetJoinAlias(relationPath ...string) string {
+	pathParts := make([]string, len(relationPath))
+	for partIdx, relationName := range relationPath {
+		pathParts[partIdx] = strcase.ToSnakeCaseLower(relationName)
+	}
+	return AbbreviateIdentifier(Pluralize(strings.Join(pathParts, "_")), true)
+}
+
+// GetJunctionJoinAlias generates a table alias for a junction table joined from the end of a relation path
+func GetJunctionJoinAlias(junctionTableName string, relationPath ...string) string {
+	if len(relationPath) == 0 {
+		return junctionTableName
+	}
+	pathParts := make([]string, len(relationPath))
+	for partIdx, relationName := range relationPath {
+		pathParts[partIdx] = strcase.ToSnakeCaseLower(relationName)
+	}
+	return AbbreviateIdentifier(strings.Join(pathParts, "_")+"_"+junctionTableName, true)
+}
+
 // GetJunctionTableUniqueConstraintName generates a name for a junction table unique constraint
 func GetJunctionTableUniqueConstraintName(
 	junctionTableName string,
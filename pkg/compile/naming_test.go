@@ -105,6 +105,17 @@ func (suite *NamingTestSuite) TestGetJunctionTableName() {
 	suite.Equal("order_items", compile.GetJunctionTableName("Order", "Item"))
 }
 
+func (suite *NamingTestSuite) TestGetJoinAlias() {
+	suite.Equal("contact_infos", compile.GetJoinAlias("ContactInfo"))
+	suite.Equal("work_contact_addresses", compile.GetJoinAlias("WorkContact", "Address"))
+	suite.Equal("home_contact_addresses", compile.GetJoinAlias("HomeContact", "Address"))
+}
+
+func (suite *NamingTestSuite) TestGetJunctionJoinAlias() {
+	suite.Equal("person_projects", compile.GetJunctionJoinAlias("person_projects"))
+	suite.Equal("work_contact_contact_projects", compile.GetJunctionJoinAlias("contact_projects", "WorkContact"))
+}
+
 func (suite *NamingTestSuite) TestGetJunctionTableUniqueConstraintName() {
 	suite.Equal("uk_user_roles_user_id_role_id",
 		compile.GetJunctionTableUniqueConstraintName("user_roles", "User", "ID", "Role", "ID"))
@@ -209,6 +220,15 @@ func (suite *NamingTestSuite) TestGetTableNameFromModel_LongNames() {
 	suite.Equal("ex_lo_mo_na_th_wo_ex_po_sq_id_le_li", result)
 }
 
+func (suite *NamingTestSuite) TestGetEnumTableName_LongNames() {
+	veryLongEnumName := "ExtremelyLongEnumNameThatWouldExceedPostgreSQLIdentifierLengthLimits"
+
+	// Enum lookup tables are named in full, unlike model tables
+	suite.Equal("extremely_long_enum_name_that_would_exceed_postgre_sql_identifier_length_limits",
+		compile.GetEnumTableName(veryLongEnumName))
+	suite.Equal("nationalities", compile.GetEnumTableName("Nationality"))
+}
+
 func (suite *NamingTestSuite) TestGetForeignKeyConstraintName_LongNames() {
 	longTableName := "extremely_long_table_name_that_would_exceed_postgresql_identifier_length_limits"
 	longColumnName := "another_extremely_long_column_name_that_would_also_exceed_limits"
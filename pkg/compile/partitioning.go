@@ -0,0 +1,127 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// partitionTable partitions a model table by the key fields of its partition options and adds its partitions.
+// PostgreSQL requires the primary key and unique keys of a partitioned table to include the partition key columns.
+func partitionTable(config cfg.MorpheConfig, r *registry.Registry, table *psqldef.Table, model yaml.Model, options cfg.MorphePartitionOptions) error {
+	keyColumnNames, keyErr := getPartitionKeyColumnNames(config, r, model, options)
+	if keyErr != nil {
+		return keyErr
+	}
+
+	table.PartitionStrategy = options.Strategy
+	table.PartitionColumns = keyColumnNames
+
+	for columnIdx := range table.Columns {
+		if slices.Contains(keyColumnNames, table.Columns[columnIdx].Name) {
+			table.Columns[columnIdx].PrimaryKey = true
+		}
+	}
+	for indexIdx := range table.Indices {
+		if table.Indices[indexIdx].IsUnique {
+			table.Indices[indexIdx].Columns = appendMissingColumns(table.Indices[indexIdx].Columns, keyColumnNames)
+		}
+	}
+	for constraintIdx := range table.UniqueConstraints {
+		uniqueConstraint := &table.UniqueConstraints[constraintIdx]
+		uniqueConstraint.ColumnNames = appendMissingColumns(uniqueConstraint.ColumnNames, keyColumnNames)
+	}
+
+	for _, partition := range options.Partitions {
+		table.Partitions = append(table.Partitions, psqldef.TablePartition{
+			Name:  GetPartitionTableName(table.Name, partition.Name),
+			Bound: getPartitionBound(options.Strategy, partition),
+		})
+	}
+
+	return nil
+}
+
+// getPartitionKeyColumnNames returns the columns of the partition key fields, named like the model columns
+func getPartitionKeyColumnNames(config cfg.MorpheConfig, r *registry.Registry, model yaml.Model, options cfg.MorphePartitionOptions) ([]string, error) {
+	keyColumnNames := make([]string, len(options.KeyFields))
+	for fieldIdx, keyFieldName := range options.KeyFields {
+		keyField, fieldExists := model.Fields[keyFieldName]
+		if !fieldExists {
+			return nil, fmt.Errorf("partition key field %s not found in model %s", keyFieldName, model.Name)
+		}
+		keyColumnNames[fieldIdx] = getModelFieldColumnName(config, r, keyFieldName, keyField)
+	}
+	return keyColumnNames, nil
+}
+
+// getPartitionBound returns the FOR VALUES bound of a partition, or an empty string for the DEFAULT partition
+func getPartitionBound(strategy string, partition cfg.MorphePartition) string {
+	if partition.Default {
+		return ""
+	}
+
+	switch strategy {
+	case cfg.PartitionStrategyRange:
+		return fmt.Sprintf("FROM (%s) TO (%s)", formatPartitionValues(partition.From), formatPartitionValues(partition.To))
+	case cfg.PartitionStrategyList:
+		return fmt.Sprintf("IN (%s)", formatPartitionValues(partition.Values))
+	default:
+		return fmt.Sprintf("WITH (MODULUS %d, REMAINDER %d)", partition.Modulus, partition.Remainder)
+	}
+}
+
+// formatPartitionValues quotes partition bound values, which PostgreSQL casts to the key column types
+func formatPartitionValues(values []string) string {
+	formattedValues := make([]string, len(values))
+	for valueIdx, value := range values {
+		if value == "MINVALUE" || value == "MAXVALUE" {
+			formattedValues[valueIdx] = value
+			continue
+		}
+		formattedValues[valueIdx] = quoteSQLString(value)
+	}
+	return strings.Join(formattedValues, ", ")
+}
+
+// appendMissingColumns returns the column names followed by those of the extra columns they do not include
+func appendMissingColumns(columnNames []string, extraColumnNames []string) []string {
+	for _, extraColumnName := range extraColumnNames {
+		if !slices.Contains(columnNames, extraColumnName) {
+			columnNames = append(columnNames, extraColumnName)
+		}
+	}
+	return columnNames
+}
+
+// validatePartitionedForeignKeyTargets rejects foreign keys to partitioned model tables that do not reference the
+// partition key, as partitioned tables have no unique key without it
+func validatePartitionedForeignKeyTargets(config cfg.MorpheConfig, r *registry.Registry, table *psqldef.Table) error {
+	for _, foreignKey := range table.ForeignKeys {
+		for modelName, modelOptions := range config.MorpheModelsConfig.ModelOptions {
+			if !modelOptions.Partition.IsPartitioned() || GetTableNameFromModel(modelName) != foreignKey.RefTableName {
+				continue
+			}
+			model, modelErr := r.GetModel(modelName)
+			if modelErr != nil {
+				return modelErr
+			}
+			keyColumnNames, keyErr := getPartitionKeyColumnNames(config, r, model, modelOptions.Partition)
+			if keyErr != nil {
+				return keyErr
+			}
+			for _, keyColumnName := range keyColumnNames {
+				if !slices.Contains(foreignKey.RefColumnNames, keyColumnName) {
+					return fmt.Errorf("%w: foreign key '%s' on table '%s' references partitioned table '%s'",
+						ErrPartitionedForeignKeyTarget, foreignKey.Name, table.Name, foreignKey.RefTableName)
+				}
+			}
+		}
+	}
+	return nil
+}
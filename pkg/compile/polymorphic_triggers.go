@@ -0,0 +1,173 @@
+package compile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kalo-build/go-util/strcase"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// polymorphicTarget is a model a polymorphic relation may reference, with the column its id is compared against
+type polymorphicTarget struct {
+	modelName    string
+	tableName    string
+	idColumnName string
+}
+
+// addPolymorphicTriggers adds the check and cleanup trigger functions for a polymorphic relation to the table
+// holding its "<relation>_type" and "<relation>_id" columns
+func addPolymorphicTriggers(table *psqldef.Table, r *registry.Registry, relationName string, relation yaml.ModelRelation) error {
+	targets, targetsErr := getPolymorphicTargets(r, relationName, relation)
+	if targetsErr != nil {
+		return targetsErr
+	}
+
+	typeColumnName := strcase.ToSnakeCaseLower(relationName) + "_type"
+	idColumnName := strcase.ToSnakeCaseLower(relationName) + "_id"
+	idColumnType := getColumnType(table, idColumnName)
+
+	checkFunction := psqldef.Function{
+		Schema:   table.Schema,
+		Name:     GetPolymorphicCheckFunctionName(table.Name, relationName),
+		Returns:  "trigger",
+		Language: "plpgsql",
+		Body:     getPolymorphicCheckFunctionBody(table.Schema, typeColumnName, idColumnName, idColumnType, targets),
+	}
+	cleanupFunction := psqldef.Function{
+		Schema:   table.Schema,
+		Name:     GetPolymorphicCleanupFunctionName(table.Name, relationName),
+		Returns:  "trigger",
+		Language: "plpgsql",
+		Body:     getPolymorphicCleanupFunctionBody(table, typeColumnName, idColumnName, idColumnType),
+	}
+	table.Functions = append(table.Functions, checkFunction, cleanupFunction)
+
+	table.Triggers = append(table.Triggers, psqldef.Trigger{
+		Schema:         table.Schema,
+		Name:           GetTriggerName(checkFunction.Name),
+		TableName:      table.Name,
+		Timing:         "BEFORE",
+		Events:         []string{"INSERT", "UPDATE"},
+		FunctionSchema: checkFunction.Schema,
+		FunctionName:   checkFunction.Name,
+	})
+
+	// Rows referencing a deleted target are removed, mirroring ON DELETE CASCADE
+	for _, target := range targets {
+		table.Triggers = append(table.Triggers, psqldef.Trigger{
+			Schema:         table.Schema,
+			Name:           GetTriggerName(cleanupFunction.Name),
+			TableName:      target.tableName,
+			Timing:         "AFTER",
+			Events:         []string{"DELETE"},
+			FunctionSchema: cleanupFunction.Schema,
+			FunctionName:   cleanupFunction.Name,
+			Arguments:      []string{target.modelName, target.idColumnName},
+		})
+	}
+
+	return nil
+}
+
+func getPolymorphicTargets(r *registry.Registry, relationName string, relation yaml.ModelRelation) ([]polymorphicTarget, error) {
+	targets := []polymorphicTarget{}
+	for _, forModelName := range relation.For {
+		forModel, modelErr := r.GetModel(forModelName)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+
+		primaryID, hasPrimary := forModel.Identifiers["primary"]
+		if !hasPrimary {
+			return nil, fmt.Errorf("polymorphic target model %s has no primary identifier", forModelName)
+		}
+		if len(primaryID.Fields) != 1 {
+			return nil, fmt.Errorf("polymorphic target model %s of relation '%s' must have a single-field primary identifier", forModelName, relationName)
+		}
+
+		targets = append(targets, polymorphicTarget{
+			modelName:    forModelName,
+			tableName:    GetTableNameFromModel(forModelName),
+			idColumnName: GetColumnNameFromField(primaryID.Fields[0]),
+		})
+	}
+	return targets, nil
+}
+
+// getColumnType returns the type of a table column, or TEXT if the column is not found
+func getColumnType(table *psqldef.Table, columnName string) psqldef.PSQLType {
+	for _, column := range table.Columns {
+		if column.Name == columnName {
+			return column.Type
+		}
+	}
+	return psqldef.PSQLTypeText
+}
+
+// isTextType reports whether values of the type compare equal to their text representation
+func isTextType(columnType psqldef.PSQLType) bool {
+	return columnType.GetSyntax() == psqldef.PSQLTypeText.GetSyntax()
+}
+
+func getPolymorphicCheckFunctionBody(schema string, typeColumnName string, idColumnName string, idColumnType psqldef.PSQLType, targets []polymorphicTarget) []string {
+	body := []string{
+		"BEGIN",
+		fmt.Sprintf("\tIF NEW.%s IS NULL OR NEW.%s IS NULL THEN", typeColumnName, idColumnName),
+		"\t\tRETURN NEW;",
+		"\tEND IF;",
+		"",
+	}
+
+	targetModelNames := make([]string, len(targets))
+	for targetIdx, target := range targets {
+		targetModelNames[targetIdx] = target.modelName
+
+		// Text id columns may hold the ids of targets with differently typed primary keys
+		targetIdRef := target.idColumnName
+		if isTextType(idColumnType) {
+			targetIdRef += "::text"
+		}
+
+		keyword := "ELSIF"
+		if targetIdx == 0 {
+			keyword = "IF"
+		}
+		body = append(body,
+			fmt.Sprintf("\t%s NEW.%s = '%s' THEN", keyword, typeColumnName, target.modelName),
+			fmt.Sprintf("\t\tIF NOT EXISTS (SELECT 1 FROM %s.%s WHERE %s = NEW.%s) THEN",
+				schema, target.tableName, targetIdRef, idColumnName),
+			fmt.Sprintf("\t\t\tRAISE EXCEPTION '%s %% does not reference an existing %s', NEW.%s;",
+				idColumnName, target.modelName, idColumnName),
+			"\t\tEND IF;",
+		)
+	}
+	body = append(body,
+		"\tELSE",
+		fmt.Sprintf("\t\tRAISE EXCEPTION '%s %% is not one of %s', NEW.%s;",
+			typeColumnName, strings.Join(targetModelNames, ", "), typeColumnName),
+		"\tEND IF;",
+		"",
+		"\tRETURN NEW;",
+		"END;",
+	)
+	return body
+}
+
+func getPolymorphicCleanupFunctionBody(table *psqldef.Table, typeColumnName string, idColumnName string, idColumnType psqldef.PSQLType) []string {
+	deletedIdRef := "to_jsonb(OLD) ->> TG_ARGV[1]"
+	if !isTextType(idColumnType) {
+		deletedIdRef = fmt.Sprintf("(%s)::%s", deletedIdRef, idColumnType.GetSyntax())
+	}
+
+	return []string{
+		"BEGIN",
+		fmt.Sprintf("\tDELETE FROM %s", getQualifiedTableName(table)),
+		fmt.Sprintf("\tWHERE %s = TG_ARGV[0]", typeColumnName),
+		fmt.Sprintf("\t\tAND %s = %s;", idColumnName, deletedIdRef),
+		"\tRETURN OLD;",
+		"END;",
+	}
+}
@@ -0,0 +1,254 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// forManyJunction is the junction table backing a ForMany relationship. Reciprocal ForMany relationships declared
+// on both models share the junction table of the model whose name sorts first.
+type forManyJunction struct {
+	TableName string
+	// IsOwner is true when the junction table is created alongside the relationship's model table
+	IsOwner bool
+	// OwnerModelName and OwnerRelationName name the relationship the junction table is created for
+	OwnerModelName    string
+	OwnerRelationName string
+	// SourceColumnNames reference the model declaring the relationship, TargetColumnNames its target model
+	SourceColumnNames []string
+	TargetColumnNames []string
+}
+
+// resolveForManyJunction returns the junction table of a non-polymorphic ForMany relationship, shared with its
+// reciprocal ForMany relationship if the target model declares one
+func resolveForManyJunction(r *registry.Registry, model yaml.Model, relationName string, relation yaml.ModelRelation) (forManyJunction, error) {
+	targetModelName := yamlops.GetRelationTargetName(relationName, relation.Aliased)
+	targetModel, targetModelErr := r.GetModel(targetModelName)
+	if targetModelErr != nil {
+		return forManyJunction{}, fmt.Errorf("target model %s not found in registry (via relationship %s)", targetModelName, relationName)
+	}
+
+	sourcePrimaryIdFields, sourcePrimaryErr := getModelPrimaryIdFields(model)
+	if sourcePrimaryErr != nil {
+		return forManyJunction{}, sourcePrimaryErr
+	}
+	targetPrimaryIdFields, targetPrimaryErr := getModelPrimaryIdFields(targetModel)
+	if targetPrimaryErr != nil {
+		return forManyJunction{}, targetPrimaryErr
+	}
+
+	reciprocalName, reciprocalErr := getReciprocalForManyRelation(model, relationName, targetModel)
+	if reciprocalErr != nil {
+		return forManyJunction{}, reciprocalErr
+	}
+
+	if reciprocalName == "" || model.Name < targetModel.Name {
+		return forManyJunction{
+			TableName:         GetJunctionTableName(model.Name, relationName),
+			IsOwner:           true,
+			OwnerModelName:    model.Name,
+			OwnerRelationName: relationName,
+			SourceColumnNames: getForeignKeyColumnNames(model.Name, sourcePrimaryIdFields),
+			TargetColumnNames: getForeignKeyColumnNames(relationName, targetPrimaryIdFields),
+		}, nil
+	}
+	return forManyJunction{
+		TableName:         GetJunctionTableName(targetModel.Name, reciprocalName),
+		IsOwner:           false,
+		OwnerModelName:    targetModel.Name,
+		OwnerRelationName: reciprocalName,
+		SourceColumnNames: getForeignKeyColumnNames(reciprocalName, sourcePrimaryIdFields),
+		TargetColumnNames: getForeignKeyColumnNames(targetModel.Name, targetPrimaryIdFields),
+	}, nil
+}
+
+// getReciprocalForManyRelation returns the ForMany relationship on the target model pointing back at the model, or
+// an empty name if the relationship is one-directional. Self-referencing relationships are never paired.
+func getReciprocalForManyRelation(model yaml.Model, relationName string, targetModel yaml.Model) (string, error) {
+	if model.Name == targetModel.Name {
+		return "", nil
+	}
+
+	reciprocalNames := getForManyRelationNamesTo(targetModel, model.Name)
+	if len(reciprocalNames) == 0 {
+		return "", nil
+	}
+
+	siblingNames := getForManyRelationNamesTo(model, targetModel.Name)
+	if len(reciprocalNames) > 1 || len(siblingNames) > 1 {
+		return "", fmt.Errorf("%w: model %s declares ForMany relationships (%s) to model %s, which declares ForMany relationships (%s) back",
+			ErrAmbiguousReciprocalRelation, model.Name, strings.Join(siblingNames, ", "), targetModel.Name, strings.Join(reciprocalNames, ", "))
+	}
+	return reciprocalNames[0], nil
+}
+
+// validateSharedJunctionOptions validates that a ForMany relationship sharing the junction table of its reciprocal
+// relationship sets no options disagreeing with the owning relationship, whose options the junction table follows
+func validateSharedJunctionOptions(modelsConfig cfg.MorpheModelsConfig, modelName string, relationName string, junction forManyJunction) error {
+	options := modelsConfig.ModelOptions[modelName].Relations[relationName]
+	ownerOptions := modelsConfig.ModelOptions[junction.OwnerModelName].Relations[junction.OwnerRelationName]
+
+	conflictingOptions := []string{}
+	if options.OnDelete != "" && options.OnDelete != ownerOptions.OnDelete {
+		conflictingOptions = append(conflictingOptions, "OnDelete")
+	}
+	if options.OnUpdate != "" && options.OnUpdate != ownerOptions.OnUpdate {
+		conflictingOptions = append(conflictingOptions, "OnUpdate")
+	}
+	if options.Optional && !ownerOptions.Optional {
+		conflictingOptions = append(conflictingOptions, "Optional")
+	}
+	if len(conflictingOptions) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s of %s.%s disagree with %s.%s, whose junction table it shares", ErrReciprocalRelationOptionConflict,
+		strings.Join(conflictingOptions, ", "), modelName, relationName, junction.OwnerModelName, junction.OwnerRelationName)
+}
+
+// getForManyRelationNamesTo returns the sorted names of a model's non-polymorphic ForMany relationships to a target
+func getForManyRelationNamesTo(model yaml.Model, targetModelName string) []string {
+	relationNames := []string{}
+	for _, relationName := range core.MapKeysSorted(model.Related) {
+		relation := model.Related[relationName]
+		if !yamlops.IsRelationFor(relation.Type) || !yamlops.IsRelationMany(relation.Type) || yamlops.IsRelationPoly(relation.Type) {
+			continue
+		}
+		if yamlops.GetRelationTargetName(relationName, relation.Aliased) == targetModelName {
+			relationNames = append(relationNames, relationName)
+		}
+	}
+	return relationNames
+}
+
+// validateRelationPairing validates that the relationships of a model agree with the relationships pairing them
+// across the registry: reciprocal ForMany relationships must be unambiguous, and a HasOne relationship cannot be the
+// inverse of a ForMany relationship
+func validateRelationPairing(r *registry.Registry, model yaml.Model) error {
+	for _, relationName := range core.MapKeysSorted(model.Related) {
+		relation := model.Related[relationName]
+		if yamlops.IsRelationPoly(relation.Type) {
+			continue
+		}
+
+		if yamlops.IsRelationFor(relation.Type) && yamlops.IsRelationMany(relation.Type) {
+			if _, junctionErr := resolveForManyJunction(r, model, relationName, relation); junctionErr != nil {
+				return junctionErr
+			}
+			cardinalityErr := validateForManyInverseCardinality(r, model, relationName, relation)
+			if cardinalityErr != nil {
+				return cardinalityErr
+			}
+			continue
+		}
+
+		if !yamlops.IsRelationHas(relation.Type) || !yamlops.IsRelationOne(relation.Type) {
+			continue
+		}
+		// Unresolvable inverses are reported where they are joined
+		targetModel, inverseName, inverse, inverseErr := getInverseRelation(r, model.Name, relationName, relation)
+		if inverseErr != nil {
+			continue
+		}
+		if yamlops.IsRelationMany(inverse.Type) {
+			return fmt.Errorf("%w: %s.%s is HasOne but its inverse %s.%s is ForMany",
+				ErrRelationCardinalityMismatch, model.Name, relationName, targetModel.Name, inverseName)
+		}
+	}
+	return nil
+}
+
+// validateForManyInverseCardinality validates that no HasOne relationship on the target model resolves to a ForMany
+// relationship as its inverse
+func validateForManyInverseCardinality(r *registry.Registry, model yaml.Model, relationName string, relation yaml.ModelRelation) error {
+	targetModel, hasName, has, hasErr := getHasInverseRelation(r, model, relationName, relation)
+	if hasErr != nil {
+		return hasErr
+	}
+	if hasName != "" && yamlops.IsRelationOne(has.Type) {
+		return fmt.Errorf("%w: %s.%s is ForMany but its inverse %s.%s is HasOne",
+			ErrRelationCardinalityMismatch, model.Name, relationName, targetModel.Name, hasName)
+	}
+	return nil
+}
+
+// getHasInverseRelation returns the target model of a non-polymorphic For relationship and the Has relationship on it
+// resolving to the For relationship as its inverse, or an empty name if the target model declares none
+func getHasInverseRelation(r *registry.Registry, model yaml.Model, relationName string, relation yaml.ModelRelation) (yaml.Model, string, yaml.ModelRelation, error) {
+	targetModelName := yamlops.GetRelationTargetName(relationName, relation.Aliased)
+	targetModel, targetModelErr := r.GetModel(targetModelName)
+	if targetModelErr != nil {
+		return yaml.Model{}, "", yaml.ModelRelation{}, fmt.Errorf("target model %s not found in registry (via relationship %s)", targetModelName, relationName)
+	}
+	for _, candidateName := range core.MapKeysSorted(targetModel.Related) {
+		candidate := targetModel.Related[candidateName]
+		if !yamlops.IsRelationHas(candidate.Type) || yamlops.IsRelationPoly(candidate.Type) {
+			continue
+		}
+		candidateTargetName, _ := getRelationTargetModelName(candidateName, candidate.Aliased)
+		if candidateTargetName != model.Name {
+			continue
+		}
+		_, inverseName, _, inverseErr := getInverseRelation(r, targetModel.Name, candidateName, candidate)
+		if inverseErr != nil || inverseName != relationName {
+			continue
+		}
+		return targetModel, candidateName, candidate, nil
+	}
+	return targetModel, "", yaml.ModelRelation{}, nil
+}
+
+// makeOneToOneForeignKeyIndicesUnique makes the foreign key index of each ForOne relationship with a HasOne inverse
+// unique, so the database enforces the one-to-one cardinality of the pair. Unique indexes on partitioned tables are
+// extended with the partition key, so one-to-one relationships of partitioned models must include it.
+func makeOneToOneForeignKeyIndicesUnique(config cfg.MorpheConfig, r *registry.Registry, table *psqldef.Table, model yaml.Model) error {
+	partitionOptions := config.MorpheModelsConfig.ModelOptions[model.Name].Partition
+	partitionKeyColumnNames := []string{}
+	if partitionOptions.IsPartitioned() {
+		keyColumnNames, keyErr := getPartitionKeyColumnNames(config, r, model, partitionOptions)
+		if keyErr != nil {
+			return keyErr
+		}
+		partitionKeyColumnNames = keyColumnNames
+	}
+
+	for _, relationName := range core.MapKeysSorted(model.Related) {
+		relation := model.Related[relationName]
+		if !yamlops.IsRelationFor(relation.Type) || !yamlops.IsRelationOne(relation.Type) || yamlops.IsRelationPoly(relation.Type) {
+			continue
+		}
+
+		targetModel, hasName, has, hasErr := getHasInverseRelation(r, model, relationName, relation)
+		if hasErr != nil {
+			return hasErr
+		}
+		if hasName == "" || !yamlops.IsRelationOne(has.Type) {
+			continue
+		}
+
+		targetPrimaryIdFields, targetPrimaryErr := getModelPrimaryIdFields(targetModel)
+		if targetPrimaryErr != nil {
+			return targetPrimaryErr
+		}
+		columnNames := getForeignKeyColumnNames(relationName, targetPrimaryIdFields)
+		for _, keyColumnName := range partitionKeyColumnNames {
+			if !slices.Contains(columnNames, keyColumnName) {
+				return fmt.Errorf("%w: %s.%s is one-to-one with %s.%s", ErrPartitionedOneToOneRelation,
+					model.Name, relationName, targetModel.Name, hasName)
+			}
+		}
+		for indexIdx := range table.Indices {
+			if slices.Equal(table.Indices[indexIdx].Columns, columnNames) {
+				table.Indices[indexIdx].IsUnique = true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package compile
+
+import (
+	"fmt"
+
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// deletedAtColumnName is the column marking soft-deleted rows
+const deletedAtColumnName = "deleted_at"
+
+// addSoftDeleteColumn adds a nullable deleted_at column to a table and limits its unique indexes to live rows, so
+// the identifiers of soft-deleted rows can be reused
+func addSoftDeleteColumn(table *psqldef.Table) error {
+	for _, column := range table.Columns {
+		if column.Name == deletedAtColumnName {
+			return fmt.Errorf("%w: %s.%s", ErrSoftDeleteColumnConflict, table.Name, column.Name)
+		}
+	}
+
+	table.Columns = append(table.Columns, psqldef.TableColumn{
+		Name: deletedAtColumnName,
+		Type: psqldef.PSQLTypeTimestampTZ,
+	})
+
+	for indexIdx := range table.Indices {
+		if table.Indices[indexIdx].IsUnique {
+			table.Indices[indexIdx].Where = deletedAtColumnName + " IS NULL"
+		}
+	}
+
+	return nil
+}
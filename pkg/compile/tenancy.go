@@ -0,0 +1,111 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kalo-build/morphe-go/pkg/registry"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/cfg"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// addTenancy scopes a model or junction table to tenants: it adds the tenant column, defaulting to the current
+// tenant, prefixes unique keys and foreign keys to model tables with it and isolates tenants with a row-level
+// security policy. Model tables also get a unique (tenant, primary key) constraint for foreign keys to reference.
+func addTenancy(modelsConfig cfg.MorpheModelsConfig, r *registry.Registry, table *psqldef.Table, isModelTable bool) error {
+	tenancy := modelsConfig.Tenancy
+	tenantColumnName := tenancy.ColumnName
+
+	for _, column := range table.Columns {
+		if column.Name == tenantColumnName {
+			return fmt.Errorf("%w: %s.%s", ErrTenantColumnConflict, table.Name, column.Name)
+		}
+	}
+
+	_, relatedTypeMap := getModelFieldTypeMaps(modelsConfig)
+	tenantColumnType, supported := relatedTypeMap[tenancy.GetFieldType()]
+	if !supported {
+		return fmt.Errorf("unsupported tenant field type %s", tenancy.GetFieldType())
+	}
+	currentTenant := fmt.Sprintf("current_setting('%s')::%s", tenancy.SettingName, tenantColumnType.GetSyntax())
+
+	table.Columns = append(table.Columns, psqldef.TableColumn{
+		Name:    tenantColumnName,
+		Type:    tenantColumnType,
+		NotNull: true,
+		Default: currentTenant,
+	})
+
+	modelTableNames := map[string]bool{}
+	for modelName := range r.GetAllModels() {
+		modelTableNames[GetTableNameFromModel(modelName)] = true
+	}
+
+	// Foreign keys to model tables stay within a tenant, enum lookup tables are shared
+	for fkIdx := range table.ForeignKeys {
+		foreignKey := &table.ForeignKeys[fkIdx]
+		if foreignKey.RefSchema != modelsConfig.Schema || !modelTableNames[foreignKey.RefTableName] {
+			continue
+		}
+		// SET NULL clears the relation columns only, the tenant column stays set
+		if foreignKey.OnUpdate == cfg.ReferentialActionSetNull {
+			return fmt.Errorf("%w: %s", ErrTenantSetNullOnUpdate, foreignKey.Name)
+		}
+		if foreignKey.OnDelete == cfg.ReferentialActionSetNull {
+			foreignKey.SetNullColumnNames = slices.Clone(foreignKey.ColumnNames)
+		}
+		for indexIdx := range table.Indices {
+			if slices.Equal(table.Indices[indexIdx].Columns, foreignKey.ColumnNames) {
+				table.Indices[indexIdx].Columns = prependTenantColumn(tenantColumnName, table.Indices[indexIdx].Columns)
+			}
+		}
+		foreignKey.ColumnNames = prependTenantColumn(tenantColumnName, foreignKey.ColumnNames)
+		foreignKey.RefColumnNames = prependTenantColumn(tenantColumnName, foreignKey.RefColumnNames)
+	}
+
+	// Unique keys are only unique within a tenant
+	for indexIdx := range table.Indices {
+		if table.Indices[indexIdx].IsUnique {
+			table.Indices[indexIdx].Columns = prependTenantColumn(tenantColumnName, table.Indices[indexIdx].Columns)
+		}
+	}
+	for constraintIdx := range table.UniqueConstraints {
+		uniqueConstraint := &table.UniqueConstraints[constraintIdx]
+		uniqueConstraint.ColumnNames = prependTenantColumn(tenantColumnName, uniqueConstraint.ColumnNames)
+	}
+
+	if isModelTable {
+		tenantKeyColumnNames := []string{tenantColumnName}
+		for _, column := range table.Columns {
+			if column.PrimaryKey {
+				tenantKeyColumnNames = append(tenantKeyColumnNames, column.Name)
+			}
+		}
+		table.UniqueConstraints = append(table.UniqueConstraints, psqldef.UniqueConstraint{
+			Schema:      table.Schema,
+			Name:        GetUniqueConstraintName(table.Name, tenantKeyColumnNames...),
+			TableName:   table.Name,
+			ColumnNames: tenantKeyColumnNames,
+		})
+	}
+
+	tenantCondition := fmt.Sprintf("%s = %s", tenantColumnName, currentTenant)
+	table.RowLevelSecurity = true
+	table.Policies = append(table.Policies, psqldef.Policy{
+		Name:      GetTenantPolicyName(table.Name),
+		TableName: table.Name,
+		Command:   "ALL",
+		Using:     tenantCondition,
+		WithCheck: tenantCondition,
+	})
+
+	return nil
+}
+
+// prependTenantColumn returns the column names led by the tenant column, unless they already include it
+func prependTenantColumn(tenantColumnName string, columnNames []string) []string {
+	if slices.Contains(columnNames, tenantColumnName) {
+		return columnNames
+	}
+	return append([]string{tenantColumnName}, columnNames...)
+}
@@ -0,0 +1,353 @@
+package compile
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kalo-build/morphe-go/pkg/yaml"
+	"github.com/kalo-build/morphe-go/pkg/yamlops"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// viewColumnTarget is the table column a view column is written back to
+type viewColumnTarget struct {
+	viewColumnName string
+	columnName     string
+	enumTableName  string // The qualified enum lookup table the written value is looked up in, if any
+	enumColumnName string // The enum lookup table column matched against the written value, "key" or "value"
+}
+
+// viewWriteTable is a table written through a view, with the view columns routed to it
+type viewWriteTable struct {
+	alias              string
+	qualifiedTableName string
+	modelName          string
+	columns            []viewColumnTarget
+}
+
+// addWritableViewTriggers adds an INSTEAD OF trigger function to the view that writes each view column back to
+// the table it is selected from, following the joins resolved for the entity's field paths
+func addWritableViewTriggers(ctx *entityCompileContext) error {
+	writeTables, writeTablesErr := getViewWriteTables(ctx)
+	if writeTablesErr != nil {
+		return writeTablesErr
+	}
+
+	rootTable := writeTables[0]
+	rootKeyColumns, rootKeyErr := getViewWriteKeyColumns(ctx, rootTable)
+	if rootKeyErr != nil {
+		return rootKeyErr
+	}
+
+	rootModel, rootModelErr := ctx.registry.GetModel(rootTable.modelName)
+	if rootModelErr != nil {
+		return rootModelErr
+	}
+
+	body := []string{"BEGIN"}
+	isSoftDelete := ctx.config.MorpheModelsConfig.IsSoftDelete(rootTable.modelName)
+	body = append(body, getViewDeleteLines(rootTable, rootKeyColumns, isSoftDelete)...)
+
+	relatedUpdateLines := []string{}
+	for _, relatedTable := range writeTables[1:] {
+		relatedLines, relatedErr := getViewRelatedUpdateLines(ctx, relatedTable, rootTable, rootKeyColumns)
+		if relatedErr != nil {
+			return relatedErr
+		}
+		relatedUpdateLines = append(relatedUpdateLines, relatedLines...)
+	}
+	body = append(body, getViewInsertOrUpdateLines(ctx, writeTables, rootKeyColumns, rootModel, relatedUpdateLines)...)
+
+	body = append(body, "", "\tRETURN NEW;", "END;")
+
+	writeFunction := psqldef.Function{
+		Schema:   ctx.view.Schema,
+		Name:     GetViewWriteFunctionName(ctx.view.Name),
+		Returns:  "trigger",
+		Language: "plpgsql",
+		Body:     body,
+	}
+	ctx.view.Functions = append(ctx.view.Functions, writeFunction)
+
+	ctx.view.Triggers = append(ctx.view.Triggers, psqldef.Trigger{
+		Schema:         ctx.view.Schema,
+		Name:           GetTriggerName(writeFunction.Name),
+		TableName:      ctx.view.Name,
+		Timing:         "INSTEAD OF",
+		Events:         []string{"INSERT", "UPDATE", "DELETE"},
+		FunctionSchema: writeFunction.Schema,
+		FunctionName:   writeFunction.Name,
+	})
+
+	return nil
+}
+
+// getViewWriteTables groups the view columns by the table they are written to, starting with the root table and
+// followed by the joined tables in join order
+func getViewWriteTables(ctx *entityCompileContext) ([]*viewWriteTable, error) {
+	if ctx.rootModelName == "" {
+		return nil, fmt.Errorf("writable entity %s has no fields selected from a model", ctx.entity.Name)
+	}
+
+	writeTables := []*viewWriteTable{{
+		alias:              ctx.tableName,
+		qualifiedTableName: getQualifiedName(ctx.view.FromSchema, ctx.view.FromTable),
+		modelName:          ctx.rootModelName,
+	}}
+	for _, join := range ctx.view.Joins {
+		info, isTracked := ctx.joins[join.Alias]
+		if !isTracked || info.enumName != "" {
+			continue
+		}
+		writeTables = append(writeTables, &viewWriteTable{
+			alias:              join.Alias,
+			qualifiedTableName: getQualifiedName(join.Schema, join.Table),
+			modelName:          info.targetModelName,
+		})
+	}
+
+	for _, column := range ctx.view.Columns {
+		tableAlias, columnName, _ := strings.Cut(column.SourceRef, ".")
+		target := viewColumnTarget{
+			viewColumnName: column.Name,
+			columnName:     columnName,
+		}
+
+		// Enum columns are written as the id of the lookup table entry matching the written key or value
+		if info, isJoined := ctx.joins[tableAlias]; isJoined && info.enumName != "" {
+			tableAlias = info.sourceTableName
+			target.columnName = GetColumnNameFromField(info.enumFieldName) + "_id"
+			target.enumTableName = getQualifiedName(ctx.config.MorpheEnumsConfig.Schema, GetEnumTableName(info.enumName))
+			target.enumColumnName = ctx.config.MorpheEntitiesConfig.GetEnumColumn()
+		}
+
+		tableIdx := slices.IndexFunc(writeTables, func(writeTable *viewWriteTable) bool {
+			return writeTable.alias == tableAlias
+		})
+		if tableIdx == -1 {
+			return nil, fmt.Errorf("column %s of writable entity %s has no source table", column.Name, ctx.entity.Name)
+		}
+		if tableIdx > 0 {
+			toManyErr := validateViewWritePath(ctx, column.Name, ctx.joins[tableAlias])
+			if toManyErr != nil {
+				return nil, toManyErr
+			}
+		}
+		writeTables[tableIdx].columns = append(writeTables[tableIdx].columns, target)
+	}
+
+	// Joined tables without written columns are only needed to reach other tables
+	return slices.DeleteFunc(writeTables, func(writeTable *viewWriteTable) bool {
+		return writeTable.alias != ctx.tableName && len(writeTable.columns) == 0
+	}), nil
+}
+
+// validateViewWritePath rejects columns reached through a to-many relationship, as a single view row cannot
+// identify which of the related rows to write
+func validateViewWritePath(ctx *entityCompileContext, viewColumnName string, info joinInfo) error {
+	for pathIdx := range info.relationPath {
+		hopInfo := ctx.joins[GetJoinAlias(info.relationPath[:pathIdx+1]...)]
+		sourceModel, modelErr := ctx.registry.GetModel(hopInfo.sourceModelName)
+		if modelErr != nil {
+			return modelErr
+		}
+		if yamlops.IsRelationMany(sourceModel.Related[hopInfo.relationshipName].Type) {
+			return fmt.Errorf("column %s of writable entity %s is reached through to-many relationship %s and cannot be written",
+				viewColumnName, ctx.entity.Name, hopInfo.relationshipName)
+		}
+	}
+	return nil
+}
+
+// getViewWriteKeyColumns returns the view columns holding the primary identifier of a written table's model
+func getViewWriteKeyColumns(ctx *entityCompileContext, writeTable *viewWriteTable) ([]viewColumnTarget, error) {
+	model, modelErr := ctx.registry.GetModel(writeTable.modelName)
+	if modelErr != nil {
+		return nil, modelErr
+	}
+	primaryIdFields, primaryErr := getModelPrimaryIdFields(model)
+	if primaryErr != nil {
+		return nil, primaryErr
+	}
+
+	keyColumns := []viewColumnTarget{}
+	for _, primaryIdColumnName := range getColumnNamesFromFields(primaryIdFields) {
+		keyIdx := slices.IndexFunc(writeTable.columns, func(target viewColumnTarget) bool {
+			return target.columnName == primaryIdColumnName && target.enumTableName == ""
+		})
+		if keyIdx == -1 {
+			return nil, fmt.Errorf("writable entity %s must select the primary identifier column %s of model %s",
+				ctx.entity.Name, primaryIdColumnName, writeTable.modelName)
+		}
+		keyColumns = append(keyColumns, writeTable.columns[keyIdx])
+	}
+	return keyColumns, nil
+}
+
+// getViewDeleteLines deletes the root row of a deleted view row, or marks it deleted for soft-deleted models
+func getViewDeleteLines(rootTable *viewWriteTable, rootKeyColumns []viewColumnTarget, isSoftDelete bool) []string {
+	lines := []string{
+		"\tIF TG_OP = 'DELETE' THEN",
+	}
+	if isSoftDelete {
+		lines = append(lines,
+			fmt.Sprintf("\t\tUPDATE %s", rootTable.qualifiedTableName),
+			fmt.Sprintf("\t\tSET %s = NOW()", deletedAtColumnName),
+		)
+	} else {
+		lines = append(lines, fmt.Sprintf("\t\tDELETE FROM %s", rootTable.qualifiedTableName))
+	}
+	return append(lines,
+		fmt.Sprintf("\t\tWHERE %s;", getViewKeyCondition(rootKeyColumns, "OLD")),
+		"\t\tRETURN OLD;",
+		"\tEND IF;",
+		"",
+	)
+}
+
+// getViewInsertOrUpdateLines inserts or updates the root table row, followed on update by the joined tables' rows.
+// Inserted rows join no related rows yet, so inserts setting related columns are rejected instead of dropping them.
+func getViewInsertOrUpdateLines(ctx *entityCompileContext, writeTables []*viewWriteTable, rootKeyColumns []viewColumnTarget, rootModel yaml.Model, relatedUpdateLines []string) []string {
+	rootTable := writeTables[0]
+	insertColumnNames := []string{}
+	insertValues := []string{}
+	for _, target := range rootTable.columns {
+		// Auto-increment keys are generated by the table and returned into the new view row
+		if slices.Contains(rootKeyColumns, target) && isAutoIncrementColumn(rootModel, target.columnName) {
+			continue
+		}
+		insertColumnNames = append(insertColumnNames, target.columnName)
+		insertValues = append(insertValues, getViewWriteValue(target))
+	}
+
+	returningColumnNames := make([]string, len(rootKeyColumns))
+	returningRefs := make([]string, len(rootKeyColumns))
+	for keyIdx, keyColumn := range rootKeyColumns {
+		returningColumnNames[keyIdx] = keyColumn.columnName
+		returningRefs[keyIdx] = "NEW." + keyColumn.viewColumnName
+	}
+
+	relatedViewColumnNames := []string{}
+	relatedSetConditions := []string{}
+	for _, relatedTable := range writeTables[1:] {
+		for _, target := range relatedTable.columns {
+			relatedViewColumnNames = append(relatedViewColumnNames, target.viewColumnName)
+			relatedSetConditions = append(relatedSetConditions, fmt.Sprintf("NEW.%s IS NOT NULL", target.viewColumnName))
+		}
+	}
+
+	lines := []string{
+		"\tIF TG_OP = 'INSERT' THEN",
+	}
+	if len(relatedSetConditions) > 0 {
+		lines = append(lines,
+			fmt.Sprintf("\t\tIF %s THEN", strings.Join(relatedSetConditions, " OR ")),
+			fmt.Sprintf("\t\t\tRAISE EXCEPTION 'inserts into %s cannot set columns of related rows (%s)';",
+				ctx.view.Name, strings.Join(relatedViewColumnNames, ", ")),
+			"\t\tEND IF;",
+		)
+	}
+	if len(insertColumnNames) == 0 {
+		lines = append(lines, fmt.Sprintf("\t\tINSERT INTO %s DEFAULT VALUES", rootTable.qualifiedTableName))
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("\t\tINSERT INTO %s (%s)", rootTable.qualifiedTableName, strings.Join(insertColumnNames, ", ")),
+			fmt.Sprintf("\t\tVALUES (%s)", strings.Join(insertValues, ", ")),
+		)
+	}
+	lines = append(lines,
+		fmt.Sprintf("\t\tRETURNING %s INTO %s;", strings.Join(returningColumnNames, ", "), strings.Join(returningRefs, ", ")),
+		"\tELSE",
+		fmt.Sprintf("\t\tUPDATE %s", rootTable.qualifiedTableName),
+		fmt.Sprintf("\t\tSET %s", getViewSetClause(rootTable.columns)),
+		fmt.Sprintf("\t\tWHERE %s;", getViewKeyCondition(rootKeyColumns, "OLD")),
+	)
+	for _, relatedUpdateLine := range relatedUpdateLines {
+		if relatedUpdateLine == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, "\t"+relatedUpdateLine)
+	}
+	return append(lines, "\tEND IF;")
+}
+
+// getViewRelatedUpdateLines updates the columns of a joined table in the rows the view joins for the written row
+func getViewRelatedUpdateLines(ctx *entityCompileContext, relatedTable *viewWriteTable, rootTable *viewWriteTable, rootKeyColumns []viewColumnTarget) ([]string, error) {
+	relatedModel, modelErr := ctx.registry.GetModel(relatedTable.modelName)
+	if modelErr != nil {
+		return nil, modelErr
+	}
+	relatedPrimaryIdFields, primaryErr := getModelPrimaryIdFields(relatedModel)
+	if primaryErr != nil {
+		return nil, primaryErr
+	}
+
+	_, relatedTableName, _ := strings.Cut(relatedTable.qualifiedTableName, ".")
+	outerKeyRefs := []string{}
+	innerKeyRefs := []string{}
+	for _, primaryIdColumnName := range getColumnNamesFromFields(relatedPrimaryIdFields) {
+		outerKeyRefs = append(outerKeyRefs, relatedTableName+"."+primaryIdColumnName)
+		innerKeyRefs = append(innerKeyRefs, relatedTable.alias+"."+primaryIdColumnName)
+	}
+
+	sourceLines, sourceErr := formatViewSourceLines(ctx.view)
+	if sourceErr != nil {
+		return nil, sourceErr
+	}
+
+	rootKeyConditions := []string{}
+	for _, keyColumn := range rootKeyColumns {
+		rootKeyConditions = append(rootKeyConditions, fmt.Sprintf("%s.%s = NEW.%s", rootTable.alias, keyColumn.columnName, keyColumn.viewColumnName))
+	}
+
+	lines := []string{
+		"",
+		fmt.Sprintf("\tUPDATE %s", relatedTable.qualifiedTableName),
+		fmt.Sprintf("\tSET %s", getViewSetClause(relatedTable.columns)),
+		fmt.Sprintf("\tWHERE (%s) IN (", strings.Join(outerKeyRefs, ", ")),
+		fmt.Sprintf("\t\tSELECT %s", strings.Join(innerKeyRefs, ", ")),
+	}
+	for _, sourceLine := range sourceLines {
+		lines = append(lines, "\t\t"+sourceLine)
+	}
+	lines = append(lines,
+		fmt.Sprintf("\t\tWHERE %s", strings.Join(rootKeyConditions, " AND ")),
+		"\t);",
+	)
+	return lines, nil
+}
+
+func getViewSetClause(targets []viewColumnTarget) string {
+	assignments := make([]string, len(targets))
+	for targetIdx, target := range targets {
+		assignments[targetIdx] = fmt.Sprintf("%s = %s", target.columnName, getViewWriteValue(target))
+	}
+	return strings.Join(assignments, ", ")
+}
+
+func getViewKeyCondition(keyColumns []viewColumnTarget, rowName string) string {
+	conditions := make([]string, len(keyColumns))
+	for keyIdx, keyColumn := range keyColumns {
+		conditions[keyIdx] = fmt.Sprintf("%s = %s.%s", keyColumn.columnName, rowName, keyColumn.viewColumnName)
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// getViewWriteValue returns the value written to a target column for the new view row
+func getViewWriteValue(target viewColumnTarget) string {
+	if target.enumTableName == "" {
+		return "NEW." + target.viewColumnName
+	}
+	return fmt.Sprintf("(SELECT id FROM %s WHERE %s = NEW.%s)", target.enumTableName, target.enumColumnName, target.viewColumnName)
+}
+
+func isAutoIncrementColumn(model yaml.Model, columnName string) bool {
+	for fieldName, field := range model.Fields {
+		if GetColumnNameFromField(fieldName) == columnName {
+			return field.Type == yaml.ModelFieldTypeAutoIncrement
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+
+type PSQLEnumTypeWriter interface {
+	WriteEnumType(*psqldef.PSQLTypeEnum) ([]byte, error)
+}
+
+// OrderedPSQLEnumTypeWriter extends PSQLEnumTypeWriter with order support for migration files.
+type OrderedPSQLEnumTypeWriter interface {
+	PSQLEnumTypeWriter
+	// WriteEnumTypeWithOrder writes an enum type with an order prefix (e.g., "001_enum_type.sql")
+	WriteEnumTypeWithOrder(*psqldef.PSQLTypeEnum, int) ([]byte, error)
+}
@@ -0,0 +1,27 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+
+type PSQLMigrationWriter interface {
+	WriteMigration(*psqldef.TableDiff) ([]byte, error)
+}
+
+// OrderedPSQLMigrationWriter extends PSQLMigrationWriter with order support for migration files.
+type OrderedPSQLMigrationWriter interface {
+	PSQLMigrationWriter
+	// WriteMigrationWithOrder writes a migration with an order prefix (e.g., "001_alter_table.sql")
+	WriteMigrationWithOrder(*psqldef.TableDiff, int) ([]byte, error)
+}
+
+// PSQLMigrationOrderReader is implemented by migration writers continuing the order of previously written migrations.
+type PSQLMigrationOrderReader interface {
+	// GetLastMigrationOrder returns the highest order prefix of the written migrations, or 0 if there are none
+	GetLastMigrationOrder() (int, error)
+}
+
+// PSQLViewMigrationWriter is implemented by migration writers dropping and recreating views around table migrations.
+type PSQLViewMigrationWriter interface {
+	WriteViewMigration(*psqldef.ViewDiff) ([]byte, error)
+	// WriteViewMigrationWithOrder writes a view migration with an order prefix (e.g., "004_create_view_person_entities.sql")
+	WriteViewMigrationWithOrder(*psqldef.ViewDiff, int) ([]byte, error)
+}
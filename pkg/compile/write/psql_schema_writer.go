@@ -0,0 +1,8 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+
+// PSQLSchemaWriter writes all definitions of a compilation as a single consolidated schema.
+type PSQLSchemaWriter interface {
+	WriteSchema(*psqldef.Schema) ([]byte, error)
+}
@@ -0,0 +1,7 @@
+package write
+
+import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+
+type PSQLSnapshotWriter interface {
+	WriteSnapshot(*psqldef.SchemaSnapshot) ([]byte, error)
+}
@@ -12,3 +12,10 @@ type OrderedPSQLTableWriter interface {
 	// WriteTableWithOrder writes a table with an order prefix (e.g., "001_table.sql")
 	WriteTableWithOrder(*psqldef.Table, int) ([]byte, error)
 }
+
+// PSQLCrossTableTriggerWriter writes the triggers tables create on other tables once all tables are written, so that
+// these triggers add no dependencies between the tables.
+type PSQLCrossTableTriggerWriter interface {
+	// WriteCrossTableTriggersWithOrder writes the triggers with an order prefix, or without one for order 0
+	WriteCrossTableTriggersWithOrder([]*psqldef.Table, int) ([]byte, error)
+}
@@ -5,3 +5,10 @@ import "github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
 type PSQLViewWriter interface {
 	WriteView(*psqldef.View) ([]byte, error)
 }
+
+// OrderedPSQLViewWriter extends PSQLViewWriter with order support for migration files.
+type OrderedPSQLViewWriter interface {
+	PSQLViewWriter
+	// WriteViewWithOrder writes a view with an order prefix (e.g., "010_view_name.sql")
+	WriteViewWithOrder(*psqldef.View, int) ([]byte, error)
+}
@@ -23,13 +23,63 @@ func WriteAllEntityViewDefinitions(config MorpheCompileConfig, allEntityViewDefs
 	return allWrittenEntities, nil
 }
 
+// WriteAllEntityViewDefinitionsWithOrder writes all entity views in dependency order with ordering prefixes.
+// The startOrder parameter is the starting order number for file prefixes.
+// Returns the compiled views and the next order number to use.
+func WriteAllEntityViewDefinitionsWithOrder(config MorpheCompileConfig, allEntityViewDefs map[string]*psqldef.View, startOrder int) (CompiledMorpheViews, int, error) {
+	allWrittenEntities := CompiledMorpheViews{}
+
+	// Flatten all views for dependency sorting
+	allViews := []*psqldef.View{}
+	viewToEntity := make(map[string]string)
+	for _, entityName := range core.MapKeysSorted(allEntityViewDefs) {
+		entityView := allEntityViewDefs[entityName]
+		allViews = append(allViews, entityView)
+		viewToEntity[entityView.Name] = entityName
+	}
+
+	// Sort views by dependency order
+	sortedDefinitions, sortErr := SortSchemaDefinitionsByDependency(nil, allViews)
+	if sortErr != nil {
+		return nil, startOrder, sortErr
+	}
+
+	// Write views in dependency order with incrementing order prefix
+	currentOrder := startOrder
+	for _, definition := range sortedDefinitions {
+		currentOrder++
+		entityName := viewToEntity[definition.View.Name]
+
+		entityView, entityViewContents, writeErr := WriteEntityViewDefinitionWithOrder(
+			config.WriteViewHooks, config.EntityWriter, definition.View, currentOrder)
+		if writeErr != nil {
+			return nil, currentOrder, writeErr
+		}
+		allWrittenEntities.AddCompiledMorpheView(entityName, entityView, entityViewContents)
+	}
+	return allWrittenEntities, currentOrder, nil
+}
+
 func WriteEntityViewDefinition(hooks hook.WritePSQLView, writer write.PSQLViewWriter, entityView *psqldef.View) (*psqldef.View, []byte, error) {
+	return WriteEntityViewDefinitionWithOrder(hooks, writer, entityView, 0)
+}
+
+func WriteEntityViewDefinitionWithOrder(hooks hook.WritePSQLView, writer write.PSQLViewWriter, entityView *psqldef.View, order int) (*psqldef.View, []byte, error) {
 	writer, entityView, writeStartErr := triggerWriteEntityViewStart(hooks, writer, entityView)
 	if writeStartErr != nil {
 		return nil, nil, triggerWriteEntityViewFailure(hooks, writer, entityView, writeStartErr)
 	}
 
-	entityViewContents, writeViewErr := writer.WriteView(entityView)
+	var entityViewContents []byte
+	var writeViewErr error
+
+	// Check if writer supports ordered writing
+	if orderedWriter, ok := writer.(write.OrderedPSQLViewWriter); ok && order > 0 {
+		entityViewContents, writeViewErr = orderedWriter.WriteViewWithOrder(entityView, order)
+	} else {
+		entityViewContents, writeViewErr = writer.WriteView(entityView)
+	}
+
 	if writeViewErr != nil {
 		return nil, nil, triggerWriteEntityViewFailure(hooks, writer, entityView, writeViewErr)
 	}
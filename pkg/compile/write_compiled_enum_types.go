@@ -0,0 +1,126 @@
+package compile
+
+import (
+	"github.com/kalo-build/clone"
+	"github.com/kalo-build/go-util/core"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/hook"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// WriteAllEnumTypeDefinitions writes all native enum types without ordering prefixes.
+func WriteAllEnumTypeDefinitions(config MorpheCompileConfig, allEnumTypeDefs map[string]*psqldef.PSQLTypeEnum) (map[string]CompiledEnumType, error) {
+	allWrittenEnumTypes := map[string]CompiledEnumType{}
+
+	sortedEnumNames := core.MapKeysSorted(allEnumTypeDefs)
+	for _, enumName := range sortedEnumNames {
+		enumType := allEnumTypeDefs[enumName]
+		enumType, enumTypeContents, writeErr := WriteEnumTypeDefinition(
+			config.WriteEnumTypeHooks, config.EnumTypeWriter, enumType)
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		allWrittenEnumTypes[enumName] = CompiledEnumType{
+			EnumType:         enumType,
+			EnumTypeContents: enumTypeContents,
+		}
+	}
+	return allWrittenEnumTypes, nil
+}
+
+// WriteAllEnumTypeDefinitionsWithOrder writes all native enum types with ordering prefixes.
+// Returns the compiled enum types and the next order number to use.
+func WriteAllEnumTypeDefinitionsWithOrder(config MorpheCompileConfig, allEnumTypeDefs map[string]*psqldef.PSQLTypeEnum, startOrder int) (map[string]CompiledEnumType, int, error) {
+	allWrittenEnumTypes := map[string]CompiledEnumType{}
+	currentOrder := startOrder
+
+	sortedEnumNames := core.MapKeysSorted(allEnumTypeDefs)
+	for _, enumName := range sortedEnumNames {
+		currentOrder++
+		enumType := allEnumTypeDefs[enumName]
+		enumType, enumTypeContents, writeErr := WriteEnumTypeDefinitionWithOrder(
+			config.WriteEnumTypeHooks, config.EnumTypeWriter, enumType, currentOrder)
+		if writeErr != nil {
+			return nil, currentOrder, writeErr
+		}
+		allWrittenEnumTypes[enumName] = CompiledEnumType{
+			EnumType:         enumType,
+			EnumTypeContents: enumTypeContents,
+		}
+	}
+	return allWrittenEnumTypes, currentOrder, nil
+}
+
+func WriteEnumTypeDefinition(hooks hook.WritePSQLEnumType, writer write.PSQLEnumTypeWriter, enumType *psqldef.PSQLTypeEnum) (*psqldef.PSQLTypeEnum, []byte, error) {
+	return WriteEnumTypeDefinitionWithOrder(hooks, writer, enumType, 0)
+}
+
+func WriteEnumTypeDefinitionWithOrder(hooks hook.WritePSQLEnumType, writer write.PSQLEnumTypeWriter, enumType *psqldef.PSQLTypeEnum, order int) (*psqldef.PSQLTypeEnum, []byte, error) {
+	writer, enumType, writeStartErr := triggerWriteEnumTypeStart(hooks, writer, enumType)
+	if writeStartErr != nil {
+		return nil, nil, triggerWriteEnumTypeFailure(hooks, writer, enumType, writeStartErr)
+	}
+
+	var enumTypeContents []byte
+	var writeEnumTypeErr error
+
+	// Check if writer supports ordered writing
+	if orderedWriter, ok := writer.(write.OrderedPSQLEnumTypeWriter); ok && order > 0 {
+		enumTypeContents, writeEnumTypeErr = orderedWriter.WriteEnumTypeWithOrder(enumType, order)
+	} else {
+		enumTypeContents, writeEnumTypeErr = writer.WriteEnumType(enumType)
+	}
+
+	if writeEnumTypeErr != nil {
+		return nil, nil, triggerWriteEnumTypeFailure(hooks, writer, enumType, writeEnumTypeErr)
+	}
+
+	enumType, enumTypeContents, writeSuccessErr := triggerWriteEnumTypeSuccess(hooks, enumType, enumTypeContents)
+	if writeSuccessErr != nil {
+		return nil, nil, triggerWriteEnumTypeFailure(hooks, writer, enumType, writeSuccessErr)
+	}
+	return enumType, enumTypeContents, nil
+}
+
+func triggerWriteEnumTypeStart(hooks hook.WritePSQLEnumType, writer write.PSQLEnumTypeWriter, enumType *psqldef.PSQLTypeEnum) (write.PSQLEnumTypeWriter, *psqldef.PSQLTypeEnum, error) {
+	if hooks.OnWritePSQLEnumTypeStart == nil {
+		return writer, enumType, nil
+	}
+	if enumType == nil {
+		return nil, nil, ErrNoEnumType
+	}
+	enumTypeClone := enumType.DeepClone()
+
+	updatedWriter, updatedEnumType, startErr := hooks.OnWritePSQLEnumTypeStart(writer, &enumTypeClone)
+	if startErr != nil {
+		return nil, nil, startErr
+	}
+
+	return updatedWriter, updatedEnumType, nil
+}
+
+func triggerWriteEnumTypeSuccess(hooks hook.WritePSQLEnumType, enumType *psqldef.PSQLTypeEnum, enumTypeContents []byte) (*psqldef.PSQLTypeEnum, []byte, error) {
+	if hooks.OnWritePSQLEnumTypeSuccess == nil {
+		return enumType, enumTypeContents, nil
+	}
+	if enumType == nil {
+		return nil, nil, ErrNoEnumType
+	}
+	enumTypeClone := enumType.DeepClone()
+	enumTypeContentsClone := clone.Slice(enumTypeContents)
+
+	updatedEnumType, updatedEnumTypeContents, successErr := hooks.OnWritePSQLEnumTypeSuccess(&enumTypeClone, enumTypeContentsClone)
+	if successErr != nil {
+		return nil, nil, successErr
+	}
+	return updatedEnumType, updatedEnumTypeContents, nil
+}
+
+func triggerWriteEnumTypeFailure(hooks hook.WritePSQLEnumType, writer write.PSQLEnumTypeWriter, enumType *psqldef.PSQLTypeEnum, failureErr error) error {
+	if hooks.OnWritePSQLEnumTypeFailure == nil {
+		return failureErr
+	}
+
+	enumTypeClone := enumType.DeepClone()
+	return hooks.OnWritePSQLEnumTypeFailure(writer, &enumTypeClone, failureErr)
+}
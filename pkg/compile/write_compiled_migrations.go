@@ -0,0 +1,139 @@
+package compile
+
+import (
+	"github.com/kalo-build/clone"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/hook"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
+	"github.com/kalo-build/plugin-morphe-psql-types/pkg/psqldef"
+)
+
+// WriteAllTableMigrations writes all table diffs in the given order with incrementing order prefixes.
+// The startOrder parameter is the starting order number for file prefixes.
+// Returns the written table diffs, their contents and the next order number to use.
+func WriteAllTableMigrations(config MorpheCompileConfig, allTableDiffs []*psqldef.TableDiff, startOrder int) ([]*psqldef.TableDiff, [][]byte, int, error) {
+	allWrittenDiffs := []*psqldef.TableDiff{}
+	allWrittenContents := [][]byte{}
+
+	currentOrder := startOrder
+	for _, tableDiff := range allTableDiffs {
+		currentOrder++
+		writtenDiff, migrationContents, writeErr := WriteTableMigrationWithOrder(
+			config.WriteMigrationHooks, config.MigrationWriter, tableDiff, currentOrder)
+		if writeErr != nil {
+			return nil, nil, currentOrder, writeErr
+		}
+		allWrittenDiffs = append(allWrittenDiffs, writtenDiff)
+		allWrittenContents = append(allWrittenContents, migrationContents)
+	}
+
+	return allWrittenDiffs, allWrittenContents, currentOrder, nil
+}
+
+// WriteAllViewMigrations writes all view diffs in the given order with incrementing order prefixes, like
+// WriteAllTableMigrations. The migration writer must implement write.PSQLViewMigrationWriter if there are any.
+func WriteAllViewMigrations(config MorpheCompileConfig, allViewDiffs []*psqldef.ViewDiff, startOrder int) ([][]byte, int, error) {
+	allWrittenContents := [][]byte{}
+	if len(allViewDiffs) == 0 {
+		return allWrittenContents, startOrder, nil
+	}
+	viewWriter, isViewWriter := config.MigrationWriter.(write.PSQLViewMigrationWriter)
+	if !isViewWriter {
+		return nil, startOrder, ErrNoViewMigrationWriter
+	}
+
+	currentOrder := startOrder
+	for _, viewDiff := range allViewDiffs {
+		currentOrder++
+		migrationContents, writeErr := WriteViewMigrationWithOrder(viewWriter, viewDiff, currentOrder)
+		if writeErr != nil {
+			return nil, currentOrder, writeErr
+		}
+		allWrittenContents = append(allWrittenContents, migrationContents)
+	}
+
+	return allWrittenContents, currentOrder, nil
+}
+
+func WriteViewMigrationWithOrder(writer write.PSQLViewMigrationWriter, viewDiff *psqldef.ViewDiff, order int) ([]byte, error) {
+	if viewDiff == nil {
+		return nil, ErrNoViewDiff
+	}
+	if order > 0 {
+		return writer.WriteViewMigrationWithOrder(viewDiff, order)
+	}
+	return writer.WriteViewMigration(viewDiff)
+}
+
+func WriteTableMigration(hooks hook.WritePSQLMigration, writer write.PSQLMigrationWriter, tableDiff *psqldef.TableDiff) (*psqldef.TableDiff, []byte, error) {
+	return WriteTableMigrationWithOrder(hooks, writer, tableDiff, 0)
+}
+
+func WriteTableMigrationWithOrder(hooks hook.WritePSQLMigration, writer write.PSQLMigrationWriter, tableDiff *psqldef.TableDiff, order int) (*psqldef.TableDiff, []byte, error) {
+	writer, tableDiff, writeStartErr := triggerWriteTableMigrationStart(hooks, writer, tableDiff)
+	if writeStartErr != nil {
+		return nil, nil, triggerWriteTableMigrationFailure(hooks, writer, tableDiff, writeStartErr)
+	}
+
+	var migrationContents []byte
+	var writeMigrationErr error
+
+	// Check if writer supports ordered writing
+	if orderedWriter, ok := writer.(write.OrderedPSQLMigrationWriter); ok && order > 0 {
+		migrationContents, writeMigrationErr = orderedWriter.WriteMigrationWithOrder(tableDiff, order)
+	} else {
+		migrationContents, writeMigrationErr = writer.WriteMigration(tableDiff)
+	}
+
+	if writeMigrationErr != nil {
+		return nil, nil, triggerWriteTableMigrationFailure(hooks, writer, tableDiff, writeMigrationErr)
+	}
+
+	tableDiff, migrationContents, writeSuccessErr := triggerWriteTableMigrationSuccess(hooks, tableDiff, migrationContents)
+	if writeSuccessErr != nil {
+		return nil, nil, triggerWriteTableMigrationFailure(hooks, writer, tableDiff, writeSuccessErr)
+	}
+	return tableDiff, migrationContents, nil
+}
+
+func triggerWriteTableMigrationStart(hooks hook.WritePSQLMigration, writer write.PSQLMigrationWriter, tableDiff *psqldef.TableDiff) (write.PSQLMigrationWriter, *psqldef.TableDiff, error) {
+	if hooks.OnWritePSQLMigrationStart == nil {
+		return writer, tableDiff, nil
+	}
+	if tableDiff == nil {
+		return nil, nil, ErrNoTableDiff
+	}
+	tableDiffClone := tableDiff.DeepClone()
+
+	updatedWriter, updatedTableDiff, startErr := hooks.OnWritePSQLMigrationStart(writer, &tableDiffClone)
+	if startErr != nil {
+		return nil, nil, startErr
+	}
+
+	return updatedWriter, updatedTableDiff, nil
+}
+
+func triggerWriteTableMigrationSuccess(hooks hook.WritePSQLMigration, tableDiff *psqldef.TableDiff, migrationContents []byte) (*psqldef.TableDiff, []byte, error) {
+	if hooks.OnWritePSQLMigrationSuccess == nil {
+		return tableDiff, migrationContents, nil
+	}
+	if tableDiff == nil {
+		return nil, nil, ErrNoTableDiff
+	}
+	tableDiffClone := tableDiff.DeepClone()
+	migrationContentsClone := clone.Slice(migrationContents)
+
+	updatedTableDiff, updatedMigrationContents, successErr := hooks.OnWritePSQLMigrationSuccess(&tableDiffClone, migrationContentsClone)
+	if successErr != nil {
+		return nil, nil, successErr
+	}
+	return updatedTableDiff, updatedMigrationContents, nil
+}
+
+func triggerWriteTableMigrationFailure(hooks hook.WritePSQLMigration, writer write.PSQLMigrationWriter, tableDiff *psqldef.TableDiff, failureErr error) error {
+	if hooks.OnWritePSQLMigrationFailure == nil {
+		return failureErr
+	}
+
+	tableDiffClone := tableDiff.DeepClone()
+	return hooks.OnWritePSQLMigrationFailure(writer, &tableDiffClone, failureErr)
+}
@@ -1,6 +1,8 @@
 package compile
 
 import (
+	"slices"
+
 	"github.com/kalo-build/clone"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/hook"
 	"github.com/kalo-build/plugin-morphe-psql-types/pkg/compile/write"
@@ -28,6 +30,7 @@ func WriteAllModelTableDefinitions(config MorpheCompileConfig, allModelTableDefs
 	}
 
 	// Write tables in dependency order without order prefix
+	writtenTables := []*psqldef.Table{}
 	for _, modelTable := range sortedTables {
 		modelName := tableToModel[modelTable.Name]
 
@@ -37,6 +40,11 @@ func WriteAllModelTableDefinitions(config MorpheCompileConfig, allModelTableDefs
 			return nil, writeErr
 		}
 		allWrittenModels.AddCompiledMorpheTable(modelName, modelTable, modelTableContents)
+		writtenTables = append(writtenTables, modelTable)
+	}
+
+	if _, writeTriggersErr := writeCrossTableTriggers(config.ModelWriter, writtenTables, 0); writeTriggersErr != nil {
+		return nil, writeTriggersErr
 	}
 
 	return allWrittenModels, nil
@@ -45,7 +53,7 @@ func WriteAllModelTableDefinitions(config MorpheCompileConfig, allModelTableDefs
 // WriteAllModelTableDefinitionsWithOrder writes all model tables with dependency-based ordering.
 // The startOrder parameter is the starting order number for file prefixes.
 // Returns the compiled tables and the next order number to use.
-func WriteAllModelTableDefinitionsWithOrder(config MorpheCompileConfig, allModelTableDefs map[string][]*psqldef.Table, startOrder int) (CompiledMorpheTables, error) {
+func WriteAllModelTableDefinitionsWithOrder(config MorpheCompileConfig, allModelTableDefs map[string][]*psqldef.Table, startOrder int) (CompiledMorpheTables, int, error) {
 	allWrittenModels := CompiledMorpheTables{}
 
 	// Flatten all tables for dependency sorting
@@ -61,11 +69,12 @@ func WriteAllModelTableDefinitionsWithOrder(config MorpheCompileConfig, allModel
 	// Sort tables by dependency order
 	sortedTables, sortErr := SortTablesByDependency(allTables)
 	if sortErr != nil {
-		return nil, sortErr
+		return nil, startOrder, sortErr
 	}
 
 	// Write tables in dependency order with incrementing order prefix
 	currentOrder := startOrder
+	writtenTables := []*psqldef.Table{}
 	for _, modelTable := range sortedTables {
 		currentOrder++
 		modelName := tableToModel[modelTable.Name]
@@ -73,12 +82,39 @@ func WriteAllModelTableDefinitionsWithOrder(config MorpheCompileConfig, allModel
 		modelTable, modelTableContents, writeErr := WriteModelTableDefinitionWithOrder(
 			config.WriteTableHooks, config.ModelWriter, modelTable, currentOrder)
 		if writeErr != nil {
-			return nil, writeErr
+			return nil, currentOrder, writeErr
 		}
 		allWrittenModels.AddCompiledMorpheTable(modelName, modelTable, modelTableContents)
+		writtenTables = append(writtenTables, modelTable)
 	}
 
-	return allWrittenModels, nil
+	// Triggers on other tables follow all tables, so they are written once the tables they fire on exist
+	wroteTriggers, writeTriggersErr := writeCrossTableTriggers(config.ModelWriter, writtenTables, currentOrder+1)
+	if writeTriggersErr != nil {
+		return nil, currentOrder, writeTriggersErr
+	}
+	if wroteTriggers {
+		currentOrder++
+	}
+
+	return allWrittenModels, currentOrder, nil
+}
+
+// writeCrossTableTriggers writes the triggers the written tables create on other tables with writers supporting it,
+// returning whether any were written. Other writers receive these triggers as part of the table definitions.
+func writeCrossTableTriggers(writer write.PSQLTableWriter, writtenTables []*psqldef.Table, order int) (bool, error) {
+	triggerWriter, isTriggerWriter := writer.(write.PSQLCrossTableTriggerWriter)
+	if !isTriggerWriter {
+		return false, nil
+	}
+	hasCrossTableTriggers := slices.ContainsFunc(writtenTables, func(table *psqldef.Table) bool {
+		return len(getCrossTableTriggers(table)) > 0
+	})
+	if !hasCrossTableTriggers {
+		return false, nil
+	}
+	_, writeErr := triggerWriter.WriteCrossTableTriggersWithOrder(writtenTables, order)
+	return writeErr == nil, writeErr
 }
 
 func WriteModelTableDefinition(hooks hook.WritePSQLTable, writer write.PSQLTableWriter, modelTable *psqldef.Table) (*psqldef.Table, []byte, error) {
@@ -0,0 +1,32 @@
+package psqldef
+
+// ColumnDiff represents a column that exists in both the previous and current table definition but changed
+type ColumnDiff struct {
+	Name     string
+	Previous TableColumn
+	Current  TableColumn
+}
+
+// TypeChanged returns true if the column type changed
+func (d ColumnDiff) TypeChanged() bool {
+	return d.Previous.Type.GetSyntax() != d.Current.Type.GetSyntax()
+}
+
+// NotNullChanged returns true if the column nullability changed
+func (d ColumnDiff) NotNullChanged() bool {
+	return d.Previous.NotNull != d.Current.NotNull
+}
+
+// DefaultChanged returns true if the column default changed
+func (d ColumnDiff) DefaultChanged() bool {
+	return d.Previous.Default != d.Current.Default
+}
+
+// DeepClone creates a deep copy of the ColumnDiff
+func (d ColumnDiff) DeepClone() ColumnDiff {
+	return ColumnDiff{
+		Name:     d.Name,
+		Previous: d.Previous.DeepClone(),
+		Current:  d.Current.DeepClone(),
+	}
+}
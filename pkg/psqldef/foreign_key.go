@@ -13,6 +13,8 @@ type ForeignKey struct {
 	RefColumnNames []string
 	OnDelete       string // e.g., "CASCADE", "SET NULL"
 	OnUpdate       string // e.g., "CASCADE", "SET NULL"
+	// SetNullColumnNames limits ON DELETE SET NULL to a subset of the columns, e.g. to keep a tenant column
+	SetNullColumnNames []string
 }
 
 // DeepClone creates a deep copy of the ForeignKey
@@ -27,6 +29,8 @@ func (fk ForeignKey) DeepClone() ForeignKey {
 		RefColumnNames: clone.Slice(fk.RefColumnNames),
 		OnDelete:       fk.OnDelete,
 		OnUpdate:       fk.OnUpdate,
+
+		SetNullColumnNames: clone.Slice(fk.SetNullColumnNames),
 	}
 
 	return foreignKeyCopy
@@ -0,0 +1,27 @@
+package psqldef
+
+import "github.com/kalo-build/clone"
+
+// Function represents a PSQL function, e.g. a trigger function
+type Function struct {
+	Schema   string
+	Name     string
+	Returns  string   // e.g., "trigger"
+	Language string   // e.g., "plpgsql"
+	Body     []string // Lines between the dollar quotes
+	Shared   bool     // Created by every definition using it and kept by their rollbacks, e.g. set_updated_at()
+}
+
+// DeepClone creates a deep copy of the Function
+func (f Function) DeepClone() Function {
+	functionCopy := Function{
+		Schema:   f.Schema,
+		Name:     f.Name,
+		Returns:  f.Returns,
+		Language: f.Language,
+		Body:     clone.Slice(f.Body),
+		Shared:   f.Shared,
+	}
+
+	return functionCopy
+}
@@ -9,6 +9,7 @@ type Index struct {
 	Columns   []string
 	IsUnique  bool
 	Using     string // e.g., "btree", "gin"
+	Where     string // Predicate of a partial index, e.g. "deleted_at IS NULL"
 }
 
 // DeepClone creates a deep copy of the Index
@@ -19,6 +20,7 @@ func (i Index) DeepClone() Index {
 		Columns:   clone.Slice(i.Columns),
 		IsUnique:  i.IsUnique,
 		Using:     i.Using,
+		Where:     i.Where,
 	}
 
 	return indexCopy
@@ -0,0 +1,23 @@
+package psqldef
+
+// Policy represents a PSQL row-level security policy on a table
+type Policy struct {
+	Name      string
+	TableName string
+	Command   string // e.g., "ALL", "SELECT"
+	Using     string // Expression rows must satisfy to be visible
+	WithCheck string // Expression new rows must satisfy
+}
+
+// DeepClone creates a deep copy of the Policy
+func (p Policy) DeepClone() Policy {
+	policyCopy := Policy{
+		Name:      p.Name,
+		TableName: p.TableName,
+		Command:   p.Command,
+		Using:     p.Using,
+		WithCheck: p.WithCheck,
+	}
+
+	return policyCopy
+}
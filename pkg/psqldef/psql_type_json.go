@@ -0,0 +1,181 @@
+package psqldef
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PSQLTypeKind identifies the PSQLType implementation in a JSON representation
+type PSQLTypeKind string
+
+const (
+	PSQLTypeKindPrimitive PSQLTypeKind = "primitive"
+	PSQLTypeKindArray     PSQLTypeKind = "array"
+	PSQLTypeKindDomain    PSQLTypeKind = "domain"
+	PSQLTypeKindComposite PSQLTypeKind = "composite"
+	PSQLTypeKindEnum      PSQLTypeKind = "enum"
+	PSQLTypeKindRange     PSQLTypeKind = "range"
+)
+
+// psqlTypeJSON is the tagged JSON representation shared by all PSQLType implementations
+type psqlTypeJSON struct {
+	Kind      PSQLTypeKind             `json:"Kind"`
+	Syntax    string                   `json:"Syntax,omitempty"`
+	Schema    string                   `json:"Schema,omitempty"`
+	Name      string                   `json:"Name,omitempty"`
+	Values    []string                 `json:"Values,omitempty"`
+	ValueType *psqlTypeJSON            `json:"ValueType,omitempty"`
+	Fields    map[string]*psqlTypeJSON `json:"Fields,omitempty"`
+}
+
+// MarshalPSQLTypeJSON marshals a PSQLType into a tagged JSON representation that can be unmarshalled
+// with UnmarshalPSQLTypeJSON.
+func MarshalPSQLTypeJSON(psqlType PSQLType) ([]byte, error) {
+	typeJSON, typeJSONErr := toPSQLTypeJSON(psqlType)
+	if typeJSONErr != nil {
+		return nil, typeJSONErr
+	}
+	return json.Marshal(typeJSON)
+}
+
+// UnmarshalPSQLTypeJSON unmarshals a tagged JSON representation created by MarshalPSQLTypeJSON.
+func UnmarshalPSQLTypeJSON(data []byte) (PSQLType, error) {
+	var typeJSON *psqlTypeJSON
+	unmarshalErr := json.Unmarshal(data, &typeJSON)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return fromPSQLTypeJSON(typeJSON)
+}
+
+func toPSQLTypeJSON(psqlType PSQLType) (*psqlTypeJSON, error) {
+	if psqlType == nil {
+		return nil, nil
+	}
+
+	switch typedType := psqlType.(type) {
+	case PSQLTypePrimitive:
+		return &psqlTypeJSON{
+			Kind:   PSQLTypeKindPrimitive,
+			Syntax: typedType.Syntax,
+		}, nil
+	case PSQLTypeArray:
+		valueTypeJSON, valueTypeErr := toPSQLTypeJSON(typedType.ValueType)
+		if valueTypeErr != nil {
+			return nil, valueTypeErr
+		}
+		return &psqlTypeJSON{
+			Kind:      PSQLTypeKindArray,
+			ValueType: valueTypeJSON,
+		}, nil
+	case PSQLTypeDomain:
+		valueTypeJSON, valueTypeErr := toPSQLTypeJSON(typedType.ValueType)
+		if valueTypeErr != nil {
+			return nil, valueTypeErr
+		}
+		return &psqlTypeJSON{
+			Kind:      PSQLTypeKindDomain,
+			Schema:    typedType.Schema,
+			Name:      typedType.Name,
+			ValueType: valueTypeJSON,
+		}, nil
+	case PSQLTypeRange:
+		valueTypeJSON, valueTypeErr := toPSQLTypeJSON(typedType.ValueType)
+		if valueTypeErr != nil {
+			return nil, valueTypeErr
+		}
+		return &psqlTypeJSON{
+			Kind:      PSQLTypeKindRange,
+			Schema:    typedType.Schema,
+			Name:      typedType.Name,
+			ValueType: valueTypeJSON,
+		}, nil
+	case PSQLTypeEnum:
+		return &psqlTypeJSON{
+			Kind:   PSQLTypeKindEnum,
+			Schema: typedType.Schema,
+			Name:   typedType.Name,
+			Values: typedType.Values,
+		}, nil
+	case PSQLTypeComposite:
+		fieldsJSON := make(map[string]*psqlTypeJSON, len(typedType.Fields))
+		for fieldName, fieldType := range typedType.Fields {
+			fieldJSON, fieldErr := toPSQLTypeJSON(fieldType)
+			if fieldErr != nil {
+				return nil, fieldErr
+			}
+			fieldsJSON[fieldName] = fieldJSON
+		}
+		return &psqlTypeJSON{
+			Kind:   PSQLTypeKindComposite,
+			Schema: typedType.Schema,
+			Name:   typedType.Name,
+			Fields: fieldsJSON,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported psql type for json conversion: '%T'", psqlType)
+}
+
+func fromPSQLTypeJSON(typeJSON *psqlTypeJSON) (PSQLType, error) {
+	if typeJSON == nil {
+		return nil, nil
+	}
+
+	switch typeJSON.Kind {
+	case PSQLTypeKindPrimitive:
+		return PSQLTypePrimitive{
+			Syntax: typeJSON.Syntax,
+		}, nil
+	case PSQLTypeKindArray:
+		valueType, valueTypeErr := fromPSQLTypeJSON(typeJSON.ValueType)
+		if valueTypeErr != nil {
+			return nil, valueTypeErr
+		}
+		return PSQLTypeArray{
+			ValueType: valueType,
+		}, nil
+	case PSQLTypeKindDomain:
+		valueType, valueTypeErr := fromPSQLTypeJSON(typeJSON.ValueType)
+		if valueTypeErr != nil {
+			return nil, valueTypeErr
+		}
+		return PSQLTypeDomain{
+			ValueType: valueType,
+			Schema:    typeJSON.Schema,
+			Name:      typeJSON.Name,
+		}, nil
+	case PSQLTypeKindRange:
+		valueType, valueTypeErr := fromPSQLTypeJSON(typeJSON.ValueType)
+		if valueTypeErr != nil {
+			return nil, valueTypeErr
+		}
+		return PSQLTypeRange{
+			ValueType: valueType,
+			Schema:    typeJSON.Schema,
+			Name:      typeJSON.Name,
+		}, nil
+	case PSQLTypeKindEnum:
+		return PSQLTypeEnum{
+			Values: typeJSON.Values,
+			Schema: typeJSON.Schema,
+			Name:   typeJSON.Name,
+		}, nil
+	case PSQLTypeKindComposite:
+		fields := make(map[string]PSQLType, len(typeJSON.Fields))
+		for fieldName, fieldJSON := range typeJSON.Fields {
+			fieldType, fieldErr := fromPSQLTypeJSON(fieldJSON)
+			if fieldErr != nil {
+				return nil, fieldErr
+			}
+			fields[fieldName] = fieldType
+		}
+		return PSQLTypeComposite{
+			Fields: fields,
+			Schema: typeJSON.Schema,
+			Name:   typeJSON.Name,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported psql type kind for json conversion: '%s'", typeJSON.Kind)
+}
@@ -0,0 +1,59 @@
+package psqldef
+
+import "github.com/kalo-build/clone"
+
+// Schema is a consolidated set of definitions, ordered so that dependencies come before dependents
+type Schema struct {
+	EnumTypes   []PSQLTypeEnum
+	Definitions []SchemaDefinition
+}
+
+// SchemaDefinition is either a table or a view definition of a consolidated schema
+type SchemaDefinition struct {
+	Table *Table
+	View  *View
+}
+
+// GetName returns the name of the table or view
+func (d SchemaDefinition) GetName() string {
+	if d.View != nil {
+		return d.View.Name
+	}
+	if d.Table != nil {
+		return d.Table.Name
+	}
+	return ""
+}
+
+// GetSchema returns the schema of the table or view
+func (d SchemaDefinition) GetSchema() string {
+	if d.View != nil {
+		return d.View.Schema
+	}
+	if d.Table != nil {
+		return d.Table.Schema
+	}
+	return ""
+}
+
+// DeepClone creates a deep copy of the SchemaDefinition
+func (d SchemaDefinition) DeepClone() SchemaDefinition {
+	definitionCopy := SchemaDefinition{}
+	if d.Table != nil {
+		tableCopy := d.Table.DeepClone()
+		definitionCopy.Table = &tableCopy
+	}
+	if d.View != nil {
+		viewCopy := d.View.DeepClone()
+		definitionCopy.View = &viewCopy
+	}
+	return definitionCopy
+}
+
+// DeepClone creates a deep copy of the Schema
+func (s Schema) DeepClone() Schema {
+	return Schema{
+		EnumTypes:   clone.DeepCloneSlice(s.EnumTypes),
+		Definitions: clone.DeepCloneSlice(s.Definitions),
+	}
+}
@@ -0,0 +1,35 @@
+package psqldef
+
+import "github.com/kalo-build/clone"
+
+// SchemaSnapshot is a machine-readable record of all table and view definitions generated by a compilation
+type SchemaSnapshot struct {
+	Tables []Table
+	Views  []View
+}
+
+// DeepClone creates a deep copy of the SchemaSnapshot
+func (s SchemaSnapshot) DeepClone() SchemaSnapshot {
+	return SchemaSnapshot{
+		Tables: clone.DeepCloneSlice(s.Tables),
+		Views:  clone.DeepCloneSlice(s.Views),
+	}
+}
+
+// GetTables returns pointers to all tables in the snapshot
+func (s SchemaSnapshot) GetTables() []*Table {
+	tables := make([]*Table, len(s.Tables))
+	for tableIdx := range s.Tables {
+		tables[tableIdx] = &s.Tables[tableIdx]
+	}
+	return tables
+}
+
+// GetViews returns pointers to all views in the snapshot
+func (s SchemaSnapshot) GetViews() []*View {
+	views := make([]*View, len(s.Views))
+	for viewIdx := range s.Views {
+		views[viewIdx] = &s.Views[viewIdx]
+	}
+	return views
+}
@@ -10,7 +10,19 @@ type Table struct {
 	Indices           []Index
 	ForeignKeys       []ForeignKey
 	UniqueConstraints []UniqueConstraint
+	CheckConstraints  []CheckConstraint
 	SeedData          []InsertStatement
+	Functions         []Function
+	Triggers          []Trigger // May be created on other tables, e.g. cleanup triggers
+
+	// Tables with row-level security only expose the rows their policies allow
+	RowLevelSecurity bool
+	Policies         []Policy
+
+	// Partitioned tables store their rows in the partitions, chosen by the partition key columns
+	PartitionStrategy string // e.g., "RANGE", "LIST", "HASH"
+	PartitionColumns  []string
+	Partitions        []TablePartition
 }
 
 // DeepClone creates a deep copy of the Table
@@ -22,7 +34,15 @@ func (t Table) DeepClone() Table {
 		Indices:           clone.DeepCloneSlice(t.Indices),
 		ForeignKeys:       clone.DeepCloneSlice(t.ForeignKeys),
 		UniqueConstraints: clone.DeepCloneSlice(t.UniqueConstraints),
+		CheckConstraints:  clone.DeepCloneSlice(t.CheckConstraints),
 		SeedData:          clone.DeepCloneSlice(t.SeedData),
+		Functions:         clone.DeepCloneSlice(t.Functions),
+		Triggers:          clone.DeepCloneSlice(t.Triggers),
+		RowLevelSecurity:  t.RowLevelSecurity,
+		Policies:          clone.DeepCloneSlice(t.Policies),
+		PartitionStrategy: t.PartitionStrategy,
+		PartitionColumns:  clone.Slice(t.PartitionColumns),
+		Partitions:        clone.DeepCloneSlice(t.Partitions),
 	}
 
 	return tableCopy
@@ -1,5 +1,7 @@
 package psqldef
 
+import "encoding/json"
+
 // TableColumn represents a column in a PSQL table
 type TableColumn struct {
 	Name       string
@@ -9,6 +11,15 @@ type TableColumn struct {
 	Default    string
 }
 
+// tableColumnJSON mirrors TableColumn with a JSON representation of the column type
+type tableColumnJSON struct {
+	Name       string
+	Type       json.RawMessage
+	NotNull    bool
+	PrimaryKey bool
+	Default    string
+}
+
 // DeepClone creates a deep copy of the TableColumn
 func (c TableColumn) DeepClone() TableColumn {
 	columnCopy := TableColumn{
@@ -21,3 +32,42 @@ func (c TableColumn) DeepClone() TableColumn {
 
 	return columnCopy
 }
+
+// MarshalJSON marshals the TableColumn including a tagged representation of its PSQLType
+func (c TableColumn) MarshalJSON() ([]byte, error) {
+	typeData, typeErr := MarshalPSQLTypeJSON(c.Type)
+	if typeErr != nil {
+		return nil, typeErr
+	}
+
+	return json.Marshal(tableColumnJSON{
+		Name:       c.Name,
+		Type:       typeData,
+		NotNull:    c.NotNull,
+		PrimaryKey: c.PrimaryKey,
+		Default:    c.Default,
+	})
+}
+
+// UnmarshalJSON unmarshals the TableColumn and restores the concrete PSQLType implementation
+func (c *TableColumn) UnmarshalJSON(data []byte) error {
+	var columnJSON tableColumnJSON
+	unmarshalErr := json.Unmarshal(data, &columnJSON)
+	if unmarshalErr != nil {
+		return unmarshalErr
+	}
+
+	columnType, typeErr := UnmarshalPSQLTypeJSON(columnJSON.Type)
+	if typeErr != nil {
+		return typeErr
+	}
+
+	*c = TableColumn{
+		Name:       columnJSON.Name,
+		Type:       columnType,
+		NotNull:    columnJSON.NotNull,
+		PrimaryKey: columnJSON.PrimaryKey,
+		Default:    columnJSON.Default,
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package psqldef
+
+import "github.com/kalo-build/clone"
+
+// TableChangeType describes how a table changed between two compilations
+type TableChangeType string
+
+const (
+	TableChangeCreate TableChangeType = "create"
+	TableChangeAlter  TableChangeType = "alter"
+	TableChangeDrop   TableChangeType = "drop"
+
+	// TableChangeTriggers creates the triggers a table creates on other tables, once all tables are migrated
+	TableChangeTriggers TableChangeType = "triggers"
+)
+
+// TableDiff represents the changes required to migrate a PSQL table from a previous definition to the current one
+type TableDiff struct {
+	Schema string
+	Name   string
+	Change TableChangeType
+
+	// Table is the current table definition for creates and alters, and the previous table definition for drops
+	Table *Table
+	// PreviousTable is the previous table definition for alters, from which their rollback is derived
+	PreviousTable *Table
+
+	AddedColumns   []TableColumn
+	DroppedColumns []TableColumn
+	AlteredColumns []ColumnDiff
+
+	AddedForeignKeys   []ForeignKey
+	DroppedForeignKeys []ForeignKey
+
+	AddedUniqueConstraints   []UniqueConstraint
+	DroppedUniqueConstraints []UniqueConstraint
+
+	AddedCheckConstraints   []CheckConstraint
+	DroppedCheckConstraints []CheckConstraint
+
+	AddedIndices   []Index
+	DroppedIndices []Index
+
+	// AddedEnumTypes are the native enum types first used by the table, AddedEnumValues the values added to
+	// native enum types it uses, and DroppedEnumTypes the native enum types no longer used once the table is migrated
+	AddedEnumTypes   []PSQLTypeEnum
+	AddedEnumValues  []PSQLTypeEnum
+	DroppedEnumTypes []PSQLTypeEnum
+
+	AddedFunctions   []Function
+	DroppedFunctions []Function
+
+	AddedTriggers   []Trigger
+	DroppedTriggers []Trigger
+
+	EnableRowLevelSecurity  bool
+	DisableRowLevelSecurity bool
+	AddedPolicies           []Policy
+	DroppedPolicies         []Policy
+
+	AddedPartitions   []TablePartition
+	DroppedPartitions []TablePartition
+
+	// AddedSeedData holds the seed rows missing from the previous table definition, DroppedSeedData the seed rows
+	// deleted when rolling them back
+	AddedSeedData   []InsertStatement
+	DroppedSeedData []InsertStatement
+}
+
+// IsEmpty returns true if the diff contains no changes
+func (d TableDiff) IsEmpty() bool {
+	if d.Change != TableChangeAlter {
+		return false
+	}
+	return len(d.AddedColumns) == 0 &&
+		len(d.DroppedColumns) == 0 &&
+		len(d.AlteredColumns) == 0 &&
+		len(d.AddedForeignKeys) == 0 &&
+		len(d.DroppedForeignKeys) == 0 &&
+		len(d.AddedUniqueConstraints) == 0 &&
+		len(d.DroppedUniqueConstraints) == 0 &&
+		len(d.AddedCheckConstraints) == 0 &&
+		len(d.DroppedCheckConstraints) == 0 &&
+		len(d.AddedIndices) == 0 &&
+		len(d.DroppedIndices) == 0 &&
+		len(d.AddedEnumTypes) == 0 &&
+		len(d.AddedEnumValues) == 0 &&
+		len(d.DroppedEnumTypes) == 0 &&
+		len(d.AddedFunctions) == 0 &&
+		len(d.DroppedFunctions) == 0 &&
+		len(d.AddedTriggers) == 0 &&
+		len(d.DroppedTriggers) == 0 &&
+		!d.EnableRowLevelSecurity &&
+		!d.DisableRowLevelSecurity &&
+		len(d.AddedPolicies) == 0 &&
+		len(d.DroppedPolicies) == 0 &&
+		len(d.AddedPartitions) == 0 &&
+		len(d.DroppedPartitions) == 0 &&
+		len(d.AddedSeedData) == 0 &&
+		len(d.DroppedSeedData) == 0
+}
+
+// DeepClone creates a deep copy of the TableDiff
+func (d TableDiff) DeepClone() TableDiff {
+	diffCopy := TableDiff{
+		Schema:                   d.Schema,
+		Name:                     d.Name,
+		Change:                   d.Change,
+		AddedColumns:             clone.DeepCloneSlice(d.AddedColumns),
+		DroppedColumns:           clone.DeepCloneSlice(d.DroppedColumns),
+		AlteredColumns:           clone.DeepCloneSlice(d.AlteredColumns),
+		AddedForeignKeys:         clone.DeepCloneSlice(d.AddedForeignKeys),
+		DroppedForeignKeys:       clone.DeepCloneSlice(d.DroppedForeignKeys),
+		AddedUniqueConstraints:   clone.DeepCloneSlice(d.AddedUniqueConstraints),
+		DroppedUniqueConstraints: clone.DeepCloneSlice(d.DroppedUniqueConstraints),
+		AddedCheckConstraints:    clone.DeepCloneSlice(d.AddedCheckConstraints),
+		DroppedCheckConstraints:  clone.DeepCloneSlice(d.DroppedCheckConstraints),
+		AddedIndices:             clone.DeepCloneSlice(d.AddedIndices),
+		DroppedIndices:           clone.DeepCloneSlice(d.DroppedIndices),
+		AddedEnumTypes:           clone.DeepCloneSlice(d.AddedEnumTypes),
+		AddedEnumValues:          clone.DeepCloneSlice(d.AddedEnumValues),
+		DroppedEnumTypes:         clone.DeepCloneSlice(d.DroppedEnumTypes),
+		AddedFunctions:           clone.DeepCloneSlice(d.AddedFunctions),
+		DroppedFunctions:         clone.DeepCloneSlice(d.DroppedFunctions),
+		AddedTriggers:            clone.DeepCloneSlice(d.AddedTriggers),
+		DroppedTriggers:          clone.DeepCloneSlice(d.DroppedTriggers),
+		EnableRowLevelSecurity:   d.EnableRowLevelSecurity,
+		DisableRowLevelSecurity:  d.DisableRowLevelSecurity,
+		AddedPolicies:            clone.DeepCloneSlice(d.AddedPolicies),
+		DroppedPolicies:          clone.DeepCloneSlice(d.DroppedPolicies),
+		AddedPartitions:          clone.DeepCloneSlice(d.AddedPartitions),
+		DroppedPartitions:        clone.DeepCloneSlice(d.DroppedPartitions),
+		AddedSeedData:            clone.DeepCloneSlice(d.AddedSeedData),
+		DroppedSeedData:          clone.DeepCloneSlice(d.DroppedSeedData),
+	}
+
+	if d.Table != nil {
+		tableCopy := d.Table.DeepClone()
+		diffCopy.Table = &tableCopy
+	}
+	if d.PreviousTable != nil {
+		previousTableCopy := d.PreviousTable.DeepClone()
+		diffCopy.PreviousTable = &previousTableCopy
+	}
+
+	return diffCopy
+}
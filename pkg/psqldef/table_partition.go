@@ -0,0 +1,17 @@
+package psqldef
+
+// TablePartition represents a child partition of a partitioned PSQL table
+type TablePartition struct {
+	Name  string
+	Bound string // e.g., "FROM ('2024-01-01') TO ('2024-02-01')", empty for the DEFAULT partition
+}
+
+// DeepClone creates a deep copy of the TablePartition
+func (p TablePartition) DeepClone() TablePartition {
+	partitionCopy := TablePartition{
+		Name:  p.Name,
+		Bound: p.Bound,
+	}
+
+	return partitionCopy
+}
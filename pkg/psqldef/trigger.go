@@ -0,0 +1,31 @@
+package psqldef
+
+import "github.com/kalo-build/clone"
+
+// Trigger represents a PSQL row trigger executing a function
+type Trigger struct {
+	Schema         string
+	Name           string
+	TableName      string
+	Timing         string   // e.g., "BEFORE", "AFTER"
+	Events         []string // e.g., "INSERT", "UPDATE", "DELETE"
+	FunctionSchema string
+	FunctionName   string
+	Arguments      []string
+}
+
+// DeepClone creates a deep copy of the Trigger
+func (t Trigger) DeepClone() Trigger {
+	triggerCopy := Trigger{
+		Schema:         t.Schema,
+		Name:           t.Name,
+		TableName:      t.TableName,
+		Timing:         t.Timing,
+		Events:         clone.Slice(t.Events),
+		FunctionSchema: t.FunctionSchema,
+		FunctionName:   t.FunctionName,
+		Arguments:      clone.Slice(t.Arguments),
+	}
+
+	return triggerCopy
+}
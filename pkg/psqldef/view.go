@@ -11,18 +11,28 @@ type View struct {
 	FromTable   string
 	Joins       []JoinClause
 	WhereClause string
+
+	// Materialized views store their rows and are kept up to date by their refresh functions
+	Materialized bool
+	Indices      []Index
+	Functions    []Function
+	Triggers     []Trigger // e.g. INSTEAD OF triggers making the view writable
 }
 
 // DeepClone creates a deep copy of the View
 func (v View) DeepClone() View {
 	viewCopy := View{
-		Schema:      v.Schema,
-		Name:        v.Name,
-		Columns:     clone.DeepCloneSlice(v.Columns),
-		FromSchema:  v.FromSchema,
-		FromTable:   v.FromTable,
-		Joins:       clone.DeepCloneSlice(v.Joins),
-		WhereClause: v.WhereClause,
+		Schema:       v.Schema,
+		Name:         v.Name,
+		Columns:      clone.DeepCloneSlice(v.Columns),
+		FromSchema:   v.FromSchema,
+		FromTable:    v.FromTable,
+		Joins:        clone.DeepCloneSlice(v.Joins),
+		WhereClause:  v.WhereClause,
+		Materialized: v.Materialized,
+		Indices:      clone.DeepCloneSlice(v.Indices),
+		Functions:    clone.DeepCloneSlice(v.Functions),
+		Triggers:     clone.DeepCloneSlice(v.Triggers),
 	}
 
 	return viewCopy
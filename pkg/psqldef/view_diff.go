@@ -0,0 +1,36 @@
+package psqldef
+
+// ViewChangeType describes how a view is migrated between two compilations
+type ViewChangeType string
+
+const (
+	ViewChangeCreate ViewChangeType = "create_view"
+	ViewChangeDrop   ViewChangeType = "drop_view"
+)
+
+// ViewDiff represents a view dropped or created by a migration. Views are not altered, but dropped and recreated,
+// e.g. when their definition changes or the tables they select from drop or retype columns.
+type ViewDiff struct {
+	Schema string
+	Name   string
+	Change ViewChangeType
+
+	// View is the current view definition for creates and the previous view definition for drops
+	View *View
+}
+
+// DeepClone creates a deep copy of the ViewDiff
+func (d ViewDiff) DeepClone() ViewDiff {
+	diffCopy := ViewDiff{
+		Schema: d.Schema,
+		Name:   d.Name,
+		Change: d.Change,
+	}
+
+	if d.View != nil {
+		viewCopy := d.View.DeepClone()
+		diffCopy.View = &viewCopy
+	}
+
+	return diffCopy
+}
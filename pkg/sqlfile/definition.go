@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/kalo-build/go-util/strcase"
 )
@@ -17,19 +19,69 @@ func WriteSQLDefinitionFile(dirPath string, definitionName string, psqlFileConte
 // WriteSQLDefinitionFileWithOrder writes a SQL definition file with an optional order prefix.
 // If order is 0, no prefix is added. Otherwise, the file is named like "001_table_name.sql".
 func WriteSQLDefinitionFileWithOrder(dirPath string, definitionName string, psqlFileContents string, order int) ([]byte, error) {
+	definitionFileName := getDefinitionFileName(definitionName, order)
+	return writeSQLFile(dirPath, definitionFileName+".sql", psqlFileContents)
+}
+
+// WriteSQLMigrationFilesWithOrder writes an up/down pair of SQL migration files with an optional order prefix.
+// If order is 0, no prefix is added. Otherwise, the files are named like "001_table_name.up.sql" and
+// "001_table_name.down.sql". Returns the contents of the up migration.
+func WriteSQLMigrationFilesWithOrder(dirPath string, definitionName string, upFileContents string, downFileContents string, order int) ([]byte, error) {
+	definitionFileName := getDefinitionFileName(definitionName, order)
+
+	_, downErr := writeSQLFile(dirPath, definitionFileName+".down.sql", downFileContents)
+	if downErr != nil {
+		return nil, downErr
+	}
+	return writeSQLFile(dirPath, definitionFileName+".up.sql", upFileContents)
+}
+
+// GetLastOrder returns the highest order prefix of the SQL files in a directory, or 0 if the directory does not exist
+// or holds no ordered files.
+func GetLastOrder(dirPath string) (int, error) {
+	dirEntries, readErr := os.ReadDir(dirPath)
+	if readErr != nil {
+		if os.IsNotExist(readErr) {
+			return 0, nil
+		}
+		return 0, readErr
+	}
+
+	lastOrder := 0
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != ".sql" {
+			continue
+		}
+		orderPrefix, _, hasPrefix := strings.Cut(dirEntry.Name(), "_")
+		if !hasPrefix {
+			continue
+		}
+		order, orderErr := strconv.Atoi(orderPrefix)
+		if orderErr != nil {
+			continue
+		}
+		lastOrder = max(lastOrder, order)
+	}
+	return lastOrder, nil
+}
+
+func getDefinitionFileName(definitionName string, order int) string {
 	definitionFileName := strcase.ToSnakeCaseLower(definitionName)
 
 	// Add order prefix if order > 0
 	if order > 0 {
 		definitionFileName = fmt.Sprintf("%03d_%s", order, definitionFileName)
 	}
+	return definitionFileName
+}
 
-	definitionFilePath := filepath.Join(dirPath, definitionFileName+".sql")
+func writeSQLFile(dirPath string, fileName string, psqlFileContents string) ([]byte, error) {
+	filePath := filepath.Join(dirPath, fileName)
 	if _, readErr := os.ReadDir(dirPath); readErr != nil && os.IsNotExist(readErr) {
 		mkDirErr := os.MkdirAll(dirPath, 0644)
 		if mkDirErr != nil {
 			return nil, mkDirErr
 		}
 	}
-	return []byte(psqlFileContents), os.WriteFile(definitionFilePath, []byte(psqlFileContents), 0644)
+	return []byte(psqlFileContents), os.WriteFile(filePath, []byte(psqlFileContents), 0644)
 }
@@ -0,0 +1,55 @@
+-- Table definition for comments
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.comments (
+	content TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	id SERIAL PRIMARY KEY,
+	commentable_type TEXT NOT NULL,
+	commentable_id INTEGER NOT NULL
+);
+
+-- Functions
+CREATE OR REPLACE FUNCTION public.check_comments_commentable()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	IF NEW.commentable_type IS NULL OR NEW.commentable_id IS NULL THEN
+		RETURN NEW;
+	END IF;
+
+	IF NEW.commentable_type = 'Person' THEN
+		IF NOT EXISTS (SELECT 1 FROM public.people WHERE id = NEW.commentable_id) THEN
+			RAISE EXCEPTION 'commentable_id % does not reference an existing Person', NEW.commentable_id;
+		END IF;
+	ELSIF NEW.commentable_type = 'Company' THEN
+		IF NOT EXISTS (SELECT 1 FROM public.companies WHERE id = NEW.commentable_id) THEN
+			RAISE EXCEPTION 'commentable_id % does not reference an existing Company', NEW.commentable_id;
+		END IF;
+	ELSE
+		RAISE EXCEPTION 'commentable_type % is not one of Person, Company', NEW.commentable_type;
+	END IF;
+
+	RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION public.cleanup_comments_commentable()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	DELETE FROM public.comments
+	WHERE commentable_type = TG_ARGV[0]
+		AND commentable_id = (to_jsonb(OLD) ->> TG_ARGV[1])::INTEGER;
+	RETURN OLD;
+END;
+$$;
+
+-- Triggers
+DROP TRIGGER IF EXISTS trg_check_comments_commentable ON public.comments;
+CREATE TRIGGER trg_check_comments_commentable BEFORE INSERT OR UPDATE ON public.comments
+	FOR EACH ROW EXECUTE FUNCTION public.check_comments_commentable();
+
@@ -0,0 +1,15 @@
+-- Triggers created on other tables
+
+DROP TRIGGER IF EXISTS trg_cleanup_comments_commentable ON public.people;
+CREATE TRIGGER trg_cleanup_comments_commentable AFTER DELETE ON public.people
+	FOR EACH ROW EXECUTE FUNCTION public.cleanup_comments_commentable('Person', 'id');
+DROP TRIGGER IF EXISTS trg_cleanup_comments_commentable ON public.companies;
+CREATE TRIGGER trg_cleanup_comments_commentable AFTER DELETE ON public.companies
+	FOR EACH ROW EXECUTE FUNCTION public.cleanup_comments_commentable('Company', 'id');
+DROP TRIGGER IF EXISTS trg_cleanup_tag_taggables_taggable ON public.people;
+CREATE TRIGGER trg_cleanup_tag_taggables_taggable AFTER DELETE ON public.people
+	FOR EACH ROW EXECUTE FUNCTION public.cleanup_tag_taggables_taggable('Person', 'id');
+DROP TRIGGER IF EXISTS trg_cleanup_tag_taggables_taggable ON public.companies;
+CREATE TRIGGER trg_cleanup_tag_taggables_taggable AFTER DELETE ON public.companies
+	FOR EACH ROW EXECUTE FUNCTION public.cleanup_tag_taggables_taggable('Company', 'id');
+
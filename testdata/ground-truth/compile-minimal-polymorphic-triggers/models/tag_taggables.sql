@@ -0,0 +1,61 @@
+-- Table definition for tag_taggables
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TABLE IF NOT EXISTS public.tag_taggables (
+	id SERIAL PRIMARY KEY,
+	tag_id INTEGER NOT NULL,
+	taggable_type TEXT NOT NULL,
+	taggable_id INTEGER NOT NULL,
+	UNIQUE (tag_id, taggable_type, taggable_id),
+	CONSTRAINT fk_tag_taggables_tag_id FOREIGN KEY (tag_id)
+		REFERENCES public.tags (id)
+		ON DELETE CASCADE
+);
+
+-- Indices
+CREATE INDEX IF NOT EXISTS idx_tag_taggables_tag_id ON public.tag_taggables (tag_id);
+
+-- Functions
+CREATE OR REPLACE FUNCTION public.check_tag_taggables_taggable()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	IF NEW.taggable_type IS NULL OR NEW.taggable_id IS NULL THEN
+		RETURN NEW;
+	END IF;
+
+	IF NEW.taggable_type = 'Person' THEN
+		IF NOT EXISTS (SELECT 1 FROM public.people WHERE id = NEW.taggable_id) THEN
+			RAISE EXCEPTION 'taggable_id % does not reference an existing Person', NEW.taggable_id;
+		END IF;
+	ELSIF NEW.taggable_type = 'Company' THEN
+		IF NOT EXISTS (SELECT 1 FROM public.companies WHERE id = NEW.taggable_id) THEN
+			RAISE EXCEPTION 'taggable_id % does not reference an existing Company', NEW.taggable_id;
+		END IF;
+	ELSE
+		RAISE EXCEPTION 'taggable_type % is not one of Person, Company', NEW.taggable_type;
+	END IF;
+
+	RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION public.cleanup_tag_taggables_taggable()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+	DELETE FROM public.tag_taggables
+	WHERE taggable_type = TG_ARGV[0]
+		AND taggable_id = (to_jsonb(OLD) ->> TG_ARGV[1])::INTEGER;
+	RETURN OLD;
+END;
+$$;
+
+-- Triggers
+DROP TRIGGER IF EXISTS trg_check_tag_taggables_taggable ON public.tag_taggables;
+CREATE TRIGGER trg_check_tag_taggables_taggable BEFORE INSERT OR UPDATE ON public.tag_taggables
+	FOR EACH ROW EXECUTE FUNCTION public.check_tag_taggables_taggable();
+
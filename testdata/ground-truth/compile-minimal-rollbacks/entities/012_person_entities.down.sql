@@ -0,0 +1,4 @@
+-- Rollback of view definition for person_entities
+
+DROP VIEW IF EXISTS public.person_entities;
+
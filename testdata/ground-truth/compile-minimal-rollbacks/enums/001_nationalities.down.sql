@@ -0,0 +1,9 @@
+-- Rollback of table definition for nationalities
+
+-- Seed Data
+DELETE FROM public.nationalities WHERE key = 'US' AND value = 'American' AND value_type = 'String';
+DELETE FROM public.nationalities WHERE key = 'FR' AND value = 'French' AND value_type = 'String';
+DELETE FROM public.nationalities WHERE key = 'DE' AND value = 'German' AND value_type = 'String';
+
+DROP TABLE IF EXISTS public.nationalities;
+
@@ -0,0 +1,9 @@
+-- Rollback of table definition for people
+
+-- Indices
+DROP INDEX IF EXISTS public.idx_people_first_name_last_name;
+DROP INDEX IF EXISTS public.idx_people_company_id;
+DROP INDEX IF EXISTS public.idx_people_nationality_id;
+
+DROP TABLE IF EXISTS public.people;
+
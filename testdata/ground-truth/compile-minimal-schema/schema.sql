@@ -0,0 +1,172 @@
+-- Consolidated schema definition
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+-- Table definition for comments
+
+CREATE TABLE IF NOT EXISTS public.comments (
+	content TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	id SERIAL PRIMARY KEY,
+	commentable_type TEXT NOT NULL,
+	commentable_id INTEGER NOT NULL
+);
+
+-- Table definition for companies
+
+CREATE TABLE IF NOT EXISTS public.companies (
+	id SERIAL PRIMARY KEY,
+	name TEXT NOT NULL,
+	tax_id TEXT NOT NULL
+);
+
+-- Indices
+CREATE UNIQUE INDEX IF NOT EXISTS idx_companies_name ON public.companies ("name");
+
+-- Table definition for morphe_structures
+
+CREATE TABLE IF NOT EXISTS public.morphe_structures (
+	id SERIAL PRIMARY KEY,
+	"type" TEXT NOT NULL,
+	"data" JSONB NOT NULL,
+	created_at TIMESTAMPTZ DEFAULT NOW(),
+	updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indices
+CREATE INDEX IF NOT EXISTS idx_morphe_structures_type ON public.morphe_structures ("type");
+CREATE INDEX IF NOT EXISTS idx_morphe_structures_data ON public.morphe_structures USING GIN ("data");
+
+-- Table definition for nationalities
+
+CREATE TABLE IF NOT EXISTS public.nationalities (
+	id SERIAL PRIMARY KEY,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	value_type TEXT NOT NULL,
+	UNIQUE (key)
+);
+
+-- Seed Data
+INSERT INTO public.nationalities (key, value, value_type) VALUES ('DE', 'German', 'String');
+INSERT INTO public.nationalities (key, value, value_type) VALUES ('FR', 'French', 'String');
+INSERT INTO public.nationalities (key, value, value_type) VALUES ('US', 'American', 'String');
+
+-- Table definition for tags
+
+CREATE TABLE IF NOT EXISTS public.tags (
+	color TEXT NOT NULL,
+	id SERIAL PRIMARY KEY,
+	name TEXT NOT NULL
+);
+
+-- Indices
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON public.tags ("name");
+
+-- Table definition for universal_numbers
+
+CREATE TABLE IF NOT EXISTS public.universal_numbers (
+	id SERIAL PRIMARY KEY,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	value_type TEXT NOT NULL,
+	UNIQUE (key)
+);
+
+-- Seed Data
+INSERT INTO public.universal_numbers (key, value, value_type) VALUES ('Euler', '2.7182818285', 'Float');
+INSERT INTO public.universal_numbers (key, value, value_type) VALUES ('Pi', '3.1415926535', 'Float');
+
+-- View definition for comment_entities
+
+CREATE OR REPLACE VIEW public.comment_entities AS
+SELECT
+	comments.content,
+	comments.created_at,
+	comments.id
+FROM public.comments;
+
+-- View definition for company_entities
+
+CREATE OR REPLACE VIEW public.company_entities AS
+SELECT
+	companies.id,
+	companies.name,
+	companies.tax_id
+FROM public.companies;
+
+-- Table definition for people
+
+CREATE TABLE IF NOT EXISTS public.people (
+	first_name TEXT NOT NULL,
+	id SERIAL PRIMARY KEY,
+	last_name TEXT NOT NULL,
+	nationality_id INTEGER NOT NULL,
+	company_id INTEGER NOT NULL,
+	CONSTRAINT fk_people_nationality_id FOREIGN KEY (nationality_id)
+		REFERENCES public.nationalities (id)
+		ON DELETE CASCADE,
+	CONSTRAINT fk_people_company_id FOREIGN KEY (company_id)
+		REFERENCES public.companies (id)
+		ON DELETE CASCADE
+);
+
+-- Indices
+CREATE INDEX IF NOT EXISTS idx_people_nationality_id ON public.people (nationality_id);
+CREATE INDEX IF NOT EXISTS idx_people_company_id ON public.people (company_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_people_first_name_last_name ON public.people (first_name, last_name);
+
+-- View definition for tag_entities
+
+CREATE OR REPLACE VIEW public.tag_entities AS
+SELECT
+	tags.color,
+	tags.id,
+	tags.name
+FROM public.tags;
+
+-- Table definition for tag_taggables
+
+CREATE TABLE IF NOT EXISTS public.tag_taggables (
+	id SERIAL PRIMARY KEY,
+	tag_id INTEGER NOT NULL,
+	taggable_type TEXT NOT NULL,
+	taggable_id INTEGER NOT NULL,
+	UNIQUE (tag_id, taggable_type, taggable_id),
+	CONSTRAINT fk_tag_taggables_tag_id FOREIGN KEY (tag_id)
+		REFERENCES public.tags (id)
+		ON DELETE CASCADE
+);
+
+-- Indices
+CREATE INDEX IF NOT EXISTS idx_tag_taggables_tag_id ON public.tag_taggables (tag_id);
+
+-- Table definition for contact_infos
+
+CREATE TABLE IF NOT EXISTS public.contact_infos (
+	email TEXT NOT NULL,
+	id SERIAL PRIMARY KEY,
+	person_id INTEGER NOT NULL,
+	CONSTRAINT fk_contact_infos_person_id FOREIGN KEY (person_id)
+		REFERENCES public.people (id)
+		ON DELETE CASCADE
+);
+
+-- Indices
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contact_infos_person_id ON public.contact_infos (person_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contact_infos_email ON public.contact_infos (email);
+
+-- View definition for person_entities
+
+CREATE OR REPLACE VIEW public.person_entities AS
+SELECT
+	contact_infos.email,
+	people.id,
+	people.last_name,
+	nationalities.key AS nationality
+FROM public.people
+LEFT JOIN public.contact_infos
+	ON contact_infos.person_id = people.id
+LEFT JOIN public.nationalities
+	ON nationalities.id = people.nationality_id;
+
@@ -7,8 +7,10 @@ SELECT
 	contact_infos.email,
 	people.id,
 	people.last_name,
-	people.nationality
+	nationalities.key AS nationality
 FROM public.people
 LEFT JOIN public.contact_infos
-	ON people.id = contact_infos.id;
+	ON contact_infos.person_id = people.id
+LEFT JOIN public.nationalities
+	ON nationalities.id = people.nationality_id;
 
@@ -7,6 +7,6 @@ CREATE TABLE IF NOT EXISTS public.comments (
 	created_at TEXT NOT NULL,
 	id SERIAL PRIMARY KEY,
 	commentable_type TEXT NOT NULL,
-	commentable_id TEXT NOT NULL
+	commentable_id INTEGER NOT NULL
 );
 
@@ -12,6 +12,6 @@ CREATE TABLE IF NOT EXISTS public.contact_infos (
 );
 
 -- Indices
-CREATE INDEX IF NOT EXISTS idx_contact_infos_person_id ON public.contact_infos (person_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contact_infos_person_id ON public.contact_infos (person_id);
 CREATE UNIQUE INDEX IF NOT EXISTS idx_contact_infos_email ON public.contact_infos (email);
 
@@ -4,9 +4,9 @@ CREATE SCHEMA IF NOT EXISTS public;
 
 CREATE TABLE IF NOT EXISTS public.tag_taggables (
 	id SERIAL PRIMARY KEY,
-	tag_id INTEGER,
-	taggable_type TEXT,
-	taggable_id TEXT,
+	tag_id INTEGER NOT NULL,
+	taggable_type TEXT NOT NULL,
+	taggable_id INTEGER NOT NULL,
 	UNIQUE (tag_id, taggable_type, taggable_id),
 	CONSTRAINT fk_tag_taggables_tag_id FOREIGN KEY (tag_id)
 		REFERENCES public.tags (id)